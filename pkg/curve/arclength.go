@@ -0,0 +1,250 @@
+package curve
+
+import (
+	"math"
+	"sort"
+)
+
+// ArcLength returns the length of c over its whole domain.
+func ArcLength[V Vector[V]](c Curve[V]) float64 {
+	start, end := c.Domain()
+	return ArcLengthBetween(c, start, end)
+}
+
+// ArcLengthBetween returns the length of c between parameters t0 and t1.
+// If t1 is less than t0, the result is negative.
+func ArcLengthBetween[V Vector[V]](c Curve[V], t0, t1 float64) float64 {
+	if t1 < t0 {
+		return -ArcLengthBetween(c, t1, t0)
+	}
+
+	// integrate each polynomial piece separately, as the speed may not be smooth where they join
+	var length float64
+	breaks := pieces(c)
+	for i := range len(breaks) - 1 {
+		a, b := math.Max(breaks[i], t0), math.Min(breaks[i+1], t1)
+		if a < b {
+			length += integrateSpeed(c, a, b)
+		}
+	}
+
+	return length
+}
+
+// SampleByArcLength returns n points spread along c such that the distance along the curve between
+// each consecutive pair is the same. The first and last points are the ends of the curve.
+//
+// If n is 1, the result is the start of the curve, and if n is less than 1 it is empty.
+func SampleByArcLength[V Vector[V]](c Curve[V], n int) []V {
+	if n < 1 {
+		return nil
+	}
+
+	start, _ := c.Domain()
+	if n == 1 {
+		return []V{c.At(start)}
+	}
+
+	a := NewArcLengthCurve(c)
+	samples := make([]V, n)
+	for i := range samples {
+		samples[i] = a.At(a.Length() * float64(i) / float64(n-1))
+	}
+
+	return samples
+}
+
+// ArcLengthCurve wraps a curve so that its parameter is the distance travelled along it. Moving the
+// parameter at a steady rate moves along the curve at a constant speed, whatever the speed of the
+// original curve.
+//
+// The domain runs from 0 to the length of the curve, and the derivative is always a unit vector, except
+// where the original curve stops moving.
+//
+// An ArcLengthCurve must be created with [NewArcLengthCurve].
+type ArcLengthCurve[V Vector[V]] struct {
+	table arcLengthTable[V]
+}
+
+// NewArcLengthCurve returns c reparameterised by arc length.
+func NewArcLengthCurve[V Vector[V]](c Curve[V]) ArcLengthCurve[V] {
+	return ArcLengthCurve[V]{newArcLengthTable(c)}
+}
+
+// Length returns the length of the curve.
+func (a ArcLengthCurve[V]) Length() float64 {
+	return a.table.length()
+}
+
+// Parameter returns the parameter of the original curve at distance s along it. Distances beyond either
+// end of the curve give the parameter of that end.
+func (a ArcLengthCurve[V]) Parameter(s float64) float64 {
+	return a.table.parameter(s)
+}
+
+// Domain returns the range of distances over which the curve is defined, which is [0, length].
+func (a ArcLengthCurve[V]) Domain() (start, end float64) {
+	return 0, a.Length()
+}
+
+// At returns the point at distance s along the curve. Distances outside the domain are clamped to it.
+func (a ArcLengthCurve[V]) At(s float64) V {
+	return a.table.c.At(a.Parameter(s))
+}
+
+// Derivative returns the unit tangent at distance s along the curve. Where the original curve has a
+// derivative of 0, it returns 0.
+func (a ArcLengthCurve[V]) Derivative(s float64) V {
+	d := a.table.c.Derivative(a.Parameter(s))
+
+	speed := d.Magnitude()
+	if speed == 0 {
+		return d
+	}
+
+	return d.Multiply(1 / speed)
+}
+
+// SecondDerivative returns the second derivative at distance s along the curve, which points towards the
+// centre of curvature with a length equal to the curvature. Where the original curve has a derivative of 0,
+// it returns 0.
+func (a ArcLengthCurve[V]) SecondDerivative(s float64) V {
+	t := a.Parameter(s)
+	d, dd := a.table.c.Derivative(t), a.table.c.SecondDerivative(t)
+
+	speed := d.Magnitude()
+	if speed == 0 {
+		return d
+	}
+
+	// by the chain rule, only the part of dd perpendicular to the direction of travel remains
+	perpendicular := dd.Subtract(d.Multiply(d.Dot(dd) / (speed * speed)))
+	return perpendicular.Multiply(1 / (speed * speed))
+}
+
+// breakpoints returns the distances at which the pieces of the original curve join.
+func (a ArcLengthCurve[V]) breakpoints() []float64 {
+	breaks := make([]float64, 0, len(a.table.lengths)/arcLengthIntervals+1)
+	for i := 0; i < len(a.table.lengths); i += arcLengthIntervals {
+		breaks = append(breaks, a.table.lengths[i])
+	}
+	return breaks
+}
+
+// The nodes and weights of 5 point Gauss-Legendre quadrature over [-1, 1].
+var (
+	gaussNodes   = [5]float64{0, -0.5384693101056831, 0.5384693101056831, -0.9061798459386640, 0.9061798459386640}
+	gaussWeights = [5]float64{0.5688888888888889, 0.4786286704993665, 0.4786286704993665, 0.2369268850561891, 0.2369268850561891}
+)
+
+// integrateSpeed returns the integral of the speed of c between a and b, using adaptive Gauss-Legendre quadrature.
+func integrateSpeed[V Vector[V]](c Curve[V], a, b float64) float64 {
+	gauss := func(a, b float64) float64 {
+		mid, half := (a+b)/2, (b-a)/2
+
+		var sum float64
+		for i, x := range gaussNodes {
+			sum += gaussWeights[i] * c.Derivative(mid+half*x).Magnitude()
+		}
+
+		return sum * half
+	}
+
+	// split the interval in two until the halves agree with the whole
+	var adapt func(a, b, whole float64, depth int) float64
+	adapt = func(a, b, whole float64, depth int) float64 {
+		mid := (a + b) / 2
+		left, right := gauss(a, mid), gauss(mid, b)
+
+		if depth == 0 || math.Abs(left+right-whole) <= 1e-12*math.Max(1, math.Abs(whole)) {
+			return left + right
+		}
+
+		return adapt(a, mid, left, depth-1) + adapt(mid, b, right, depth-1)
+	}
+
+	return adapt(a, b, gauss(a, b), 30)
+}
+
+// arcLengthIntervals is the number of intervals each piece of a curve is divided into when tabulating its length.
+const arcLengthIntervals = 16
+
+// arcLengthTable records the length of a curve up to a series of parameters, so that the parameter at any
+// distance along the curve can be found quickly.
+type arcLengthTable[V Vector[V]] struct {
+	c Curve[V]
+	// lengths[i] is the length of the curve from the start of its domain to params[i]
+	params, lengths []float64
+}
+
+func newArcLengthTable[V Vector[V]](c Curve[V]) arcLengthTable[V] {
+	breaks := pieces(c)
+	table := arcLengthTable[V]{
+		c:       c,
+		params:  []float64{breaks[0]},
+		lengths: []float64{0},
+	}
+
+	for i := range len(breaks) - 1 {
+		a, b := breaks[i], breaks[i+1]
+		for j := 1; j <= arcLengthIntervals; j++ {
+			prev := table.params[len(table.params)-1]
+			t := a + (b-a)*float64(j)/arcLengthIntervals
+			if j == arcLengthIntervals {
+				t = b
+			}
+
+			table.params = append(table.params, t)
+			table.lengths = append(table.lengths, table.lengths[len(table.lengths)-1]+integrateSpeed(c, prev, t))
+		}
+	}
+
+	return table
+}
+
+// length returns the total length of the curve.
+func (a arcLengthTable[V]) length() float64 {
+	return a.lengths[len(a.lengths)-1]
+}
+
+// parameter returns the parameter of the point at distance s along the curve from its start.
+// Distances beyond either end of the curve give the parameter of that end.
+func (a arcLengthTable[V]) parameter(s float64) float64 {
+	if s <= 0 {
+		return a.params[0]
+	}
+	if s >= a.length() {
+		return a.params[len(a.params)-1]
+	}
+
+	// find the tabulated interval containing s, then solve within it using Newton's method, falling
+	// back to bisection whenever a step would leave the interval
+	i := sort.SearchFloat64s(a.lengths, s)
+	if i > 0 {
+		i--
+	}
+	lo, hi := a.params[i], a.params[i+1]
+	base := a.lengths[i]
+
+	t := lo + (hi-lo)*(s-base)/(a.lengths[i+1]-base)
+	for range 50 {
+		g := base + integrateSpeed(a.c, a.params[i], t) - s
+		if math.Abs(g) <= 1e-12*math.Max(1, a.length()) {
+			break
+		}
+
+		if g > 0 {
+			hi = t
+		} else {
+			lo = t
+		}
+
+		next := t - g/a.c.Derivative(t).Magnitude()
+		if !(next > lo && next < hi) {
+			next = (lo + hi) / 2
+		}
+		t = next
+	}
+
+	return t
+}
@@ -0,0 +1,104 @@
+package curve
+
+// QuadraticBezier is a Bézier curve with three control points. It starts at P0 heading towards P1,
+// and ends at P2 arriving from the direction of P1. Its domain is [0, 1].
+type QuadraticBezier[V Vector[V]] struct {
+	P0, P1, P2 V
+}
+
+// Domain returns the range of parameters over which the curve is defined, which is always [0, 1].
+func (b QuadraticBezier[V]) Domain() (start, end float64) {
+	return 0, 1
+}
+
+// At returns the point on the curve at parameter t.
+func (b QuadraticBezier[V]) At(t float64) V {
+	s := 1 - t
+	return b.P0.Multiply(s * s).Add(b.P1.Multiply(2 * s * t)).Add(b.P2.Multiply(t * t))
+}
+
+// Derivative returns the first derivative of the curve at parameter t.
+func (b QuadraticBezier[V]) Derivative(t float64) V {
+	return b.P1.Subtract(b.P0).Multiply(2 * (1 - t)).Add(b.P2.Subtract(b.P1).Multiply(2 * t))
+}
+
+// SecondDerivative returns the second derivative of the curve, which is the same at every parameter.
+func (b QuadraticBezier[V]) SecondDerivative(t float64) V {
+	return b.P2.Subtract(b.P1.Multiply(2)).Add(b.P0).Multiply(2)
+}
+
+// Split divides the curve at parameter t using de Casteljau's algorithm, returning the part before t and
+// the part after it. Each part is a complete Bézier curve over [0, 1], and together they trace the same
+// path as the original.
+func (b QuadraticBezier[V]) Split(t float64) (left, right QuadraticBezier[V]) {
+	p01 := lerp(b.P0, b.P1, t)
+	p12 := lerp(b.P1, b.P2, t)
+	mid := lerp(p01, p12, t)
+
+	return QuadraticBezier[V]{b.P0, p01, mid}, QuadraticBezier[V]{mid, p12, b.P2}
+}
+
+func (b QuadraticBezier[V]) degree() int {
+	return 2
+}
+
+// CubicBezier is a Bézier curve with four control points. It starts at P0 heading towards P1, and ends
+// at P3 arriving from the direction of P2. Its domain is [0, 1].
+type CubicBezier[V Vector[V]] struct {
+	P0, P1, P2, P3 V
+}
+
+// Domain returns the range of parameters over which the curve is defined, which is always [0, 1].
+func (b CubicBezier[V]) Domain() (start, end float64) {
+	return 0, 1
+}
+
+// At returns the point on the curve at parameter t.
+func (b CubicBezier[V]) At(t float64) V {
+	s := 1 - t
+	return b.P0.Multiply(s * s * s).
+		Add(b.P1.Multiply(3 * s * s * t)).
+		Add(b.P2.Multiply(3 * s * t * t)).
+		Add(b.P3.Multiply(t * t * t))
+}
+
+// Derivative returns the first derivative of the curve at parameter t.
+func (b CubicBezier[V]) Derivative(t float64) V {
+	// the derivative of a cubic Bézier curve is a quadratic one, scaled by 3
+	return QuadraticBezier[V]{
+		b.P1.Subtract(b.P0),
+		b.P2.Subtract(b.P1),
+		b.P3.Subtract(b.P2),
+	}.At(t).Multiply(3)
+}
+
+// SecondDerivative returns the second derivative of the curve at parameter t.
+func (b CubicBezier[V]) SecondDerivative(t float64) V {
+	a := b.P2.Subtract(b.P1.Multiply(2)).Add(b.P0)
+	c := b.P3.Subtract(b.P2.Multiply(2)).Add(b.P1)
+
+	return lerp(a, c, t).Multiply(6)
+}
+
+// Split divides the curve at parameter t using de Casteljau's algorithm, returning the part before t and
+// the part after it. Each part is a complete Bézier curve over [0, 1], and together they trace the same
+// path as the original.
+func (b CubicBezier[V]) Split(t float64) (left, right CubicBezier[V]) {
+	p01 := lerp(b.P0, b.P1, t)
+	p12 := lerp(b.P1, b.P2, t)
+	p23 := lerp(b.P2, b.P3, t)
+	p012 := lerp(p01, p12, t)
+	p123 := lerp(p12, p23, t)
+	mid := lerp(p012, p123, t)
+
+	return CubicBezier[V]{b.P0, p01, p012, mid}, CubicBezier[V]{mid, p123, p23, b.P3}
+}
+
+func (b CubicBezier[V]) degree() int {
+	return 3
+}
+
+// lerp returns the point a fraction t of the way from v1 to v2.
+func lerp[V Vector[V]](v1, v2 V, t float64) V {
+	return v1.Add(v2.Subtract(v1).Multiply(t))
+}
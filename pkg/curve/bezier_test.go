@@ -0,0 +1,131 @@
+package curve
+
+import (
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// checkDerivatives compares the derivatives of c against central finite differences of At and Derivative.
+func checkDerivatives[V Vector[V]](t *testing.T, c Curve[V]) {
+	t.Helper()
+
+	const h = 1e-6
+	start, end := c.Domain()
+	for i := range 10 {
+		// keep away from knots, where B-spline derivatives may jump
+		u := start + (end-start)*(float64(i)+0.37)/10
+
+		d := c.At(u + h).Subtract(c.At(u - h)).Multiply(1 / (2 * h))
+		if got := c.Derivative(u); got.Subtract(d).Magnitude() > 1e-6*(1+d.Magnitude()) {
+			t.Errorf("Derivative(%v) = %v, want %v", u, got, d)
+		}
+
+		dd := c.Derivative(u + h).Subtract(c.Derivative(u - h)).Multiply(1 / (2 * h))
+		if got := c.SecondDerivative(u); got.Subtract(dd).Magnitude() > 1e-5*(1+dd.Magnitude()) {
+			t.Errorf("SecondDerivative(%v) = %v, want %v", u, got, dd)
+		}
+	}
+}
+
+// checkSplit checks that left and right trace the parts of whole before and after the parameter at.
+func checkSplit[V Vector[V]](t *testing.T, whole, left, right Curve[V], at float64, sameParameters bool) {
+	t.Helper()
+
+	start, end := whole.Domain()
+	for i := range 11 {
+		f := float64(i) / 10
+
+		want := whole.At(start + (at-start)*f)
+		u := f
+		if sameParameters {
+			u = start + (at-start)*f
+		}
+		if got := left.At(u); got.Subtract(want).Magnitude() > 1e-9 {
+			t.Errorf("left.At(%v) = %v, want %v", u, got, want)
+		}
+
+		want = whole.At(at + (end-at)*f)
+		u = f
+		if sameParameters {
+			u = at + (end-at)*f
+		}
+		if got := right.At(u); got.Subtract(want).Magnitude() > 1e-9 {
+			t.Errorf("right.At(%v) = %v, want %v", u, got, want)
+		}
+	}
+}
+
+func TestQuadraticBezier_At(t *testing.T) {
+	b := QuadraticBezier[vec.Vec2]{vec.Vec2{X: -1, Y: 1}, vec.Vec2{X: 0, Y: -1}, vec.Vec2{X: 1, Y: 1}}
+
+	tests := []struct {
+		name string
+		t    float64
+		want vec.Vec2
+	}{
+		{"start", 0, vec.Vec2{X: -1, Y: 1}},
+		{"middle", 0.5, vec.Vec2{X: 0, Y: 0}},
+		{"quarter", 0.25, vec.Vec2{X: -0.5, Y: 0.25}},
+		{"end", 1, vec.Vec2{X: 1, Y: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.At(tt.t); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("At(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuadraticBezier_Derivative(t *testing.T) {
+	checkDerivatives[vec.Vec2](t, QuadraticBezier[vec.Vec2]{vec.Vec2{X: -1, Y: 1}, vec.Vec2{X: 2, Y: -1}, vec.Vec2{X: 1, Y: 3}})
+	checkDerivatives[vec.Vec3](t, QuadraticBezier[vec.Vec3]{vec.Vec3{X: 0, Y: 1, Z: 2}, vec.Vec3{X: 3, Y: -1, Z: 0}, vec.Vec3{X: 1, Y: 1, Z: 5}})
+}
+
+func TestQuadraticBezier_Split(t *testing.T) {
+	b := QuadraticBezier[vec.Vec2]{vec.Vec2{X: -1, Y: 1}, vec.Vec2{X: 2, Y: -1}, vec.Vec2{X: 1, Y: 3}}
+
+	for _, at := range []float64{0.1, 0.5, 0.8} {
+		left, right := b.Split(at)
+		checkSplit[vec.Vec2](t, b, left, right, at, false)
+	}
+}
+
+func TestCubicBezier_At(t *testing.T) {
+	b := CubicBezier[vec.Vec2]{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 0, Y: 1}, vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 0}}
+
+	tests := []struct {
+		name string
+		t    float64
+		want vec.Vec2
+	}{
+		{"start", 0, vec.Vec2{X: 0, Y: 0}},
+		{"middle", 0.5, vec.Vec2{X: 0.5, Y: 0.75}},
+		{"quarter", 0.25, vec.Vec2{X: 0.15625, Y: 0.5625}},
+		{"end", 1, vec.Vec2{X: 1, Y: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.At(tt.t); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("At(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCubicBezier_Derivative(t *testing.T) {
+	checkDerivatives[vec.Vec2](t, CubicBezier[vec.Vec2]{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 0, Y: 1}, vec.Vec2{X: 3, Y: 1}, vec.Vec2{X: 1, Y: -2}})
+	checkDerivatives[vec.Vec3](t, CubicBezier[vec.Vec3]{vec.Vec3{X: 0, Y: 0, Z: 1}, vec.Vec3{X: 0, Y: 1, Z: 4}, vec.Vec3{X: 3, Y: 1, Z: -2}, vec.Vec3{X: 1, Y: -2, Z: 0}})
+}
+
+func TestCubicBezier_Split(t *testing.T) {
+	b := CubicBezier[vec.Vec3]{vec.Vec3{X: 0, Y: 0, Z: 1}, vec.Vec3{X: 0, Y: 1, Z: 4}, vec.Vec3{X: 3, Y: 1, Z: -2}, vec.Vec3{X: 1, Y: -2, Z: 0}}
+
+	for _, at := range []float64{0.1, 0.5, 0.8} {
+		left, right := b.Split(at)
+		checkSplit[vec.Vec3](t, b, left, right, at, false)
+	}
+}
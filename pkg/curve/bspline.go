@@ -0,0 +1,286 @@
+package curve
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// BSpline is a B-spline curve: a chain of polynomial pieces of the same degree, each shaped by a few
+// neighbouring control points and joined smoothly to the next. The knots decide how the parameter is
+// divided between the pieces.
+//
+// A BSpline must be created with [NewBSpline] or [NewUniformBSpline].
+type BSpline[V Vector[V]] struct {
+	spline bspline[V]
+	// first and second trace the first and second derivatives of spline
+	first, second bspline[V]
+}
+
+// NewBSpline returns a B-spline of the given degree, with control points and knots.
+//
+// There must be exactly len(points) + degree + 1 knots, in non-decreasing order, and at least degree + 1
+// control points. The domain of the curve runs from knots[degree] to knots[len(points)], which must not
+// be equal. To make the curve start and end at its first and last control points, repeat the first and
+// last knots degree + 1 times.
+//
+// If any of these conditions are not met, this function will return an error wrapping [vec.ErrInvalidArgument].
+func NewBSpline[V Vector[V]](degree int, points []V, knots []float64) (BSpline[V], error) {
+	if degree < 1 {
+		return BSpline[V]{}, vec.NewOpError("NewBSpline", fmt.Errorf("%w: degree must be at least 1, got %v", vec.ErrInvalidArgument, degree), degree)
+	}
+	if len(points) < degree+1 {
+		return BSpline[V]{}, vec.NewOpError("NewBSpline", fmt.Errorf("%w: a B-spline of degree %v needs at least %v control points, got %v", vec.ErrInvalidArgument, degree, degree+1, len(points)), degree)
+	}
+	if len(knots) != len(points)+degree+1 {
+		return BSpline[V]{}, vec.NewOpError("NewBSpline", fmt.Errorf("%w: a B-spline of degree %v with %v control points needs %v knots, got %v", vec.ErrInvalidArgument, degree, len(points), len(points)+degree+1, len(knots)), degree)
+	}
+	for i := 1; i < len(knots); i++ {
+		if knots[i] < knots[i-1] {
+			return BSpline[V]{}, vec.NewOpError("NewBSpline", fmt.Errorf("%w: knots must be non-decreasing, but knot %v is less than knot %v", vec.ErrInvalidArgument, i, i-1), degree)
+		}
+	}
+	if knots[degree] == knots[len(points)] {
+		return BSpline[V]{}, vec.NewOpError("NewBSpline", fmt.Errorf("%w: B-spline has an empty domain", vec.ErrInvalidArgument), degree)
+	}
+
+	return newBSpline(bspline[V]{
+		degree: degree,
+		points: slices.Clone(points),
+		knots:  slices.Clone(knots),
+	}), nil
+}
+
+// NewUniformBSpline returns a B-spline of the given degree through points, with knots evenly spaced one
+// apart.
+//
+// If clamped is true, the end knots are repeated so that the curve starts at the first control point and
+// ends at the last, and its domain is [0, len(points) - degree]. Otherwise the knots are 0, 1, 2, ... and
+// the domain is [degree, len(points)], with the curve starting and ending near, but not at, the end
+// control points.
+//
+// If degree is less than 1, or there are fewer than degree + 1 points, this function will return an error
+// wrapping [vec.ErrInvalidArgument].
+func NewUniformBSpline[V Vector[V]](degree int, points []V, clamped bool) (BSpline[V], error) {
+	if degree < 1 {
+		return BSpline[V]{}, vec.NewOpError("NewUniformBSpline", fmt.Errorf("%w: degree must be at least 1, got %v", vec.ErrInvalidArgument, degree), degree, clamped)
+	}
+	if len(points) < degree+1 {
+		return BSpline[V]{}, vec.NewOpError("NewUniformBSpline", fmt.Errorf("%w: a B-spline of degree %v needs at least %v control points, got %v", vec.ErrInvalidArgument, degree, degree+1, len(points)), degree, clamped)
+	}
+
+	knots := make([]float64, len(points)+degree+1)
+	for i := range knots {
+		knots[i] = float64(i)
+		if clamped {
+			knots[i] = float64(min(max(i-degree, 0), len(points)-degree))
+		}
+	}
+
+	return NewBSpline(degree, points, knots)
+}
+
+func newBSpline[V Vector[V]](spline bspline[V]) BSpline[V] {
+	first := spline.derivative()
+
+	return BSpline[V]{
+		spline: spline,
+		first:  first,
+		second: first.derivative(),
+	}
+}
+
+// Degree returns the degree of the polynomial pieces making up the curve.
+func (b BSpline[V]) Degree() int {
+	return b.spline.degree
+}
+
+// ControlPoints returns a copy of the curve's control points.
+func (b BSpline[V]) ControlPoints() []V {
+	return slices.Clone(b.spline.points)
+}
+
+// Knots returns a copy of the curve's knots.
+func (b BSpline[V]) Knots() []float64 {
+	return slices.Clone(b.spline.knots)
+}
+
+// Domain returns the range of parameters over which the curve is defined.
+func (b BSpline[V]) Domain() (start, end float64) {
+	return b.spline.domain()
+}
+
+// At returns the point on the curve at parameter t. Parameters outside the domain are clamped to it.
+func (b BSpline[V]) At(t float64) V {
+	return b.spline.at(t)
+}
+
+// Derivative returns the first derivative of the curve at parameter t. Where two pieces meet at a knot
+// and the derivative is discontinuous, this gives the derivative of the later piece.
+func (b BSpline[V]) Derivative(t float64) V {
+	return b.first.at(t)
+}
+
+// SecondDerivative returns the second derivative of the curve at parameter t. Where two pieces meet at a
+// knot and the second derivative is discontinuous, this gives the second derivative of the later piece.
+func (b BSpline[V]) SecondDerivative(t float64) V {
+	return b.second.at(t)
+}
+
+// Split divides the curve at parameter t, returning the part before t and the part after it. The parts
+// keep the parameters of the original curve, so left has the domain [start, t] and right [t, end].
+//
+// The split is made by inserting t as a knot until the curve passes through a control point there, using
+// Boehm's algorithm, so both parts have the same degree as the original.
+//
+// If t does not lie strictly inside the domain, this function will return an error wrapping [vec.ErrInvalidArgument].
+func (b BSpline[V]) Split(t float64) (left, right BSpline[V], err error) {
+	start, end := b.Domain()
+	if !(t > start && t < end) {
+		return BSpline[V]{}, BSpline[V]{}, vec.NewOpError("BSpline.Split", fmt.Errorf("%w: cannot split at %v, which is not strictly inside the domain [%v, %v]", vec.ErrInvalidArgument, t, start, end), t)
+	}
+
+	s := b.spline
+	p := s.degree
+	for s.multiplicity(t) < p {
+		s = s.insert(t)
+	}
+
+	// the knot t now appears p times from index a onwards, so the curve passes through point a-1 at t
+	a := sort.SearchFloat64s(s.knots, t)
+
+	leftKnots := append(slices.Clone(s.knots[:a+p]), t)
+	rightKnots := append([]float64{t}, s.knots[a:]...)
+
+	left = newBSpline(bspline[V]{degree: p, points: slices.Clone(s.points[:a]), knots: leftKnots})
+	right = newBSpline(bspline[V]{degree: p, points: slices.Clone(s.points[a-1:]), knots: rightKnots})
+
+	return left, right, nil
+}
+
+// breakpoints returns the distinct knots within the domain, which separate the polynomial pieces.
+func (b BSpline[V]) breakpoints() []float64 {
+	start, end := b.Domain()
+
+	var breaks []float64
+	for _, k := range b.spline.knots {
+		if k >= start && k <= end && (len(breaks) == 0 || k != breaks[len(breaks)-1]) {
+			breaks = append(breaks, k)
+		}
+	}
+
+	return breaks
+}
+
+func (b BSpline[V]) degree() int {
+	return b.spline.degree
+}
+
+// bspline holds the definition of a B-spline, without the derivatives cached by [BSpline].
+type bspline[V Vector[V]] struct {
+	degree int
+	points []V
+	knots  []float64
+}
+
+func (s bspline[V]) domain() (start, end float64) {
+	return s.knots[s.degree], s.knots[len(s.points)]
+}
+
+// span returns the index k of the knot interval [knots[k], knots[k+1]) containing t, restricted to
+// intervals of non-zero length within the domain.
+func (s bspline[V]) span(t float64) int {
+	// the last knot no greater than t
+	k := sort.Search(len(s.knots), func(i int) bool { return s.knots[i] > t }) - 1
+	k = min(max(k, s.degree), len(s.points)-1)
+
+	// at the very end of the domain, step back from any repeated knots onto the last real interval
+	for k > s.degree && s.knots[k] == s.knots[k+1] {
+		k--
+	}
+
+	return k
+}
+
+// at evaluates the spline at t using de Boor's algorithm.
+func (s bspline[V]) at(t float64) V {
+	start, end := s.domain()
+	t = min(max(t, start), end)
+
+	p := s.degree
+	k := s.span(t)
+
+	d := slices.Clone(s.points[k-p : k+1])
+	for r := 1; r <= p; r++ {
+		for j := p; j >= r; j-- {
+			i := j + k - p
+			alpha := (t - s.knots[i]) / (s.knots[i+1+p-r] - s.knots[i])
+			d[j] = lerp(d[j-1], d[j], alpha)
+		}
+	}
+
+	return d[p]
+}
+
+// derivative returns the spline of one degree lower that traces the derivative of s. The derivative of a
+// spline of degree 0 is 0 everywhere.
+func (s bspline[V]) derivative() bspline[V] {
+	p := s.degree
+
+	if p == 0 {
+		return bspline[V]{degree: 0, points: make([]V, len(s.points)), knots: s.knots}
+	}
+
+	points := make([]V, len(s.points)-1)
+	for i := range points {
+		// where the knots coincide the basis function is 0, so the control point has no effect
+		if span := s.knots[i+p+1] - s.knots[i+1]; span != 0 {
+			points[i] = s.points[i+1].Subtract(s.points[i]).Multiply(float64(p) / span)
+		}
+	}
+
+	return bspline[V]{
+		degree: p - 1,
+		points: points,
+		knots:  s.knots[1 : len(s.knots)-1],
+	}
+}
+
+// multiplicity returns the number of times t appears in the knots.
+func (s bspline[V]) multiplicity(t float64) int {
+	count := 0
+	for _, k := range s.knots {
+		if k == t {
+			count++
+		}
+	}
+	return count
+}
+
+// insert returns an equivalent spline with t added as an extra knot, using Boehm's algorithm.
+func (s bspline[V]) insert(t float64) bspline[V] {
+	p := s.degree
+	k := s.span(t)
+
+	points := make([]V, len(s.points)+1)
+	for i := range points {
+		switch {
+		case i <= k-p:
+			points[i] = s.points[i]
+		case i <= k:
+			alpha := (t - s.knots[i]) / (s.knots[i+p] - s.knots[i])
+			points[i] = lerp(s.points[i-1], s.points[i], alpha)
+		default:
+			points[i] = s.points[i-1]
+		}
+	}
+
+	knots := make([]float64, 0, len(s.knots)+1)
+	knots = append(knots, s.knots[:k+1]...)
+	knots = append(knots, t)
+	knots = append(knots, s.knots[k+1:]...)
+
+	return bspline[V]{degree: p, points: points, knots: knots}
+}
@@ -0,0 +1,157 @@
+package curve
+
+import (
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+var zigzag = []vec.Vec2{{X: 0, Y: 0}, {X: 1, Y: 2}, {X: 2, Y: -1}, {X: 4, Y: 1}, {X: 5, Y: 3}, {X: 7, Y: 0}}
+
+func TestNewBSpline(t *testing.T) {
+	tests := []struct {
+		name    string
+		degree  int
+		points  []vec.Vec2
+		knots   []float64
+		wantErr bool
+	}{
+		{"clamped cubic", 3, zigzag, []float64{0, 0, 0, 0, 1, 2, 3, 3, 3, 3}, false},
+		{"non-uniform", 2, zigzag, []float64{0, 0, 0, 0.5, 2, 2, 3, 3, 3}, false},
+		{"degree 0", 0, zigzag, []float64{0, 1, 2, 3, 4, 5, 6}, true},
+		{"too few points", 3, zigzag[:3], []float64{0, 1, 2, 3, 4, 5, 6}, true},
+		{"too few knots", 3, zigzag, []float64{0, 0, 0, 0, 1, 2, 3, 3, 3}, true},
+		{"decreasing knots", 2, zigzag, []float64{0, 0, 0, 2, 1, 3, 3, 3, 3}, true},
+		{"empty domain", 2, zigzag, []float64{0, 0, 0, 0, 0, 0, 0, 1, 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBSpline(tt.degree, tt.points, tt.knots)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBSpline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewUniformBSpline(t *testing.T) {
+	tests := []struct {
+		name      string
+		degree    int
+		clamped   bool
+		wantKnots []float64
+	}{
+		{"clamped", 3, true, []float64{0, 0, 0, 0, 1, 2, 3, 3, 3, 3}},
+		{"unclamped", 3, false, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"clamped linear", 1, true, []float64{0, 0, 1, 2, 3, 4, 5, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewUniformBSpline(tt.degree, zigzag, tt.clamped)
+			if err != nil {
+				t.Fatalf("NewUniformBSpline() error = %v", err)
+			}
+
+			got := b.Knots()
+			if len(got) != len(tt.wantKnots) {
+				t.Fatalf("Knots() = %v, want %v", got, tt.wantKnots)
+			}
+			for i := range got {
+				if got[i] != tt.wantKnots[i] {
+					t.Fatalf("Knots() = %v, want %v", got, tt.wantKnots)
+				}
+			}
+		})
+	}
+}
+
+func TestBSpline_At(t *testing.T) {
+	bezier := CubicBezier[vec.Vec2]{zigzag[0], zigzag[1], zigzag[2], zigzag[3]}
+	clampedCubic, _ := NewUniformBSpline(3, zigzag[:4], true)
+	linear, _ := NewUniformBSpline(1, zigzag, true)
+	unclamped, _ := NewUniformBSpline(3, zigzag, false)
+
+	tests := []struct {
+		name string
+		b    BSpline[vec.Vec2]
+		t    float64
+		want vec.Vec2
+	}{
+		{"single piece is a Bézier curve", clampedCubic, 0.3, bezier.At(0.3)},
+		{"clamped start", clampedCubic, 0, zigzag[0]},
+		{"clamped end", clampedCubic, 1, zigzag[3]},
+		{"linear at knot", linear, 2, zigzag[2]},
+		{"linear between knots", linear, 3.5, vec.Vec2{X: 4.5, Y: 2}},
+		{"linear end", linear, 5, zigzag[5]},
+		// an unclamped uniform cubic starts at (P0 + 4 P1 + P2) / 6
+		{"unclamped start", unclamped, 3, zigzag[0].Add(zigzag[1].Multiply(4)).Add(zigzag[2]).Multiply(1.0 / 6)},
+		{"unclamped end", unclamped, 6, zigzag[3].Add(zigzag[4].Multiply(4)).Add(zigzag[5]).Multiply(1.0 / 6)},
+		{"before the domain", linear, -1, zigzag[0]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.At(tt.t); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("At(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBSpline_Derivative(t *testing.T) {
+	uniform, _ := NewUniformBSpline(3, zigzag, true)
+	unclamped, _ := NewUniformBSpline(3, zigzag, false)
+	nonUniform, _ := NewBSpline(2, zigzag, []float64{0, 0, 0, 0.5, 2, 2.25, 3, 3, 3})
+	space, _ := NewUniformBSpline(2, []vec.Vec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 2, Z: 1}, {X: 2, Y: 0, Z: 3}, {X: 3, Y: 1, Z: 1}}, true)
+
+	checkDerivatives[vec.Vec2](t, uniform)
+	checkDerivatives[vec.Vec2](t, unclamped)
+	checkDerivatives[vec.Vec2](t, nonUniform)
+	checkDerivatives[vec.Vec3](t, space)
+}
+
+func TestBSpline_Split(t *testing.T) {
+	uniform, _ := NewUniformBSpline(3, zigzag, true)
+	unclamped, _ := NewUniformBSpline(3, zigzag, false)
+	nonUniform, _ := NewBSpline(2, zigzag, []float64{0, 0, 0, 0.5, 2, 2.25, 3, 3, 3})
+
+	tests := []struct {
+		name    string
+		b       BSpline[vec.Vec2]
+		at      float64
+		wantErr bool
+	}{
+		{"between knots", uniform, 1.3, false},
+		{"at a knot", uniform, 2, false},
+		{"unclamped", unclamped, 4.5, false},
+		{"non-uniform", nonUniform, 2.1, false},
+		{"at the start", uniform, 0, true},
+		{"after the end", uniform, 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right, err := tt.b.Split(tt.at)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if _, end := left.Domain(); end != tt.at {
+				t.Errorf("left domain ends at %v, want %v", end, tt.at)
+			}
+			if start, _ := right.Domain(); start != tt.at {
+				t.Errorf("right domain starts at %v, want %v", start, tt.at)
+			}
+			if left.Degree() != tt.b.Degree() || right.Degree() != tt.b.Degree() {
+				t.Errorf("split degrees = %v, %v, want %v", left.Degree(), right.Degree(), tt.b.Degree())
+			}
+
+			checkSplit[vec.Vec2](t, tt.b, left, right, tt.at, true)
+		})
+	}
+}
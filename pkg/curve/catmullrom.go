@@ -0,0 +1,118 @@
+package curve
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Parameterisation controls how a [CatmullRom] spline spaces its waypoints in parameter space, which
+// changes the shape of the curve between them.
+type Parameterisation float64
+
+const (
+	// Uniform spaces every waypoint equally. Where waypoints are unevenly spread, the curve can overshoot
+	// and form loops or cusps.
+	Uniform Parameterisation = 0
+	// Centripetal spaces waypoints by the square root of the distance between them. The curve never forms
+	// loops or cusps within a segment, and follows the waypoints tightly.
+	Centripetal Parameterisation = 0.5
+	// Chordal spaces waypoints by the distance between them, giving rounder curves around sharp corners.
+	Chordal Parameterisation = 1
+)
+
+// CatmullRom is a Catmull-Rom spline: a smooth curve passing through every waypoint, with the direction at
+// each waypoint set by its neighbours. Segment i runs from waypoint i to waypoint i+1 as the parameter goes
+// from i to i+1, so the domain is [0, n-1] for n waypoints.
+//
+// A CatmullRom must be created with [NewCatmullRom].
+type CatmullRom[V Vector[V]] struct {
+	pieces hermitePieces[V]
+}
+
+// NewCatmullRom returns a Catmull-Rom spline through waypoints, with the given parameterisation.
+// The ends of the curve are shaped by extending the first and last segments in a straight line.
+//
+// If there are fewer than 2 waypoints, or two consecutive waypoints are the same, this function will
+// return an error wrapping [vec.ErrInvalidArgument].
+func NewCatmullRom[V Vector[V]](waypoints []V, parameterisation Parameterisation) (CatmullRom[V], error) {
+	n := len(waypoints)
+	if n < 2 {
+		return CatmullRom[V]{}, vec.NewOpError("NewCatmullRom", fmt.Errorf("%w: a Catmull-Rom spline needs at least 2 waypoints, got %v", vec.ErrInvalidArgument, n), parameterisation)
+	}
+	for i := 1; i < n; i++ {
+		if waypoints[i].Subtract(waypoints[i-1]).Magnitude() == 0 {
+			return CatmullRom[V]{}, vec.NewOpError("NewCatmullRom", fmt.Errorf("%w: waypoints %v and %v are the same", vec.ErrInvalidArgument, i-1, i), parameterisation)
+		}
+	}
+
+	// phantom waypoints beyond each end, continuing the first and last segments
+	extended := make([]V, 0, n+2)
+	extended = append(extended, waypoints[0].Multiply(2).Subtract(waypoints[1]))
+	extended = append(extended, waypoints...)
+	extended = append(extended, waypoints[n-1].Multiply(2).Subtract(waypoints[n-2]))
+
+	// interval[i] is the spacing in parameter space between extended[i] and extended[i+1]
+	interval := make([]float64, n+1)
+	for i := range interval {
+		interval[i] = math.Pow(extended[i+1].Subtract(extended[i]).Magnitude(), float64(parameterisation))
+	}
+
+	pieces := hermitePieces[V]{
+		points: slices.Clone(waypoints),
+		out:    make([]V, n-1),
+		in:     make([]V, n-1),
+	}
+
+	for i := range n - 1 {
+		p0, p1, p2, p3 := extended[i], extended[i+1], extended[i+2], extended[i+3]
+		d0, d1, d2 := interval[i], interval[i+1], interval[i+2]
+
+		// the tangents at p1 and p2 with respect to the spacing parameter, following Barry and Goldman's
+		// pyramidal formulation, then scaled so the segment runs over a parameter interval of 1
+		m1 := p1.Subtract(p0).Multiply(1 / d0).
+			Subtract(p2.Subtract(p0).Multiply(1 / (d0 + d1))).
+			Add(p2.Subtract(p1).Multiply(1 / d1))
+		m2 := p2.Subtract(p1).Multiply(1 / d1).
+			Subtract(p3.Subtract(p1).Multiply(1 / (d1 + d2))).
+			Add(p3.Subtract(p2).Multiply(1 / d2))
+
+		pieces.out[i] = m1.Multiply(d1)
+		pieces.in[i] = m2.Multiply(d1)
+	}
+
+	return CatmullRom[V]{pieces}, nil
+}
+
+// Domain returns the range of parameters over which the curve is defined, which is [0, n-1] for n waypoints.
+func (c CatmullRom[V]) Domain() (start, end float64) {
+	return c.pieces.domain()
+}
+
+// At returns the point on the curve at parameter t. Parameters outside the domain are clamped to it.
+func (c CatmullRom[V]) At(t float64) V {
+	return c.pieces.at(t)
+}
+
+// Derivative returns the first derivative of the curve at parameter t. Unless the parameterisation is
+// [Uniform], the speed usually changes suddenly at each waypoint, though the direction does not. There,
+// this gives the derivative of the later segment.
+func (c CatmullRom[V]) Derivative(t float64) V {
+	return c.pieces.derivative(t)
+}
+
+// SecondDerivative returns the second derivative of the curve at parameter t. This is usually discontinuous
+// at the waypoints, where it gives the second derivative of the later segment.
+func (c CatmullRom[V]) SecondDerivative(t float64) V {
+	return c.pieces.secondDerivative(t)
+}
+
+func (c CatmullRom[V]) breakpoints() []float64 {
+	return c.pieces.breakpoints()
+}
+
+func (c CatmullRom[V]) degree() int {
+	return 3
+}
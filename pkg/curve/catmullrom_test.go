@@ -0,0 +1,130 @@
+package curve
+
+import (
+	"math"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+var waypoints = []vec.Vec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 1, Y: 5, Z: 1}, {X: 6, Y: 5, Z: 0}, {X: 6.5, Y: 5.5, Z: 0}}
+
+func TestNewCatmullRom(t *testing.T) {
+	tests := []struct {
+		name      string
+		waypoints []vec.Vec3
+		wantErr   bool
+	}{
+		{"valid", waypoints, false},
+		{"two waypoints", waypoints[:2], false},
+		{"one waypoint", waypoints[:1], true},
+		{"repeated waypoint", []vec.Vec3{waypoints[0], waypoints[1], waypoints[1], waypoints[2]}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, p := range []Parameterisation{Uniform, Centripetal, Chordal} {
+				_, err := NewCatmullRom(tt.waypoints, p)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("NewCatmullRom(%v) error = %v, wantErr %v", p, err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestCatmullRom_At(t *testing.T) {
+	tests := []struct {
+		name             string
+		parameterisation Parameterisation
+	}{
+		{"uniform", Uniform},
+		{"centripetal", Centripetal},
+		{"chordal", Chordal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCatmullRom(waypoints, tt.parameterisation)
+			if err != nil {
+				t.Fatalf("NewCatmullRom() error = %v", err)
+			}
+
+			if start, end := c.Domain(); start != 0 || end != 4 {
+				t.Errorf("Domain() = %v, %v, want 0, 4", start, end)
+			}
+
+			// the curve passes through every waypoint, changing speed but not direction
+			for i, p := range waypoints {
+				if got := c.At(float64(i)); !got.AlmostEquals(p, 1e-12) {
+					t.Errorf("At(%v) = %v, want %v", i, got, p)
+				}
+
+				if i == 0 || i == len(waypoints)-1 {
+					continue
+				}
+				before, _ := Tangent[vec.Vec3](c, float64(i)-1e-9)
+				after, _ := Tangent[vec.Vec3](c, float64(i))
+				if !before.AlmostEquals(after, 1e-6) {
+					t.Errorf("tangent jumps from %v to %v at waypoint %v", before, after, i)
+				}
+			}
+
+			checkDerivatives[vec.Vec3](t, c)
+		})
+	}
+}
+
+func TestCatmullRom_Derivative(t *testing.T) {
+	// the tangent of a uniform Catmull-Rom spline at each waypoint is half the vector between its neighbours
+	c, _ := NewCatmullRom(waypoints, Uniform)
+
+	for i := 1; i < len(waypoints)-1; i++ {
+		want := waypoints[i+1].Subtract(waypoints[i-1]).Multiply(0.5)
+		if got := c.Derivative(float64(i)); !got.AlmostEquals(want, 1e-12) {
+			t.Errorf("Derivative(%v) = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestCatmullRom_Loops(t *testing.T) {
+	// a short segment between long ones makes a uniform spline overshoot into a loop, which the others avoid
+	uneven := []vec.Vec2{{X: -10, Y: 0}, {X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 5}}
+
+	tests := []struct {
+		name             string
+		parameterisation Parameterisation
+		wantLoop         bool
+	}{
+		{"uniform", Uniform, true},
+		{"centripetal", Centripetal, false},
+		{"chordal", Chordal, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := NewCatmullRom(uneven, tt.parameterisation)
+
+			// a loop in the middle segment makes the curve run backwards along it at some point
+			chord := uneven[2].Subtract(uneven[1])
+			loop := false
+			for i := range 101 {
+				if c.Derivative(1+float64(i)/100).Dot(chord) < 0 {
+					loop = true
+				}
+			}
+			if loop != tt.wantLoop {
+				t.Errorf("loop = %v, want %v", loop, tt.wantLoop)
+			}
+		})
+	}
+}
+
+func TestCatmullRom_ArcLength(t *testing.T) {
+	// evenly spaced collinear waypoints give a straight line
+	c, _ := NewCatmullRom([]vec.Vec2{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}}, Centripetal)
+
+	if got, want := ArcLength[vec.Vec2](c), 3*math.Sqrt2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("ArcLength() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,328 @@
+package curve
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Vector is the set of operations curves need from the points they are built from.
+// [vec.Vec2] and [vec.Vec3] both satisfy it.
+type Vector[V any] interface {
+	Add(V) V
+	Subtract(V) V
+	Multiply(float64) V
+	Dot(V) float64
+	Magnitude() float64
+}
+
+// Curve is a parametric curve through the space of V.
+//
+// The functions in this package that operate on any Curve, such as [ArcLength] and [Tangent], only
+// need these four methods, so they also work with curves defined outside this package.
+type Curve[V Vector[V]] interface {
+	// Domain returns the range of parameters over which the curve is defined.
+	Domain() (start, end float64)
+	// At returns the point on the curve at parameter t.
+	At(t float64) V
+	// Derivative returns the first derivative of the curve with respect to t, which points along the
+	// curve with a length equal to the speed at which At moves as t increases.
+	Derivative(t float64) V
+	// SecondDerivative returns the second derivative of the curve with respect to t.
+	SecondDerivative(t float64) V
+}
+
+// piecewise is implemented by curves made up of several polynomial pieces, to report where one piece
+// ends and the next begins. Numerical methods treat each piece separately for accuracy.
+type piecewise interface {
+	breakpoints() []float64
+}
+
+// polynomial is implemented by curves whose pieces are each polynomials of at most the given degree.
+type polynomial interface {
+	degree() int
+}
+
+// Tangent returns the unit vector pointing along c at parameter t, in the direction of increasing t.
+//
+// Where the derivative of c is 0, such as at a cusp, there is no well-defined direction and this function
+// will return an error wrapping [vec.ErrZeroLength].
+func Tangent[V Vector[V]](c Curve[V], t float64) (V, error) {
+	d := c.Derivative(t)
+	speed := d.Magnitude()
+
+	if speed == 0 {
+		var zero V
+		return zero, vec.NewOpError("Tangent", fmt.Errorf("%w: curve has a derivative of 0", vec.ErrZeroLength), t)
+	}
+
+	return d.Multiply(1 / speed), nil
+}
+
+// Curvature returns the curvature of c at parameter t, which is the reciprocal of the radius of the circle
+// that best fits the curve there. Straight sections have a curvature of 0.
+//
+// Where the derivative of c is 0, curvature is undefined and this function will return an error wrapping [vec.ErrZeroLength].
+func Curvature[V Vector[V]](c Curve[V], t float64) (float64, error) {
+	d := c.Derivative(t)
+	dd := c.SecondDerivative(t)
+	speed := d.Magnitude()
+
+	if speed == 0 {
+		return 0, vec.NewOpError("Curvature", fmt.Errorf("%w: curve has a derivative of 0", vec.ErrZeroLength), t)
+	}
+
+	// |d x dd| / |d|^3, written without a cross product so it works in any dimension: only the part of
+	// dd perpendicular to d bends the curve
+	perpendicular := dd.Subtract(d.Multiply(d.Dot(dd) / (speed * speed)))
+
+	return perpendicular.Magnitude() / (speed * speed), nil
+}
+
+// Bounds2 returns the smallest rectangle containing every point of c.
+//
+// For the curves in this package, which are made of polynomial pieces, the extremes are found exactly. Other
+// curves are sampled, and the rectangle may miss extremes narrower than the gap between samples.
+func Bounds2(c Curve[vec.Vec2]) geom.Rect {
+	xLo, xHi := extent(c, vec.Vec2{X: 1})
+	yLo, yHi := extent(c, vec.Vec2{Y: 1})
+
+	return geom.Rect{
+		Min: vec.Vec2{X: xLo, Y: yLo},
+		Max: vec.Vec2{X: xHi, Y: yHi},
+	}
+}
+
+// Bounds3 returns the smallest box containing every point of c.
+//
+// As with [Bounds2], this is exact for the curves in this package, but other curves are sampled, and the
+// box may miss extremes narrower than the gap between samples.
+func Bounds3(c Curve[vec.Vec3]) geom.AABB3 {
+	xLo, xHi := extent(c, vec.Vec3{X: 1})
+	yLo, yHi := extent(c, vec.Vec3{Y: 1})
+	zLo, zHi := extent(c, vec.Vec3{Z: 1})
+
+	return geom.AABB3{
+		Min: vec.Vec3{X: xLo, Y: yLo, Z: zLo},
+		Max: vec.Vec3{X: xHi, Y: yHi, Z: zHi},
+	}
+}
+
+// extentSamples is the number of intervals each piece of a curve of unknown form is divided into when
+// searching for extremes.
+const extentSamples = 64
+
+// extent returns the lowest and highest values of c(t) . axis.
+//
+// The extremes lie either at the ends of the pieces of the curve or where the derivative is perpendicular
+// to axis. Where each piece is a polynomial of known degree, so is c'(t) . axis, and its roots are solved
+// for directly. Otherwise, each piece is sampled, and the extremes are narrowed down around any sample that
+// is higher or lower than both its neighbours, and any change in the sign of the derivative between samples.
+func extent[V Vector[V]](c Curve[V], axis V) (lo, hi float64) {
+	start, _ := c.Domain()
+	lo = c.At(start).Dot(axis)
+	hi = lo
+
+	value := func(t float64) float64 { return c.At(t).Dot(axis) }
+	slope := func(t float64) float64 { return c.Derivative(t).Dot(axis) }
+	consider := func(t float64) {
+		x := value(t)
+		lo, hi = math.Min(lo, x), math.Max(hi, x)
+	}
+
+	breaks := pieces(c)
+	for i := range len(breaks) - 1 {
+		a, b := breaks[i], breaks[i+1]
+		consider(b)
+
+		if p, ok := c.(polynomial); ok {
+			fit := interpolate(func(u float64) float64 { return slope(a + (b-a)*u) }, p.degree()-1)
+			for _, u := range roots(fit) {
+				consider(a + (b-a)*u)
+			}
+			continue
+		}
+
+		ts := make([]float64, extentSamples+1)
+		xs := make([]float64, extentSamples+1)
+		for j := range ts {
+			ts[j] = a + (b-a)*float64(j)/extentSamples
+			if j == extentSamples {
+				ts[j] = b
+			}
+			xs[j] = value(ts[j])
+			consider(ts[j])
+		}
+
+		for j := 1; j <= extentSamples; j++ {
+			if d0, d1 := slope(ts[j-1]), slope(ts[j]); (d0 < 0 && d1 > 0) || (d0 > 0 && d1 < 0) {
+				consider(bisect(slope, ts[j-1], ts[j]))
+			}
+			if j < extentSamples {
+				if xs[j] >= xs[j-1] && xs[j] >= xs[j+1] {
+					consider(goldenSection(value, ts[j-1], ts[j+1]))
+				}
+				if xs[j] <= xs[j-1] && xs[j] <= xs[j+1] {
+					consider(goldenSection(func(t float64) float64 { return -value(t) }, ts[j-1], ts[j+1]))
+				}
+			}
+		}
+	}
+
+	return lo, hi
+}
+
+// bisect returns a root of f between a and b, where f(a) and f(b) have opposite signs.
+func bisect(f func(float64) float64, a, b float64) float64 {
+	fa := f(a)
+
+	for range 100 {
+		mid := (a + b) / 2
+		if mid <= a || mid >= b {
+			break
+		}
+
+		if fm := f(mid); (fm < 0) == (fa < 0) {
+			a, fa = mid, fm
+		} else {
+			b = mid
+		}
+	}
+
+	return (a + b) / 2
+}
+
+// goldenSection returns the parameter between a and b at which f is highest, assuming f has a single peak there.
+func goldenSection(f func(float64) float64, a, b float64) float64 {
+	ratio := (math.Sqrt(5) - 1) / 2
+
+	c, d := b-ratio*(b-a), a+ratio*(b-a)
+	fc, fd := f(c), f(d)
+	for range 100 {
+		if fc > fd {
+			b, d, fd = d, c, fc
+			c = b - ratio*(b-a)
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + ratio*(b-a)
+			fd = f(d)
+		}
+	}
+
+	return (a + b) / 2
+}
+
+// interpolate returns the coefficients, lowest power first, of the polynomial of degree n that agrees with f
+// at n+1 points between 0 and 1. If f is itself such a polynomial, this recovers it up to rounding.
+func interpolate(f func(float64) float64, n int) []float64 {
+	if n < 0 {
+		return nil
+	}
+
+	// Chebyshev nodes keep the fit well conditioned, and avoid the ends of the interval, where a piecewise
+	// curve's derivative may belong to the neighbouring piece
+	xs := make([]float64, n+1)
+	diffs := make([]float64, n+1)
+	for i := range xs {
+		xs[i] = (1 - math.Cos(float64(2*i+1)*math.Pi/float64(2*n+2))) / 2
+		diffs[i] = f(xs[i])
+	}
+
+	// Newton's divided differences, then expanded from the highest term down into powers of x
+	for j := 1; j <= n; j++ {
+		for i := n; i >= j; i-- {
+			diffs[i] = (diffs[i] - diffs[i-1]) / (xs[i] - xs[i-j])
+		}
+	}
+
+	poly := make([]float64, n+1)
+	poly[0] = diffs[n]
+	for i := n - 1; i >= 0; i-- {
+		for k := n; k > 0; k-- {
+			poly[k] = poly[k-1] - xs[i]*poly[k]
+		}
+		poly[0] = diffs[i] - xs[i]*poly[0]
+	}
+
+	return poly
+}
+
+// roots returns, in increasing order, the roots of the polynomial with coefficients poly, lowest power first,
+// that lie strictly between 0 and 1.
+func roots(poly []float64) []float64 {
+	n := len(poly) - 1
+	for n > 0 && poly[n] == 0 {
+		n--
+	}
+
+	inside := func(xs ...float64) []float64 {
+		var found []float64
+		for _, x := range xs {
+			if x > 0 && x < 1 {
+				found = append(found, x)
+			}
+		}
+		slices.Sort(found)
+		return found
+	}
+
+	switch {
+	case n <= 0:
+		return nil
+	case n == 1:
+		return inside(-poly[0] / poly[1])
+	case n == 2:
+		a, b, c := poly[2], poly[1], poly[0]
+		disc := b*b - 4*a*c
+		if disc < 0 {
+			return nil
+		}
+
+		// the form that avoids subtracting nearly equal values, which loses precision when a is small
+		q := -(b + math.Copysign(math.Sqrt(disc), b)) / 2
+		if q == 0 {
+			return nil
+		}
+		return inside(q/a, c/q)
+	}
+
+	// between consecutive roots of the derivative, the polynomial only rises or only falls, so crosses 0
+	// at most once
+	derivative := make([]float64, n)
+	for k := 1; k <= n; k++ {
+		derivative[k-1] = float64(k) * poly[k]
+	}
+
+	eval := func(x float64) float64 {
+		var y float64
+		for k := n; k >= 0; k-- {
+			y = y*x + poly[k]
+		}
+		return y
+	}
+
+	ends := append(append([]float64{0}, roots(derivative)...), 1)
+	var found []float64
+	for i := range len(ends) - 1 {
+		if ya, yb := eval(ends[i]), eval(ends[i+1]); (ya < 0 && yb > 0) || (ya > 0 && yb < 0) {
+			found = append(found, bisect(eval, ends[i], ends[i+1]))
+		}
+	}
+
+	return found
+}
+
+// pieces returns the parameters dividing c into its polynomial pieces, from the start of its domain to the end.
+// Curves that don't report their pieces are treated as one piece.
+func pieces[V Vector[V]](c Curve[V]) []float64 {
+	if p, ok := c.(piecewise); ok {
+		return p.breakpoints()
+	}
+
+	start, end := c.Domain()
+	return []float64{start, end}
+}
@@ -0,0 +1,268 @@
+package curve
+
+import (
+	"math"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// parabola traces y = x^2 for x from -1 to 1.
+var parabola = QuadraticBezier[vec.Vec2]{vec.Vec2{X: -1, Y: 1}, vec.Vec2{X: 0, Y: -1}, vec.Vec2{X: 1, Y: 1}}
+
+// unevenLine runs along the X axis from 0 to 3, speeding up as it goes.
+var unevenLine = CubicBezier[vec.Vec2]{vec.Vec2{X: 0}, vec.Vec2{X: 0.1}, vec.Vec2{X: 0.2}, vec.Vec2{X: 3}}
+
+func TestTangent(t *testing.T) {
+	point := CubicBezier[vec.Vec2]{vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 1}}
+
+	tests := []struct {
+		name    string
+		c       Curve[vec.Vec2]
+		t       float64
+		want    vec.Vec2
+		wantErr bool
+	}{
+		{"vertex of parabola", parabola, 0.5, vec.Vec2{X: 1, Y: 0}, false},
+		{"start of parabola", parabola, 0, vec.Vec2{X: 1 / math.Sqrt(5), Y: -2 / math.Sqrt(5)}, false},
+		{"line", unevenLine, 0.7, vec.Vec2{X: 1}, false},
+		{"single point", point, 0.5, vec.Vec2{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Tangent(tt.c, tt.t)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Tangent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("Tangent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurvature(t *testing.T) {
+	point := QuadraticBezier[vec.Vec2]{vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 1}}
+	helix, _ := NewUniformBSpline(1, []vec.Vec2{{X: 0, Y: 0}, {X: 1, Y: 1}}, true)
+
+	tests := []struct {
+		name    string
+		c       Curve[vec.Vec2]
+		t       float64
+		want    float64
+		wantErr bool
+	}{
+		{"vertex of parabola", parabola, 0.5, 2, false},
+		// y = x^2 at x = 1 has curvature 2 / 5^(3/2)
+		{"end of parabola", parabola, 1, 2 / math.Pow(5, 1.5), false},
+		{"line", unevenLine, 0.3, 0, false},
+		{"polyline", helix, 0.3, 0, false},
+		{"single point", point, 0.5, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Curvature(tt.c, tt.t)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Curvature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Curvature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBounds2(t *testing.T) {
+	arch := CubicBezier[vec.Vec2]{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 0, Y: 1}, vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 0}}
+	loop := CubicBezier[vec.Vec2]{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 4, Y: 2}, vec.Vec2{X: -3, Y: 2}, vec.Vec2{X: 1, Y: 0}}
+	polyline, _ := NewUniformBSpline(1, zigzag, true)
+	// x(t) = t^3 - 2.925t^2 + 2.8512t turns back at t = 0.96 and forward again at t = 0.99, between the
+	// last two of any 16 evenly spaced samples, and peaks just beyond x(1)
+	sBend := CubicBezier[vec.Vec2]{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 0.9504, Y: 1}, vec.Vec2{X: 0.9258, Y: 2}, vec.Vec2{X: 0.9262, Y: 3}}
+	quintic, _ := NewUniformBSpline(5, []vec.Vec2{{X: 0, Y: 0}, {X: 1, Y: 3}, {X: 2, Y: -3}, {X: 3, Y: 3}, {X: 4, Y: -3}, {X: 5, Y: 0}}, true)
+	// the quintic is a single Bézier piece with y'(t) proportional to x^4 - 8x^3 + 12x^2 - 8x + 1, where
+	// x = t / (1-t), which is 0 where x + 1/x = 4 + sqrt(6)
+	z := 4 + math.Sqrt(6)
+	x := (z - math.Sqrt(z*z-4)) / 2
+	peak := x / (1 + x)
+
+	tests := []struct {
+		name string
+		c    Curve[vec.Vec2]
+		want geom.Rect
+	}{
+		{"parabola", parabola, geom.Rect{Min: vec.Vec2{X: -1, Y: 0}, Max: vec.Vec2{X: 1, Y: 1}}},
+		{"arch", arch, geom.Rect{Min: vec.Vec2{X: 0, Y: 0}, Max: vec.Vec2{X: 1, Y: 0.75}}},
+		// x(t) = 12t - 33t^2 + 22t^3 has extremes where t = (66 ± sqrt(1188)) / 132
+		{"loop", loop, geom.Rect{
+			Min: vec.Vec2{X: loop.At((66 + math.Sqrt(1188)) / 132).X, Y: 0},
+			Max: vec.Vec2{X: loop.At((66 - math.Sqrt(1188)) / 132).X, Y: 1.5},
+		}},
+		{"polyline", polyline, geom.Rect{Min: vec.Vec2{X: 0, Y: -1}, Max: vec.Vec2{X: 7, Y: 3}}},
+		{"tight S-bend", sBend, geom.Rect{Min: vec.Vec2{X: 0, Y: 0}, Max: vec.Vec2{X: sBend.At(0.96).X, Y: 3}}},
+		// reparameterising by arc length leaves a curve of unknown form, which must be sampled
+		{"tight S-bend by arc length", NewArcLengthCurve[vec.Vec2](sBend), geom.Rect{Min: vec.Vec2{X: 0, Y: 0}, Max: vec.Vec2{X: sBend.At(0.96).X, Y: 3}}},
+		{"quintic B-spline", quintic, geom.Rect{
+			Min: vec.Vec2{X: 0, Y: quintic.At(1 - peak).Y},
+			Max: vec.Vec2{X: 5, Y: quintic.At(peak).Y},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Bounds2(tt.c)
+			if !got.Min.AlmostEquals(tt.want.Min, 1e-9) || !got.Max.AlmostEquals(tt.want.Max, 1e-9) {
+				t.Errorf("Bounds2() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBounds3(t *testing.T) {
+	arch := QuadraticBezier[vec.Vec3]{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 2, Z: -2}, vec.Vec3{X: 2, Y: 0, Z: 0}}
+
+	got := Bounds3(arch)
+	want := geom.AABB3{Min: vec.Vec3{X: 0, Y: 0, Z: -1}, Max: vec.Vec3{X: 2, Y: 1, Z: 0}}
+	if !got.Min.AlmostEquals(want.Min, 1e-9) || !got.Max.AlmostEquals(want.Max, 1e-9) {
+		t.Errorf("Bounds3() = %v, want %v", got, want)
+	}
+}
+
+func TestArcLength(t *testing.T) {
+	polyline, _ := NewUniformBSpline(1, zigzag, true)
+	var polylineLength float64
+	for i := 1; i < len(zigzag); i++ {
+		polylineLength += zigzag[i].Subtract(zigzag[i-1]).Magnitude()
+	}
+
+	tests := []struct {
+		name string
+		c    Curve[vec.Vec2]
+		want float64
+	}{
+		{"line", unevenLine, 3},
+		{"parabola", parabola, math.Sqrt(5) + math.Asinh(2)/2},
+		{"polyline", polyline, polylineLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ArcLength(tt.c); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ArcLength() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArcLengthBetween(t *testing.T) {
+	tests := []struct {
+		name   string
+		t0, t1 float64
+		want   float64
+	}{
+		{"whole", 0, 1, 3},
+		{"start", 0, 0.5, unevenLine.At(0.5).X},
+		{"backwards", 0.5, 0, -unevenLine.At(0.5).X},
+		{"empty", 0.3, 0.3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ArcLengthBetween[vec.Vec2](unevenLine, tt.t0, tt.t1); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ArcLengthBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSampleByArcLength(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Curve[vec.Vec2]
+		n    int
+		want []vec.Vec2
+	}{
+		{"line", unevenLine, 4, []vec.Vec2{{X: 0}, {X: 1}, {X: 2}, {X: 3}}},
+		{"single", unevenLine, 1, []vec.Vec2{{X: 0}}},
+		{"none", unevenLine, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SampleByArcLength(tt.c, tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SampleByArcLength() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].AlmostEquals(tt.want[i], 1e-9) {
+					t.Errorf("SampleByArcLength()[%v] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("evenly spaced along a B-spline", func(t *testing.T) {
+		b, _ := NewUniformBSpline(3, zigzag, true)
+		samples := SampleByArcLength[vec.Vec2](b, 20)
+		spacing := ArcLength[vec.Vec2](b) / 19
+
+		// the straight line distance between close samples is a little less than the distance along the curve
+		for i := 1; i < len(samples); i++ {
+			if d := samples[i].Subtract(samples[i-1]).Magnitude(); d > spacing+1e-9 || d < 0.95*spacing {
+				t.Errorf("distance between samples %v and %v = %v, want about %v", i-1, i, d, spacing)
+			}
+		}
+	})
+}
+
+func TestArcLengthCurve(t *testing.T) {
+	t.Run("uneven line", func(t *testing.T) {
+		a := NewArcLengthCurve[vec.Vec2](unevenLine)
+
+		if start, end := a.Domain(); start != 0 || math.Abs(end-3) > 1e-9 {
+			t.Errorf("Domain() = %v, %v, want 0, 3", start, end)
+		}
+
+		for _, s := range []float64{0, 0.5, 1, 2.2, 3} {
+			if got, want := a.At(s), (vec.Vec2{X: s}); !got.AlmostEquals(want, 1e-9) {
+				t.Errorf("At(%v) = %v, want %v", s, got, want)
+			}
+			if got, want := a.Derivative(s), (vec.Vec2{X: 1}); !got.AlmostEquals(want, 1e-9) {
+				t.Errorf("Derivative(%v) = %v, want %v", s, got, want)
+			}
+			if got := unevenLine.At(a.Parameter(s)).X; math.Abs(got-s) > 1e-9 {
+				t.Errorf("Parameter(%v) = %v, which is at distance %v", s, a.Parameter(s), got)
+			}
+		}
+	})
+
+	t.Run("constant speed along a Catmull-Rom spline", func(t *testing.T) {
+		c, _ := NewCatmullRom(waypoints, Centripetal)
+		a := NewArcLengthCurve[vec.Vec3](c)
+
+		if got, want := a.Length(), ArcLength[vec.Vec3](c); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Length() = %v, want %v", got, want)
+		}
+		if got, want := ArcLength[vec.Vec3](a), a.Length(); math.Abs(got-want) > 1e-6 {
+			t.Errorf("ArcLength() of the reparameterised curve = %v, want %v", got, want)
+		}
+
+		for i := range 20 {
+			s := a.Length() * (float64(i) + 0.5) / 20
+			if speed := a.Derivative(s).Magnitude(); math.Abs(speed-1) > 1e-9 {
+				t.Errorf("speed at %v = %v, want 1", s, speed)
+			}
+
+			// the second derivative is the curvature vector
+			curvature, _ := Curvature[vec.Vec3](c, a.Parameter(s))
+			if got := a.SecondDerivative(s).Magnitude(); math.Abs(got-curvature) > 1e-9*(1+curvature) {
+				t.Errorf("|SecondDerivative(%v)| = %v, want %v", s, got, curvature)
+			}
+		}
+
+		checkDerivatives[vec.Vec3](t, a)
+	})
+}
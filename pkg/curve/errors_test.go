@@ -0,0 +1,54 @@
+package curve
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestErrors(t *testing.T) {
+	ignore := func(_ any, err error) error { return err }
+
+	cusp := CubicBezier[vec.Vec2]{
+		P0: vec.Vec2{X: 0, Y: 0},
+		P1: vec.Vec2{X: 0, Y: 0},
+		P2: vec.Vec2{X: 1, Y: 1},
+		P3: vec.Vec2{X: 2, Y: 0},
+	}
+	spline, err := NewUniformBSpline(2, zigzag, true)
+	if err != nil {
+		t.Fatalf("NewUniformBSpline() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		want   error
+		wantOp string
+	}{
+		{"Tangent", ignore(Tangent[vec.Vec2](cusp, 0)), vec.ErrZeroLength, "Tangent"},
+		{"Curvature", ignore(Curvature[vec.Vec2](cusp, 0)), vec.ErrZeroLength, "Curvature"},
+		{"NewBSpline", ignore(NewBSpline(0, zigzag, nil)), vec.ErrInvalidArgument, "NewBSpline"},
+		{"NewUniformBSpline", ignore(NewUniformBSpline(3, zigzag[:2], true)), vec.ErrInvalidArgument, "NewUniformBSpline"},
+		{"BSpline.Split", func() error { _, _, err := spline.Split(0); return err }(), vec.ErrInvalidArgument, "BSpline.Split"},
+		{"NewCatmullRom", ignore(NewCatmullRom(zigzag[:1], Centripetal)), vec.ErrInvalidArgument, "NewCatmullRom"},
+		{"NewHermite", ignore(NewHermite(zigzag, zigzag[:2])), vec.ErrInvalidArgument, "NewHermite"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.want)
+			}
+
+			var opErr *vec.OpError
+			if !errors.As(tt.err, &opErr) {
+				t.Fatalf("errors.As(%v) found no *vec.OpError", tt.err)
+			}
+			if opErr.Op != tt.wantOp {
+				t.Errorf("Op = %v, want %v", opErr.Op, tt.wantOp)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package curve
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Hermite is a cubic Hermite spline: a chain of cubic pieces passing through each of its points with a
+// given tangent. Piece i runs from point i to point i+1 as the parameter goes from i to i+1, so the domain
+// is [0, n-1] for n points.
+//
+// A Hermite must be created with [NewHermite].
+type Hermite[V Vector[V]] struct {
+	pieces hermitePieces[V]
+}
+
+// NewHermite returns a cubic Hermite spline through points, where tangents[i] is the derivative of the
+// curve at points[i].
+//
+// If there are fewer than 2 points, or the number of tangents doesn't match the number of points, this
+// function will return an error wrapping [vec.ErrInvalidArgument].
+func NewHermite[V Vector[V]](points, tangents []V) (Hermite[V], error) {
+	if len(points) < 2 {
+		return Hermite[V]{}, vec.NewOpError("NewHermite", fmt.Errorf("%w: a Hermite spline needs at least 2 points, got %v", vec.ErrInvalidArgument, len(points)))
+	}
+	if len(tangents) != len(points) {
+		return Hermite[V]{}, vec.NewOpError("NewHermite", fmt.Errorf("%w: a Hermite spline needs one tangent per point, got %v points and %v tangents", vec.ErrInvalidArgument, len(points), len(tangents)))
+	}
+
+	return Hermite[V]{hermitePieces[V]{
+		points: slices.Clone(points),
+		out:    slices.Clone(tangents[:len(tangents)-1]),
+		in:     slices.Clone(tangents[1:]),
+	}}, nil
+}
+
+// Domain returns the range of parameters over which the curve is defined, which is [0, n-1] for n points.
+func (h Hermite[V]) Domain() (start, end float64) {
+	return h.pieces.domain()
+}
+
+// At returns the point on the curve at parameter t. Parameters outside the domain are clamped to it.
+func (h Hermite[V]) At(t float64) V {
+	return h.pieces.at(t)
+}
+
+// Derivative returns the first derivative of the curve at parameter t.
+func (h Hermite[V]) Derivative(t float64) V {
+	return h.pieces.derivative(t)
+}
+
+// SecondDerivative returns the second derivative of the curve at parameter t. This is usually discontinuous
+// at the points, where it gives the second derivative of the later piece.
+func (h Hermite[V]) SecondDerivative(t float64) V {
+	return h.pieces.secondDerivative(t)
+}
+
+func (h Hermite[V]) breakpoints() []float64 {
+	return h.pieces.breakpoints()
+}
+
+func (h Hermite[V]) degree() int {
+	return 3
+}
+
+// hermitePieces is a chain of cubic Hermite pieces, with piece i running from points[i] to points[i+1]
+// over the parameters [i, i+1]. The piece leaves points[i] with derivative out[i], and arrives at
+// points[i+1] with derivative in[i].
+type hermitePieces[V Vector[V]] struct {
+	points  []V
+	out, in []V
+}
+
+func (h hermitePieces[V]) domain() (start, end float64) {
+	return 0, float64(len(h.points) - 1)
+}
+
+// locate returns the piece containing t, and how far along that piece t lies, from 0 to 1.
+func (h hermitePieces[V]) locate(t float64) (int, float64) {
+	_, end := h.domain()
+	t = min(max(t, 0), end)
+
+	i := min(int(t), len(h.points)-2)
+	return i, t - float64(i)
+}
+
+// combine returns the weighted sum of the endpoints and derivatives of piece i.
+func (h hermitePieces[V]) combine(i int, p0, m0, p1, m1 float64) V {
+	return h.points[i].Multiply(p0).
+		Add(h.out[i].Multiply(m0)).
+		Add(h.points[i+1].Multiply(p1)).
+		Add(h.in[i].Multiply(m1))
+}
+
+func (h hermitePieces[V]) at(t float64) V {
+	i, u := h.locate(t)
+	u2, u3 := u*u, u*u*u
+
+	return h.combine(i, 2*u3-3*u2+1, u3-2*u2+u, -2*u3+3*u2, u3-u2)
+}
+
+func (h hermitePieces[V]) derivative(t float64) V {
+	i, u := h.locate(t)
+	u2 := u * u
+
+	return h.combine(i, 6*u2-6*u, 3*u2-4*u+1, -6*u2+6*u, 3*u2-2*u)
+}
+
+func (h hermitePieces[V]) secondDerivative(t float64) V {
+	i, u := h.locate(t)
+
+	return h.combine(i, 12*u-6, 6*u-4, -12*u+6, 6*u-2)
+}
+
+func (h hermitePieces[V]) breakpoints() []float64 {
+	breaks := make([]float64, len(h.points))
+	for i := range breaks {
+		breaks[i] = float64(i)
+	}
+	return breaks
+}
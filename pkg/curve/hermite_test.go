@@ -0,0 +1,70 @@
+package curve
+
+import (
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestNewHermite(t *testing.T) {
+	tests := []struct {
+		name     string
+		points   []vec.Vec2
+		tangents []vec.Vec2
+		wantErr  bool
+	}{
+		{"valid", zigzag[:3], zigzag[3:], false},
+		{"two points", zigzag[:2], zigzag[:2], false},
+		{"one point", zigzag[:1], zigzag[:1], true},
+		{"too few tangents", zigzag[:3], zigzag[:2], true},
+		{"too many tangents", zigzag[:3], zigzag[:4], true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHermite(tt.points, tt.tangents)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewHermite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHermite_At(t *testing.T) {
+	points := []vec.Vec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 2, Z: 0}, {X: 3, Y: 1, Z: -1}, {X: 4, Y: 4, Z: 2}}
+	tangents := []vec.Vec3{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 1}, {X: 2, Y: -1, Z: 0}, {X: 0, Y: 0, Z: 3}}
+	h, err := NewHermite(points, tangents)
+	if err != nil {
+		t.Fatalf("NewHermite() error = %v", err)
+	}
+
+	if start, end := h.Domain(); start != 0 || end != 3 {
+		t.Errorf("Domain() = %v, %v, want 0, 3", start, end)
+	}
+
+	for i := range points {
+		if got := h.At(float64(i)); !got.AlmostEquals(points[i], 1e-12) {
+			t.Errorf("At(%v) = %v, want %v", i, got, points[i])
+		}
+		if got := h.Derivative(float64(i)); !got.AlmostEquals(tangents[i], 1e-12) {
+			t.Errorf("Derivative(%v) = %v, want %v", i, got, tangents[i])
+		}
+	}
+
+	checkDerivatives[vec.Vec3](t, h)
+}
+
+func TestHermite_Line(t *testing.T) {
+	// with tangents matching the spacing of the points, a Hermite spline is a straight line at constant speed
+	h, _ := NewHermite(
+		[]vec.Vec2{{X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 2}},
+		[]vec.Vec2{{X: 2, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 1}},
+	)
+
+	for _, u := range []float64{0.25, 0.9, 1.5} {
+		want := vec.Vec2{X: 2 * u, Y: u}
+		if got := h.At(u); !got.AlmostEquals(want, 1e-12) {
+			t.Errorf("At(%v) = %v, want %v", u, got, want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package geom
+
+import (
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// AABB3 represents an axis-aligned bounding box in 3D space, spanning from Min to Max inclusive.
+//
+// Each component of Min is expected to be no greater than the corresponding component of Max.
+type AABB3 struct {
+	Min, Max vec.Vec3
+}
+
+// AABB3FromPoints returns the smallest box containing every point in ps.
+//
+// If ps is empty, this function returns false.
+func AABB3FromPoints(ps []vec.Vec3) (AABB3, bool) {
+	if len(ps) == 0 {
+		return AABB3{}, false
+	}
+
+	b := AABB3{ps[0], ps[0]}
+	for _, p := range ps[1:] {
+		b = b.Expand(p)
+	}
+
+	return b, true
+}
+
+// Expand returns the smallest box containing both b and p.
+func (b AABB3) Expand(p vec.Vec3) AABB3 {
+	return AABB3{
+		Min: vec.Vec3{X: math.Min(b.Min.X, p.X), Y: math.Min(b.Min.Y, p.Y), Z: math.Min(b.Min.Z, p.Z)},
+		Max: vec.Vec3{X: math.Max(b.Max.X, p.X), Y: math.Max(b.Max.Y, p.Y), Z: math.Max(b.Max.Z, p.Z)},
+	}
+}
+
+// Union returns the smallest box containing both b1 and b2.
+func (b1 AABB3) Union(b2 AABB3) AABB3 {
+	return b1.Expand(b2.Min).Expand(b2.Max)
+}
+
+// Centre returns the point at the middle of the box.
+func (b AABB3) Centre() vec.Vec3 {
+	return b.Min.Lerp(b.Max, 0.5)
+}
+
+// Size returns the extent of the box along each axis.
+func (b AABB3) Size() vec.Vec3 {
+	return b.Max.Subtract(b.Min)
+}
+
+// SurfaceArea returns the total area of the six faces of the box.
+func (b AABB3) SurfaceArea() float64 {
+	size := b.Size()
+	return 2 * (size.X*size.Y + size.Y*size.Z + size.Z*size.X)
+}
+
+// Contains returns true if p lies inside or on the surface of b.
+func (b AABB3) Contains(p vec.Vec3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// ClosestPoint returns the point in b nearest to p. If p is inside b, this is p itself.
+func (b AABB3) ClosestPoint(p vec.Vec3) vec.Vec3 {
+	return vec.Vec3{
+		X: math.Max(b.Min.X, math.Min(p.X, b.Max.X)),
+		Y: math.Max(b.Min.Y, math.Min(p.Y, b.Max.Y)),
+		Z: math.Max(b.Min.Z, math.Min(p.Z, b.Max.Z)),
+	}
+}
+
+// Overlaps returns true if b1 and b2 share at least one point.
+func (b1 AABB3) Overlaps(b2 AABB3) bool {
+	return b1.Min.X <= b2.Max.X && b1.Max.X >= b2.Min.X &&
+		b1.Min.Y <= b2.Max.Y && b1.Max.Y >= b2.Min.Y &&
+		b1.Min.Z <= b2.Max.Z && b1.Max.Z >= b2.Min.Z
+}
+
+// OverlapsSphere returns true if b and s share at least one point.
+func (b AABB3) OverlapsSphere(s Sphere) bool {
+	return s.OverlapsAABB(b)
+}
@@ -0,0 +1,44 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestAABB3_Overlaps(t *testing.T) {
+	unit := AABB3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 1, Z: 1}}
+
+	tests := []struct {
+		name string
+		b1   AABB3
+		b2   AABB3
+		want bool
+	}{
+		{
+			name: "identical",
+			b1:   unit,
+			b2:   unit,
+			want: true,
+		},
+		{
+			name: "touching faces",
+			b1:   unit,
+			b2:   AABB3{vec.Vec3{X: 1, Y: 0, Z: 0}, vec.Vec3{X: 2, Y: 1, Z: 1}},
+			want: true,
+		},
+		{
+			name: "separated along z only",
+			b1:   unit,
+			b2:   AABB3{vec.Vec3{X: 0, Y: 0, Z: 1.5}, vec.Vec3{X: 1, Y: 1, Z: 2}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b1.Overlaps(tt.b2); got != tt.want {
+				t.Errorf("b1.Overlaps(b2) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
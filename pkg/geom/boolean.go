@@ -0,0 +1,536 @@
+package geom
+
+import (
+	"math"
+	"slices"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// PolygonWithHoles represents a region of 2D space bounded by an outer ring, with zero or more holes cut out of it.
+//
+// Rings may be in either orientation. Where results are produced by this package, the outer ring is
+// anticlockwise and the holes are clockwise.
+type PolygonWithHoles struct {
+	Outer Polygon
+	Holes []Polygon
+}
+
+// Area returns the area of the outer ring, less the area of the holes.
+func (p PolygonWithHoles) Area() float64 {
+	area := p.Outer.Area()
+
+	for _, hole := range p.Holes {
+		area -= hole.Area()
+	}
+
+	return area
+}
+
+// Contains returns true if q lies inside or on the boundary of the outer ring, and not strictly inside any hole.
+func (p PolygonWithHoles) Contains(q vec.Vec2) bool {
+	if !p.Outer.Contains(q) {
+		return false
+	}
+
+	for _, hole := range p.Holes {
+		if hole.Contains(q) && !hole.onBoundary(q) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MultiPolygon represents a region of 2D space made up of any number of polygons with holes.
+//
+// The boolean operations on MultiPolygon treat each input as the set of points with a non-zero winding number,
+// once every outer ring has been made anticlockwise and every hole clockwise. As such, the polygons making up an
+// input may overlap, and their rings need not be simple. Results never overlap, and are made up of simple rings,
+// though the rings may touch each other at a vertex.
+//
+// Each operation runs a sweep line across the edges of both inputs, so takes O((n + k) log(n + k)) time, where n is
+// the total number of vertices and k the number of points at which edges cross.
+type MultiPolygon []PolygonWithHoles
+
+// Area returns the total area of the polygons, assuming they do not overlap.
+func (mp MultiPolygon) Area() float64 {
+	var area float64
+
+	for _, p := range mp {
+		area += p.Area()
+	}
+
+	return area
+}
+
+// Contains returns true if q lies inside or on the boundary of any of the polygons.
+func (mp MultiPolygon) Contains(q vec.Vec2) bool {
+	for _, p := range mp {
+		if p.Contains(q) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Union returns the region covered by either mp1 or mp2.
+func (mp1 MultiPolygon) Union(mp2 MultiPolygon) MultiPolygon {
+	return overlay(overlayEdges(mp1), overlayEdges(mp2), nonZero, func(in1, in2 bool) bool { return in1 || in2 })
+}
+
+// Intersect returns the region covered by both mp1 and mp2.
+func (mp1 MultiPolygon) Intersect(mp2 MultiPolygon) MultiPolygon {
+	return overlay(overlayEdges(mp1), overlayEdges(mp2), nonZero, func(in1, in2 bool) bool { return in1 && in2 })
+}
+
+// Difference returns the region covered by mp1 but not mp2.
+func (mp1 MultiPolygon) Difference(mp2 MultiPolygon) MultiPolygon {
+	return overlay(overlayEdges(mp1), overlayEdges(mp2), nonZero, func(in1, in2 bool) bool { return in1 && !in2 })
+}
+
+// Xor returns the region covered by exactly one of mp1 and mp2.
+func (mp1 MultiPolygon) Xor(mp2 MultiPolygon) MultiPolygon {
+	return overlay(overlayEdges(mp1), overlayEdges(mp2), nonZero, func(in1, in2 bool) bool { return in1 != in2 })
+}
+
+// ClipConvex returns the part of poly lying inside the convex polygon clip, using the Sutherland-Hodgman algorithm.
+// clip may be in either orientation. If poly lies entirely outside clip, the result is empty.
+//
+// This is much faster than [MultiPolygon.Intersect], but if poly is concave, and its intersection with clip falls
+// into several pieces, the pieces are joined by zero-width slivers running along the boundary of clip.
+func (poly Polygon) ClipConvex(clip Polygon) Polygon {
+	if clip.Orientation() == Clockwise {
+		clip = clip.Reversed()
+	}
+
+	clipped := poly
+	for _, edge := range clip.Edges() {
+		if len(clipped) == 0 {
+			return nil
+		}
+
+		// the outward normal of an anticlockwise edge points to its right
+		d := edge.B.Subtract(edge.A)
+		clipped = clipHalfPlane(clipped, edge.A, vec.Vec2{X: d.Y, Y: -d.X})
+	}
+
+	return clipped
+}
+
+// onBoundary returns true if p lies exactly on one of the polygon's edges.
+func (poly Polygon) onBoundary(p vec.Vec2) bool {
+	for _, edge := range poly.Edges() {
+		if edge.Contains(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nonZero is the fill rule placing points with any non-zero winding number inside a polygon.
+func nonZero(winding int) bool {
+	return winding != 0
+}
+
+// positive is the fill rule placing only points with a positive winding number inside a polygon.
+func positive(winding int) bool {
+	return winding > 0
+}
+
+// overlay computes a boolean operation with a sweep line, following Martinez, Rueda and Feito's "A new algorithm
+// for computing Boolean operations on polygons".
+//
+// The inputs are given as the directed edges of their rings, with fill deciding from its winding number
+// whether a point lies in an input. keep reports whether a point belongs to the result, given whether it
+// lies in the first input and in the second.
+//
+// Martinez et al. work out which edges belong to the result in the same sweep that splits them where they
+// cross. Here the two jobs are done in separate sweeps. The first, [subdivide], splits the edges, and the
+// second, [classify], finds the winding numbers either side of each piece from the piece below it. Once no
+// pieces cross, the piece below is always known by the time it is needed, even where pieces overlap or
+// meet at a vertex.
+func overlay(edges1, edges2 []Segment2, fill func(winding int) bool, keep func(in1, in2 bool) bool) MultiPolygon {
+	var s sweep
+	for source, input := range [][]Segment2{edges1, edges2} {
+		for _, edge := range input {
+			// the winding number on the left of a directed edge is one more than on its right, and the
+			// left side is above an edge heading away from its left event
+			var wind [2]int
+			wind[source] = 1
+			if comparePoints(edge.A, edge.B) > 0 {
+				wind[source] = -1
+			}
+			s.addEdge(edge.A, edge.B, wind)
+		}
+	}
+
+	boundary, below := classify(subdivide(&s), fill, keep)
+
+	return assembleRings(boundary, below)
+}
+
+// overlayEdges returns the edges of every ring in mp, with outer rings made anticlockwise and holes clockwise.
+func overlayEdges(mp MultiPolygon) []Segment2 {
+	var edges []Segment2
+
+	addRing := func(ring Polygon, want Orientation) {
+		switch ring.Orientation() {
+		case Collinear:
+			return
+		case -want:
+			ring = ring.Reversed()
+		}
+
+		for _, edge := range ring.Edges() {
+			if !edge.A.Equals(edge.B) {
+				edges = append(edges, edge)
+			}
+		}
+	}
+
+	for _, p := range mp {
+		addRing(p.Outer, Anticlockwise)
+		for _, hole := range p.Holes {
+			addRing(hole, Clockwise)
+		}
+	}
+
+	return edges
+}
+
+// sweep holds the state of a sweep line moving across a set of edges.
+type sweep struct {
+	queue  eventQueue
+	status sweepStatus
+	ids    int
+}
+
+// newEvent returns a new event at p.
+func (s *sweep) newEvent(p vec.Vec2, left bool) *sweepEvent {
+	s.ids++
+	return &sweepEvent{point: p, left: left, id: s.ids, boundary: -1}
+}
+
+// addEdge queues the edge from a to b, which increases the winding number of each input by wind on crossing
+// it from below to above. Edges of 0 length are ignored.
+func (s *sweep) addEdge(a, b vec.Vec2, wind [2]int) {
+	if comparePoints(a, b) > 0 {
+		a, b = b, a
+	}
+	if a == b {
+		return
+	}
+
+	l, r := s.newEvent(a, true), s.newEvent(b, false)
+	l.other, r.other = r, l
+	l.wind = wind
+
+	s.queue.push(l)
+	s.queue.push(r)
+}
+
+// subdivide sweeps across the queued edges, cutting them wherever they cross or overlap, and returns the
+// resulting pieces. Pieces meet only at their endpoints, and pieces that coincide are merged into one
+// whose wind is the sum of theirs.
+//
+// Each time an edge joins the sweep line, or the edge between two others leaves it, the edges that have
+// just become neighbours are checked against each other. Pieces cut off an edge are queued like any other,
+// so crossings moved slightly by rounding are still found once the pieces become neighbours.
+func subdivide(s *sweep) []*sweepEvent {
+	var pieces []*sweepEvent
+
+	for s.queue.len() > 0 {
+		e := s.queue.pop()
+
+		if e.left {
+			e.node = s.status.insert(e)
+			if next := e.node.next(); next != nil {
+				s.cut(e, next.event)
+			}
+			if prev := e.node.prev(); prev != nil {
+				s.cut(prev.event, e)
+			}
+			continue
+		}
+
+		l := e.other
+		prev, next := l.node.prev(), l.node.next()
+		s.status.remove(l.node)
+		l.node = nil
+		if prev != nil && next != nil {
+			s.cut(prev.event, next.event)
+		}
+
+		pieces = append(pieces, l)
+	}
+
+	type key struct{ p, q vec.Vec2 }
+	merged := make(map[key]*sweepEvent)
+	var result []*sweepEvent
+
+	for _, l := range pieces {
+		k := key{l.point, l.other.point}
+		if m, ok := merged[k]; ok {
+			m.wind[0] += l.wind[0]
+			m.wind[1] += l.wind[1]
+			continue
+		}
+		merged[k] = l
+		result = append(result, l)
+	}
+
+	return result
+}
+
+// cut splits the edges of left events e1 and e2 where they cross, where an endpoint of one lies on the
+// other, and at the ends of any stretch along which they overlap.
+func (s *sweep) cut(e1, e2 *sweepEvent) {
+	p1, q1 := e1.point, e1.other.point
+	p2, q2 := e2.point, e2.other.point
+
+	o1, o2 := Orient2D(p1, q1, p2), Orient2D(p1, q1, q2)
+	o3, o4 := Orient2D(p2, q2, p1), Orient2D(p2, q2, q1)
+
+	if o1*o2 < 0 && o3*o4 < 0 {
+		// a proper crossing: compute the point once, so both edges are cut in exactly the same place
+		d1, d2 := q1.Subtract(p1), q2.Subtract(p2)
+		x := p1.Add(d1.Multiply(p2.Subtract(p1).Cross(d2) / d1.Cross(d2)))
+		s.divide(e1, x)
+		s.divide(e2, x)
+		return
+	}
+
+	// otherwise, an endpoint of one edge lying on the other cuts it there. Where the edges overlap, this
+	// cuts both at the ends of the overlap, leaving identical pieces along it. Cutting at the farther
+	// point first leaves the nearer point on the part of the edge still held by the event
+	var cuts1, cuts2 []vec.Vec2
+	if o1 == Collinear {
+		cuts1 = append(cuts1, p2)
+	}
+	if o2 == Collinear {
+		cuts1 = append(cuts1, q2)
+	}
+	if o3 == Collinear {
+		cuts2 = append(cuts2, p1)
+	}
+	if o4 == Collinear {
+		cuts2 = append(cuts2, q1)
+	}
+
+	for _, c := range []struct {
+		e    *sweepEvent
+		cuts []vec.Vec2
+	}{{e1, cuts1}, {e2, cuts2}} {
+		slices.SortFunc(c.cuts, func(a, b vec.Vec2) int { return comparePoints(b, a) })
+		for _, p := range c.cuts {
+			s.divide(c.e, p)
+		}
+	}
+}
+
+// divide cuts the edge of left event e at p, queueing the piece beyond p as a new edge. If p does not lie
+// strictly between the edge's endpoints in sweep order, which rounding can cause, the edge is left whole.
+func (s *sweep) divide(e *sweepEvent, p vec.Vec2) {
+	if comparePoints(e.point, p) >= 0 || comparePoints(p, e.other.point) >= 0 {
+		return
+	}
+
+	r, l := s.newEvent(p, false), s.newEvent(p, true)
+	r.other = e
+	l.other = e.other
+	l.wind = e.wind
+	e.other.other = l
+	e.other = r
+
+	s.queue.push(r)
+	s.queue.push(l)
+}
+
+// classify sweeps across pieces, which must meet only at their endpoints, and returns the pieces that
+// separate the result from the rest of the plane. Each is directed so that the result lies on its left.
+//
+// For each piece returned, below holds the index of the nearest returned piece under its left end,
+// or -1 if there is none.
+func classify(pieces []*sweepEvent, fill func(winding int) bool, keep func(in1, in2 bool) bool) (boundary []Segment2, below []int) {
+	var s sweep
+	for _, l := range pieces {
+		if l.wind != [2]int{} {
+			s.addEdge(l.point, l.other.point, l.wind)
+		}
+	}
+
+	// the sweep line holding only pieces of the boundary, for finding the piece under each
+	var boundaryStatus sweepStatus
+
+	for s.queue.len() > 0 {
+		e := s.queue.pop()
+
+		if !e.left {
+			l := e.other
+			s.status.remove(l.node)
+			if l.boundaryNode != nil {
+				boundaryStatus.remove(l.boundaryNode)
+			}
+			continue
+		}
+
+		// the winding numbers just below this piece are those just above the one under it
+		e.node = s.status.insert(e)
+		if prev := e.node.prev(); prev != nil {
+			e.below = [2]int{prev.event.below[0] + prev.event.wind[0], prev.event.below[1] + prev.event.wind[1]}
+		}
+		above := [2]int{e.below[0] + e.wind[0], e.below[1] + e.wind[1]}
+
+		inBelow := keep(fill(e.below[0]), fill(e.below[1]))
+		inAbove := keep(fill(above[0]), fill(above[1]))
+		if inBelow == inAbove {
+			continue
+		}
+
+		edge := Segment2{e.point, e.other.point}
+		if inBelow {
+			edge = Segment2{e.other.point, e.point}
+		}
+
+		e.boundaryNode = boundaryStatus.insert(e)
+		under := -1
+		if prev := e.boundaryNode.prev(); prev != nil {
+			under = prev.event.boundary
+		}
+
+		e.boundary = len(boundary)
+		boundary = append(boundary, edge)
+		below = append(below, under)
+	}
+
+	return boundary, below
+}
+
+// assembleRings joins boundary edges end to end into closed rings, and groups them into polygons with holes.
+//
+// The edges must be in the order [classify] returns them, with below as it returns it. Each ring is then
+// started from its lowest edge at its leftmost vertex, and any ring started earlier lies at least partly
+// below or to the left of it.
+func assembleRings(boundary []Segment2, below []int) MultiPolygon {
+	outgoing := make(map[vec.Vec2][]int)
+	for i, edge := range boundary {
+		outgoing[edge.A] = append(outgoing[edge.A], i)
+	}
+
+	used := make([]bool, len(boundary))
+	// owner holds, for each edge, the index in result of the polygon its ring bounds, or -1 if it is not known
+	owner := make([]int, len(boundary))
+	var result MultiPolygon
+	var orphans []Polygon
+
+	for start := range boundary {
+		if used[start] {
+			continue
+		}
+
+		var ring Polygon
+		var edges []int
+		closed := false
+		for e := start; ; {
+			used[e] = true
+			edges = append(edges, e)
+			ring = append(ring, boundary[e].A)
+			dir := boundary[e].B.Subtract(boundary[e].A)
+
+			// where several edges leave the same vertex, take the sharpest left turn, which keeps the
+			// result's rings simple where they touch at a vertex
+			next, nextTurn := -1, 0.0
+			for _, o := range outgoing[boundary[e].B] {
+				if used[o] && o != start {
+					continue
+				}
+				d := boundary[o].B.Subtract(boundary[o].A)
+				if turn := math.Atan2(dir.Cross(d), dir.Dot(d)); next < 0 || turn > nextTurn {
+					next, nextTurn = o, turn
+				}
+			}
+
+			if next < 0 || next == start {
+				closed = next == start
+				break
+			}
+			e = next
+		}
+
+		ringOwner := -1
+		if closed {
+			ring = removeCollinear(ring)
+		}
+		switch {
+		case !closed || len(ring) < 3:
+		case ring.Orientation() == Anticlockwise:
+			ringOwner = len(result)
+			result = append(result, PolygonWithHoles{Outer: ring})
+		case ring.Orientation() == Clockwise:
+			// the result lies just below a hole's lowest edge at its leftmost vertex, and the nearest boundary
+			// edge under that bounds either the polygon the hole belongs to, or another hole in that polygon.
+			// Either way, its ring was started earlier
+			if under := below[start]; under >= 0 && owner[under] >= 0 {
+				ringOwner = owner[under]
+				result[ringOwner].Holes = append(result[ringOwner].Holes, ring)
+			} else {
+				orphans = append(orphans, ring)
+			}
+		}
+
+		for _, e := range edges {
+			owner[e] = ringOwner
+		}
+	}
+
+	// rounding can leave a hole with nothing recognisable under it, so fall back to searching for the
+	// smallest outer ring around it
+	for _, hole := range orphans {
+		best := -1
+		for i, p := range result {
+			if encloses(p.Outer, hole) && (best < 0 || p.Outer.Area() < result[best].Outer.Area()) {
+				best = i
+			}
+		}
+
+		if best >= 0 {
+			result[best].Holes = append(result[best].Holes, hole)
+		}
+	}
+
+	return result
+}
+
+// encloses returns true if inner lies inside outer, given that the two rings do not cross.
+func encloses(outer, inner Polygon) bool {
+	// the rings may touch at vertices, so look for a point of inner that is clear of outer's boundary
+	for _, edge := range inner.Edges() {
+		for _, p := range []vec.Vec2{edge.A, edge.At(0.5)} {
+			if !outer.onBoundary(p) {
+				return outer.WindingNumber(p) != 0
+			}
+		}
+	}
+
+	return false
+}
+
+// removeCollinear returns ring with every vertex lying on the line through its neighbours removed.
+func removeCollinear(ring Polygon) Polygon {
+	for changed := true; changed; {
+		changed = false
+		for i := 0; i < len(ring) && len(ring) >= 3; i++ {
+			prev, next := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+			if Orient2D(prev, ring[i], next) == Collinear {
+				ring = slices.Delete(ring, i, i+1)
+				i--
+				changed = true
+			}
+		}
+	}
+
+	return ring
+}
@@ -0,0 +1,310 @@
+package geom
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func box(minX, minY, maxX, maxY float64) Polygon {
+	return Rect{vec.Vec2{X: minX, Y: minY}, vec.Vec2{X: maxX, Y: maxY}}.Polygon()
+}
+
+// checkOverlay verifies the shape of the result of a boolean operation, and that it contains exactly
+// the points it should across a grid of samples chosen to avoid lying on any boundary.
+func checkOverlay(t *testing.T, got, a, b MultiPolygon, keep func(inA, inB bool) bool, wantArea float64, wantPolygons, wantHoles int) {
+	t.Helper()
+
+	holes := 0
+	for _, p := range got {
+		if p.Outer.Orientation() != Anticlockwise {
+			t.Errorf("outer ring %v is not anticlockwise", p.Outer)
+		}
+		for _, hole := range p.Holes {
+			if hole.Orientation() != Clockwise {
+				t.Errorf("hole %v is not clockwise", hole)
+			}
+		}
+		holes += len(p.Holes)
+	}
+
+	if len(got) != wantPolygons {
+		t.Errorf("got %v polygons, want %v", len(got), wantPolygons)
+	}
+	if holes != wantHoles {
+		t.Errorf("got %v holes, want %v", holes, wantHoles)
+	}
+	if area := got.Area(); math.Abs(area-wantArea) > 1e-9 {
+		t.Errorf("got area %v, want %v", area, wantArea)
+	}
+
+	for x := -2.0; x < 16; x += 0.3 {
+		for y := -2.0; y < 16; y += 0.3 {
+			p := vec.Vec2{X: x + 0.0123, Y: y + 0.0456}
+			if want := keep(a.Contains(p), b.Contains(p)); got.Contains(p) != want {
+				t.Fatalf("Contains(%v) = %v, want %v", p, !want, want)
+			}
+		}
+	}
+}
+
+var overlayTests = []struct {
+	name string
+	a, b MultiPolygon
+	// area, polygon count and hole count for union, intersection, difference and xor in turn
+	wantArea     [4]float64
+	wantPolygons [4]int
+	wantHoles    [4]int
+}{
+	{
+		name:         "overlapping squares",
+		a:            MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+		b:            MultiPolygon{{Outer: box(1, 1, 3, 3)}},
+		wantArea:     [4]float64{7, 1, 3, 6},
+		wantPolygons: [4]int{1, 1, 1, 2},
+	},
+	{
+		name:         "clockwise overlapping squares",
+		a:            MultiPolygon{{Outer: box(0, 0, 2, 2).Reversed()}},
+		b:            MultiPolygon{{Outer: box(1, 1, 3, 3).Reversed()}},
+		wantArea:     [4]float64{7, 1, 3, 6},
+		wantPolygons: [4]int{1, 1, 1, 2},
+	},
+	{
+		name:         "disjoint squares",
+		a:            MultiPolygon{{Outer: box(0, 0, 1, 1)}},
+		b:            MultiPolygon{{Outer: box(5, 5, 6, 6)}},
+		wantArea:     [4]float64{2, 0, 1, 2},
+		wantPolygons: [4]int{2, 0, 1, 2},
+	},
+	{
+		name:         "identical squares",
+		a:            MultiPolygon{{Outer: box(0, 0, 4, 4)}},
+		b:            MultiPolygon{{Outer: box(0, 0, 4, 4)}},
+		wantArea:     [4]float64{16, 16, 0, 0},
+		wantPolygons: [4]int{1, 1, 0, 0},
+	},
+	{
+		name:         "squares sharing an edge",
+		a:            MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+		b:            MultiPolygon{{Outer: box(2, 0, 4, 2)}},
+		wantArea:     [4]float64{8, 0, 4, 8},
+		wantPolygons: [4]int{1, 0, 1, 1},
+	},
+	{
+		name:         "squares touching at a corner",
+		a:            MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+		b:            MultiPolygon{{Outer: box(2, 2, 4, 4)}},
+		wantArea:     [4]float64{8, 0, 4, 8},
+		wantPolygons: [4]int{2, 0, 1, 2},
+	},
+	{
+		name:         "square inside square",
+		a:            MultiPolygon{{Outer: box(0, 0, 10, 10)}},
+		b:            MultiPolygon{{Outer: box(4, 4, 6, 6)}},
+		wantArea:     [4]float64{100, 4, 96, 96},
+		wantPolygons: [4]int{1, 1, 1, 1},
+		wantHoles:    [4]int{0, 0, 1, 1},
+	},
+	{
+		name: "square with hole overlapping square",
+		a: MultiPolygon{{
+			Outer: box(0, 0, 10, 10),
+			Holes: []Polygon{box(4, 4, 6, 6)},
+		}},
+		b:            MultiPolygon{{Outer: box(5, 5, 15, 15)}},
+		wantArea:     [4]float64{172, 24, 72, 148},
+		wantPolygons: [4]int{1, 1, 1, 3},
+		wantHoles:    [4]int{1, 0, 0, 0},
+	},
+	{
+		name: "island in a hole",
+		a: MultiPolygon{{
+			Outer: box(0, 0, 10, 10),
+			Holes: []Polygon{box(2, 2, 8, 8)},
+		}},
+		b:            MultiPolygon{{Outer: box(4, 4, 6, 6)}},
+		wantArea:     [4]float64{68, 0, 64, 68},
+		wantPolygons: [4]int{2, 0, 1, 2},
+		wantHoles:    [4]int{1, 0, 1, 1},
+	},
+	{
+		name: "overlapping polygons within one input",
+		a: MultiPolygon{
+			{Outer: box(0, 0, 2, 2)},
+			{Outer: box(1, 0, 3, 2)},
+		},
+		b:            MultiPolygon{{Outer: box(1, 1, 2, 3)}},
+		wantArea:     [4]float64{7, 1, 5, 6},
+		wantPolygons: [4]int{1, 1, 1, 2},
+	},
+	{
+		name:         "crossing triangles",
+		a:            MultiPolygon{{Outer: Polygon{{X: 0, Y: 0}, {X: 6, Y: 0}, {X: 3, Y: 6}}}},
+		b:            MultiPolygon{{Outer: Polygon{{X: 0, Y: 4}, {X: 3, Y: -2}, {X: 6, Y: 4}}}},
+		wantArea:     [4]float64{24, 12, 6, 12},
+		wantPolygons: [4]int{1, 1, 3, 6},
+	},
+	{
+		name:         "edges meeting in T-junctions",
+		a:            MultiPolygon{{Outer: box(0, 0, 4, 2)}},
+		b:            MultiPolygon{{Outer: box(1, 2, 3, 4)}},
+		wantArea:     [4]float64{12, 0, 8, 12},
+		wantPolygons: [4]int{1, 0, 1, 1},
+	},
+	{
+		name:         "partly overlapping edges",
+		a:            MultiPolygon{{Outer: box(0, 0, 4, 4)}},
+		b:            MultiPolygon{{Outer: box(2, 0, 6, 2)}},
+		wantArea:     [4]float64{20, 4, 12, 16},
+		wantPolygons: [4]int{1, 1, 1, 2},
+	},
+	{
+		name:         "empty input",
+		a:            MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+		b:            nil,
+		wantArea:     [4]float64{4, 0, 4, 4},
+		wantPolygons: [4]int{1, 0, 1, 1},
+	},
+}
+
+func TestMultiPolygon_Union(t *testing.T) {
+	for _, tt := range overlayTests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkOverlay(t, tt.a.Union(tt.b), tt.a, tt.b, func(inA, inB bool) bool { return inA || inB },
+				tt.wantArea[0], tt.wantPolygons[0], tt.wantHoles[0])
+		})
+	}
+}
+
+func TestMultiPolygon_Intersect(t *testing.T) {
+	for _, tt := range overlayTests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkOverlay(t, tt.a.Intersect(tt.b), tt.a, tt.b, func(inA, inB bool) bool { return inA && inB },
+				tt.wantArea[1], tt.wantPolygons[1], tt.wantHoles[1])
+		})
+	}
+}
+
+func TestMultiPolygon_Difference(t *testing.T) {
+	for _, tt := range overlayTests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkOverlay(t, tt.a.Difference(tt.b), tt.a, tt.b, func(inA, inB bool) bool { return inA && !inB },
+				tt.wantArea[2], tt.wantPolygons[2], tt.wantHoles[2])
+		})
+	}
+}
+
+func TestMultiPolygon_Xor(t *testing.T) {
+	for _, tt := range overlayTests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkOverlay(t, tt.a.Xor(tt.b), tt.a, tt.b, func(inA, inB bool) bool { return inA != inB },
+				tt.wantArea[3], tt.wantPolygons[3], tt.wantHoles[3])
+		})
+	}
+}
+
+func TestMultiPolygon_ManyOverlaps(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	triangles := func(n int) MultiPolygon {
+		var mp MultiPolygon
+		for range n {
+			var tri Polygon
+			for range 3 {
+				tri = append(tri, vec.Vec2{X: r.Float64() * 14, Y: r.Float64() * 14})
+			}
+			mp = append(mp, PolygonWithHoles{Outer: tri})
+		}
+		return mp
+	}
+	a, b := triangles(20), triangles(20)
+
+	tests := []struct {
+		name string
+		got  MultiPolygon
+		keep func(inA, inB bool) bool
+	}{
+		{name: "union", got: a.Union(b), keep: func(inA, inB bool) bool { return inA || inB }},
+		{name: "intersect", got: a.Intersect(b), keep: func(inA, inB bool) bool { return inA && inB }},
+		{name: "difference", got: a.Difference(b), keep: func(inA, inB bool) bool { return inA && !inB }},
+		{name: "xor", got: a.Xor(b), keep: func(inA, inB bool) bool { return inA != inB }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for x := 0.0; x < 14; x += 0.1 {
+				for y := 0.0; y < 14; y += 0.1 {
+					p := vec.Vec2{X: x + 0.00123, Y: y + 0.00456}
+					if want := tt.keep(a.Contains(p), b.Contains(p)); tt.got.Contains(p) != want {
+						t.Fatalf("Contains(%v) = %v, want %v", p, !want, want)
+					}
+				}
+			}
+		})
+	}
+
+	// the union splits into the intersection and the symmetric difference
+	if union, parts := tests[0].got.Area(), tests[1].got.Area()+tests[3].got.Area(); math.Abs(union-parts) > 1e-9 {
+		t.Errorf("union has area %v, but intersection and xor have %v", union, parts)
+	}
+}
+
+func TestPolygon_ClipConvex(t *testing.T) {
+	tests := []struct {
+		name     string
+		poly     Polygon
+		clip     Polygon
+		wantArea float64
+	}{
+		{
+			name:     "overlapping squares",
+			poly:     box(0, 0, 2, 2),
+			clip:     box(1, 1, 3, 3),
+			wantArea: 1,
+		},
+		{
+			name:     "clockwise clip",
+			poly:     box(0, 0, 2, 2),
+			clip:     box(1, 1, 3, 3).Reversed(),
+			wantArea: 1,
+		},
+		{
+			name:     "poly inside clip",
+			poly:     box(1, 1, 2, 2),
+			clip:     box(0, 0, 3, 3),
+			wantArea: 1,
+		},
+		{
+			name:     "clip inside poly",
+			poly:     box(0, 0, 3, 3),
+			clip:     Polygon{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 1, Y: 2}},
+			wantArea: 0.5,
+		},
+		{
+			name:     "disjoint",
+			poly:     box(0, 0, 1, 1),
+			clip:     box(2, 2, 3, 3),
+			wantArea: 0,
+		},
+		{
+			name:     "concave poly",
+			poly:     Polygon{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 2, Y: 1}, {X: 0, Y: 4}},
+			clip:     box(0, 0, 4, 2),
+			wantArea: 22.0 / 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.poly.ClipConvex(tt.clip)
+			if area := got.Area(); math.Abs(area-tt.wantArea) > 1e-9 {
+				t.Errorf("ClipConvex() area = %v, want %v", area, tt.wantArea)
+			}
+			if tt.wantArea > 0 && got.Orientation() != tt.poly.Orientation() {
+				t.Errorf("ClipConvex() orientation = %v, want %v", got.Orientation(), tt.poly.Orientation())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package geom
+
+import (
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Circle represents a solid disc in 2D space.
+type Circle struct {
+	Centre vec.Vec2
+	Radius float64
+}
+
+// Area returns the area of the circle.
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Contains returns true if p lies inside or on the boundary of c.
+func (c Circle) Contains(p vec.Vec2) bool {
+	offset := p.Subtract(c.Centre)
+	return offset.Dot(offset) <= c.Radius*c.Radius
+}
+
+// Overlaps returns true if c1 and c2 share at least one point.
+func (c1 Circle) Overlaps(c2 Circle) bool {
+	offset := c1.Centre.Subtract(c2.Centre)
+	radii := c1.Radius + c2.Radius
+	return offset.Dot(offset) <= radii*radii
+}
+
+// OverlapsRect returns true if c and r share at least one point.
+func (c Circle) OverlapsRect(r Rect) bool {
+	return c.Contains(r.ClosestPoint(c.Centre))
+}
+
+// OverlapsSegment returns true if c and s share at least one point.
+func (c Circle) OverlapsSegment(s Segment2) bool {
+	return c.Contains(s.ClosestPoint(c.Centre))
+}
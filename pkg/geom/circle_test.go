@@ -0,0 +1,206 @@
+package geom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestCircle_Area(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Circle
+		want float64
+	}{
+		{
+			name: "unit",
+			c:    Circle{vec.Vec2{X: 3, Y: -1}, 1},
+			want: math.Pi,
+		},
+		{
+			name: "radius 2",
+			c:    Circle{vec.Vec2{X: 0, Y: 0}, 2},
+			want: 4 * math.Pi,
+		},
+		{
+			name: "radius 0",
+			c:    Circle{vec.Vec2{X: 0, Y: 0}, 0},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Area(); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("c.Area() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircle_Contains(t *testing.T) {
+	c := Circle{vec.Vec2{X: 1, Y: 1}, 2}
+
+	tests := []struct {
+		name string
+		p    vec.Vec2
+		want bool
+	}{
+		{
+			name: "centre",
+			p:    vec.Vec2{X: 1, Y: 1},
+			want: true,
+		},
+		{
+			name: "on boundary",
+			p:    vec.Vec2{X: -1, Y: 1},
+			want: true,
+		},
+		{
+			name: "just outside",
+			p:    vec.Vec2{X: 1, Y: 3.001},
+			want: false,
+		},
+		{
+			name: "inside the bounding square but outside the circle",
+			p:    vec.Vec2{X: 2.5, Y: 2.5},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Contains(tt.p); got != tt.want {
+				t.Errorf("c.Contains(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircle_Overlaps(t *testing.T) {
+	unit := Circle{vec.Vec2{X: 0, Y: 0}, 1}
+
+	tests := []struct {
+		name string
+		c1   Circle
+		c2   Circle
+		want bool
+	}{
+		{
+			name: "one inside the other",
+			c1:   unit,
+			c2:   Circle{vec.Vec2{X: 0.5, Y: 0}, 0.1},
+			want: true,
+		},
+		{
+			name: "touching",
+			c1:   unit,
+			c2:   Circle{vec.Vec2{X: 3, Y: 0}, 2},
+			want: true,
+		},
+		{
+			name: "separated",
+			c1:   unit,
+			c2:   Circle{vec.Vec2{X: 2, Y: 2}, 1},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c1.Overlaps(tt.c2); got != tt.want {
+				t.Errorf("c1.Overlaps(c2) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircle_OverlapsRect(t *testing.T) {
+	unit := Rect{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 1, Y: 1}}
+
+	tests := []struct {
+		name string
+		c    Circle
+		r    Rect
+		want bool
+	}{
+		{
+			name: "centre inside",
+			c:    Circle{vec.Vec2{X: 0.5, Y: 0.5}, 0.1},
+			r:    unit,
+			want: true,
+		},
+		{
+			name: "rect inside circle",
+			c:    Circle{vec.Vec2{X: 0.5, Y: 0.5}, 5},
+			r:    unit,
+			want: true,
+		},
+		{
+			name: "near edge",
+			c:    Circle{vec.Vec2{X: 1.5, Y: 0.5}, 0.6},
+			r:    unit,
+			want: true,
+		},
+		{
+			name: "touching corner",
+			c:    Circle{vec.Vec2{X: 4, Y: 5}, 5},
+			r:    unit,
+			want: true,
+		},
+		{
+			name: "near corner but outside",
+			c:    Circle{vec.Vec2{X: 2, Y: 2}, 1.4},
+			r:    unit,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.OverlapsRect(tt.r); got != tt.want {
+				t.Errorf("c.OverlapsRect(r) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircle_OverlapsSegment(t *testing.T) {
+	unit := Circle{vec.Vec2{X: 0, Y: 0}, 1}
+
+	tests := []struct {
+		name string
+		c    Circle
+		s    Segment2
+		want bool
+	}{
+		{
+			name: "crossing",
+			c:    unit,
+			s:    Segment2{vec.Vec2{X: -2, Y: 0}, vec.Vec2{X: 2, Y: 0}},
+			want: true,
+		},
+		{
+			name: "tangent",
+			c:    unit,
+			s:    Segment2{vec.Vec2{X: -2, Y: 1}, vec.Vec2{X: 2, Y: 1}},
+			want: true,
+		},
+		{
+			name: "entirely inside",
+			c:    unit,
+			s:    Segment2{vec.Vec2{X: -0.5, Y: 0}, vec.Vec2{X: 0.5, Y: 0}},
+			want: true,
+		},
+		{
+			name: "line would cross, but segment stops short",
+			c:    unit,
+			s:    Segment2{vec.Vec2{X: 2, Y: 0}, vec.Vec2{X: 3, Y: 0}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.OverlapsSegment(tt.s); got != tt.want {
+				t.Errorf("c.OverlapsSegment(s) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
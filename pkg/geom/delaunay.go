@@ -0,0 +1,340 @@
+package geom
+
+import (
+	"fmt"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Triangulation is a triangulation of a set of points, represented as a half-edge structure.
+//
+// Every triangle is made up of three consecutive half-edges: half-edges 3t, 3t+1 and 3t+2 belong to
+// triangle t, and run anticlockwise around it. Each half-edge runs from its Origin to the Origin of the
+// next half-edge in the same triangle.
+type Triangulation struct {
+	// Points holds the points that were triangulated. Half-edge origins are indices into this slice.
+	Points []vec.Vec2
+	// HalfEdges holds three half-edges per triangle.
+	HalfEdges []HalfEdge
+}
+
+// HalfEdge is one directed edge of a triangle in a [Triangulation].
+type HalfEdge struct {
+	// Origin is the index of the point this half-edge starts from.
+	Origin int
+	// Twin is the index of the half-edge running in the opposite direction along the same edge,
+	// in the neighbouring triangle. It is -1 if the edge lies on the convex hull.
+	Twin int
+}
+
+// TriangleCount returns the number of triangles in the triangulation.
+func (t Triangulation) TriangleCount() int {
+	return len(t.HalfEdges) / 3
+}
+
+// Triangle returns the indices of the points making up triangle i, in anticlockwise order.
+func (t Triangulation) Triangle(i int) [3]int {
+	return [3]int{
+		t.HalfEdges[3*i].Origin,
+		t.HalfEdges[3*i+1].Origin,
+		t.HalfEdges[3*i+2].Origin,
+	}
+}
+
+// Next returns the half-edge following e anticlockwise around its triangle.
+func (t Triangulation) Next(e int) int {
+	return e - e%3 + (e+1)%3
+}
+
+// Prev returns the half-edge preceding e anticlockwise around its triangle.
+func (t Triangulation) Prev(e int) int {
+	return e - e%3 + (e+2)%3
+}
+
+// Dest returns the index of the point half-edge e ends at.
+func (t Triangulation) Dest(e int) int {
+	return t.HalfEdges[t.Next(e)].Origin
+}
+
+// Neighbours returns, for each point, the indices of the points it shares an edge with.
+// Points that are not part of the triangulation, such as duplicates, have no neighbours.
+func (t Triangulation) Neighbours() [][]int {
+	neighbours := make([][]int, len(t.Points))
+
+	for e, he := range t.HalfEdges {
+		neighbours[he.Origin] = append(neighbours[he.Origin], t.Dest(e))
+
+		// interior edges are seen from both sides, but hull edges only once
+		if he.Twin < 0 {
+			dest := t.Dest(e)
+			neighbours[dest] = append(neighbours[dest], he.Origin)
+		}
+	}
+
+	return neighbours
+}
+
+// Delaunay returns the Delaunay triangulation of points, computed with the Bowyer-Watson algorithm.
+// No point lies strictly inside the circumcircle of any triangle, and the triangles together exactly
+// cover the convex hull of the points.
+//
+// Points are inserted in the order given, and all geometric decisions use exact predicates, so the
+// result depends only on the input. Where four or more points are cocircular, the choice between the
+// equally valid triangulations is made by insertion order. Duplicate points are only used once.
+//
+// If there are fewer than three distinct points, or every point is collinear, there are no triangles
+// and this function will return an error wrapping [vec.ErrInvalidArgument].
+func Delaunay(points []vec.Vec2) (Triangulation, error) {
+	d := delaunay{points: points}
+
+	first, err := d.initialTriangle()
+	if err != nil {
+		return Triangulation{}, vec.NewOpError("Delaunay", err)
+	}
+
+	for p := range points {
+		if p == first[0] || p == first[1] || p == first[2] {
+			continue
+		}
+		d.insert(p)
+	}
+
+	return d.result(), nil
+}
+
+// infinite is the vertex index used for the point at infinity shared by every ghost triangle.
+const infinite = -1
+
+// delaunay holds the working state of a Bowyer-Watson triangulation.
+//
+// The convex hull is surrounded by ghost triangles, each joining a hull edge to a single vertex at infinity.
+// This lets points outside the current hull be inserted in exactly the same way as points inside it.
+type delaunay struct {
+	points    []vec.Vec2
+	triangles []delaunayTriangle
+	// last is a live real triangle to start point location from
+	last int
+}
+
+type delaunayTriangle struct {
+	// v holds the vertices in anticlockwise order. For ghost triangles, one of these is infinite.
+	v [3]int
+	// n[i] is the neighbouring triangle across the edge opposite v[i]
+	n    [3]int
+	dead bool
+}
+
+func (t delaunayTriangle) ghost() bool {
+	return t.v[0] == infinite || t.v[1] == infinite || t.v[2] == infinite
+}
+
+// initialTriangle seeds the triangulation with the first three non-collinear points, returning their indices.
+func (d *delaunay) initialTriangle() ([3]int, error) {
+	a := 0
+	b := -1
+	for i, p := range d.points {
+		if !p.Equals(d.points[a]) {
+			b = i
+			break
+		}
+	}
+
+	if b < 0 {
+		return [3]int{}, fmt.Errorf("%w: at least 3 distinct points are needed", vec.ErrInvalidArgument)
+	}
+
+	c := -1
+	for i, p := range d.points {
+		if Orient2D(d.points[a], d.points[b], p) != Collinear {
+			c = i
+			break
+		}
+	}
+
+	if c < 0 {
+		return [3]int{}, fmt.Errorf("%w: every point is collinear", vec.ErrInvalidArgument)
+	}
+
+	if Orient2D(d.points[a], d.points[b], d.points[c]) == Clockwise {
+		b, c = c, b
+	}
+
+	// one real triangle, plus a ghost triangle on each of its edges
+	d.triangles = []delaunayTriangle{
+		{v: [3]int{a, b, c}, n: [3]int{2, 3, 1}},
+		{v: [3]int{b, a, infinite}, n: [3]int{3, 2, 0}},
+		{v: [3]int{c, b, infinite}, n: [3]int{1, 3, 0}},
+		{v: [3]int{a, c, infinite}, n: [3]int{2, 1, 0}},
+	}
+
+	return [3]int{a, b, c}, nil
+}
+
+// conflicts returns true if inserting p would destroy triangle t, because p lies inside its circumcircle.
+//
+// A ghost triangle's "circumcircle" is the open half-plane beyond its hull edge, plus the edge itself.
+func (d *delaunay) conflicts(t, p int) bool {
+	tri := d.triangles[t]
+	pt := d.points[p]
+
+	if !tri.ghost() {
+		return InCircle(d.points[tri.v[0]], d.points[tri.v[1]], d.points[tri.v[2]], pt) == Inside
+	}
+
+	// rotate so the infinite vertex is last
+	v := tri.v
+	for v[2] != infinite {
+		v = [3]int{v[1], v[2], v[0]}
+	}
+	a, b := d.points[v[0]], d.points[v[1]]
+
+	switch Orient2D(a, b, pt) {
+	case Anticlockwise:
+		return true
+	case Collinear:
+		// on the line through the hull edge: only a conflict if strictly between its endpoints
+		return pt.Subtract(a).Dot(pt.Subtract(b)) < 0
+	default:
+		return false
+	}
+}
+
+// locate walks from the last triangle towards p, returning a triangle that conflicts with p.
+// If p is a duplicate of an existing vertex, it returns -1.
+func (d *delaunay) locate(p int) int {
+	pt := d.points[p]
+	t := d.last
+
+	for {
+		tri := d.triangles[t]
+
+		if tri.ghost() {
+			return t
+		}
+
+		moved := false
+		for i := range 3 {
+			a, b := d.points[tri.v[(i+1)%3]], d.points[tri.v[(i+2)%3]]
+			if Orient2D(a, b, pt) == Clockwise {
+				t = tri.n[i]
+				moved = true
+				break
+			}
+		}
+
+		if !moved {
+			for _, v := range tri.v {
+				if d.points[v].Equals(pt) {
+					return -1
+				}
+			}
+			return t
+		}
+	}
+}
+
+// insert adds the point at index p, removing every triangle it conflicts with and filling the
+// resulting cavity with triangles that fan out from p.
+func (d *delaunay) insert(p int) {
+	start := d.locate(p)
+	if start < 0 {
+		return
+	}
+
+	// the conflicting triangles form a connected cavity, so flood fill from the located triangle
+	inCavity := map[int]bool{start: true}
+	cavity := []int{start}
+	for i := 0; i < len(cavity); i++ {
+		for _, n := range d.triangles[cavity[i]].n {
+			if !inCavity[n] && d.conflicts(n, p) {
+				inCavity[n] = true
+				cavity = append(cavity, n)
+			}
+		}
+	}
+
+	// new triangles, keyed by the start and end of the boundary edge they were built on
+	byFrom := make(map[int]int)
+	byTo := make(map[int]int)
+
+	for _, t := range cavity {
+		tri := d.triangles[t]
+
+		for i := range 3 {
+			outer := tri.n[i]
+			if inCavity[outer] {
+				continue
+			}
+
+			// the boundary edge, with the new triangle on the cavity side
+			from, to := tri.v[(i+1)%3], tri.v[(i+2)%3]
+			nt := len(d.triangles)
+			d.triangles = append(d.triangles, delaunayTriangle{
+				v: [3]int{from, to, p},
+				n: [3]int{-1, -1, outer},
+			})
+			byFrom[from] = nt
+			byTo[to] = nt
+
+			// point the outer neighbour back at the new triangle
+			for j := range 3 {
+				if d.triangles[outer].n[j] == t {
+					d.triangles[outer].n[j] = nt
+				}
+			}
+
+			if !d.triangles[nt].ghost() {
+				d.last = nt
+			}
+		}
+	}
+
+	// the cavity boundary is a single loop, so each new triangle (from, to, p) shares the edge (to, p)
+	// with the triangle built on the next boundary edge, and (p, from) with the one built on the previous
+	for _, t := range byFrom {
+		tri := &d.triangles[t]
+		tri.n[0] = byFrom[tri.v[1]]
+		tri.n[1] = byTo[tri.v[0]]
+	}
+
+	for _, t := range cavity {
+		d.triangles[t].dead = true
+	}
+}
+
+// result converts the live real triangles into a half-edge structure.
+func (d *delaunay) result() Triangulation {
+	index := make(map[int]int)
+	var live []int
+	for t, tri := range d.triangles {
+		if !tri.dead && !tri.ghost() {
+			index[t] = len(live)
+			live = append(live, t)
+		}
+	}
+
+	halfEdges := make([]HalfEdge, 3*len(live))
+	for i, t := range live {
+		tri := d.triangles[t]
+
+		for j := range 3 {
+			// half-edge j runs from v[j] to v[j+1], which is the edge opposite v[j+2]
+			twin := -1
+			neighbour := tri.n[(j+2)%3]
+			if k, ok := index[neighbour]; ok {
+				for m, v := range d.triangles[neighbour].v {
+					if v == tri.v[(j+1)%3] {
+						twin = 3*k + m
+					}
+				}
+			}
+
+			halfEdges[3*i+j] = HalfEdge{Origin: tri.v[j], Twin: twin}
+		}
+	}
+
+	return Triangulation{
+		Points:    d.points,
+		HalfEdges: halfEdges,
+	}
+}
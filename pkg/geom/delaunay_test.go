@@ -0,0 +1,161 @@
+package geom
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// checkDelaunay verifies the structural and geometric invariants of a Delaunay triangulation.
+func checkDelaunay(t *testing.T, tri Triangulation) {
+	t.Helper()
+
+	var area float64
+	for i := range tri.TriangleCount() {
+		v := tri.Triangle(i)
+		a, b, c := tri.Points[v[0]], tri.Points[v[1]], tri.Points[v[2]]
+
+		if Orient2D(a, b, c) != Anticlockwise {
+			t.Fatalf("triangle %v is not anticlockwise", v)
+		}
+
+		for p, pt := range tri.Points {
+			if InCircle(a, b, c, pt) == Inside {
+				t.Fatalf("point %v lies inside the circumcircle of triangle %v", p, v)
+			}
+		}
+
+		area += Polygon{a, b, c}.Area()
+	}
+
+	for e, he := range tri.HalfEdges {
+		if he.Twin < 0 {
+			continue
+		}
+		twin := tri.HalfEdges[he.Twin]
+		if twin.Twin != e || twin.Origin != tri.Dest(e) || tri.Dest(he.Twin) != he.Origin {
+			t.Fatalf("half-edge %v and its twin %v do not match", e, he.Twin)
+		}
+	}
+
+	if hullArea := ConvexHull2D(tri.Points).Area(); math.Abs(area-hullArea) > 1e-9*hullArea {
+		t.Errorf("triangles cover area %v, want hull area %v", area, hullArea)
+	}
+}
+
+func TestDelaunay(t *testing.T) {
+	tests := []struct {
+		name          string
+		points        []vec.Vec2
+		wantTriangles int
+	}{
+		{
+			name:          "single triangle",
+			points:        []vec.Vec2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}},
+			wantTriangles: 1,
+		},
+		{
+			name:          "square with centre",
+			points:        []vec.Vec2{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}, {X: 1, Y: 1}},
+			wantTriangles: 4,
+		},
+		{
+			name:          "collinear start and duplicates",
+			points:        []vec.Vec2{{X: 0, Y: 0}, {X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}},
+			wantTriangles: 2,
+		},
+		{
+			name: "grid of cocircular points",
+			points: []vec.Vec2{
+				{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0},
+				{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1},
+				{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2},
+			},
+			wantTriangles: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Delaunay(tt.points)
+			if err != nil {
+				t.Fatalf("Delaunay(points) error = %v", err)
+			}
+			if got.TriangleCount() != tt.wantTriangles {
+				t.Errorf("Delaunay(points).TriangleCount() = %v, want %v", got.TriangleCount(), tt.wantTriangles)
+			}
+			checkDelaunay(t, got)
+		})
+	}
+}
+
+func TestDelaunay_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]vec.Vec2, 300)
+	for i := range points {
+		points[i] = vec.Vec2{X: r.Float64(), Y: r.Float64()}
+	}
+
+	got, err := Delaunay(points)
+	if err != nil {
+		t.Fatalf("Delaunay(points) error = %v", err)
+	}
+	checkDelaunay(t, got)
+}
+
+func TestDelaunay_Degenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []vec.Vec2
+	}{
+		{
+			name:   "empty",
+			points: []vec.Vec2{},
+		},
+		{
+			name:   "duplicates",
+			points: []vec.Vec2{{X: 1, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 1}},
+		},
+		{
+			name:   "collinear",
+			points: []vec.Vec2{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Delaunay(tt.points); err == nil {
+				t.Errorf("Delaunay(points) error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestTriangulation_Voronoi(t *testing.T) {
+	points := []vec.Vec2{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}, {X: 1, Y: 1}}
+	bounds := Rect{vec.Vec2{X: -1, Y: -1}, vec.Vec2{X: 3, Y: 3}}
+
+	tri, err := Delaunay(points)
+	if err != nil {
+		t.Fatalf("Delaunay(points) error = %v", err)
+	}
+
+	cells := tri.Voronoi(bounds)
+
+	// the centre point's cell is the diamond between it and the four corners
+	if got := cells[4].Area(); math.Abs(got-2) > 1e-12 {
+		t.Errorf("area of centre cell = %v, want 2", got)
+	}
+
+	// the cells partition the bounds
+	var total float64
+	for i, cell := range cells {
+		if cell.Orientation() != Anticlockwise {
+			t.Errorf("cell %v is not anticlockwise", i)
+		}
+		total += cell.Area()
+	}
+	if math.Abs(total-bounds.Area()) > 1e-12 {
+		t.Errorf("total cell area = %v, want %v", total, bounds.Area())
+	}
+}
@@ -0,0 +1,49 @@
+package geom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestErrors(t *testing.T) {
+	ignore := func(_ any, err error) error { return err }
+
+	line := []vec.Vec2{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}}
+	square := Polygon{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}}
+	bowtie := Polygon{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 2}}
+	flat := []vec.Vec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 1, Y: 1, Z: 0}}
+
+	tests := []struct {
+		name   string
+		err    error
+		want   error
+		wantOp string
+	}{
+		{"NewPlane", ignore(NewPlane(vec.Vec3{}, vec.Vec3{X: 1})), vec.ErrZeroLength, "NewPlane"},
+		{"PlaneFromPoints", ignore(PlaneFromPoints(vec.Vec3{}, vec.Vec3{X: 1}, vec.Vec3{X: 2})), vec.ErrZeroLength, "PlaneFromPoints"},
+		{"Triangle3.Normal", ignore(Triangle3{}.Normal()), vec.ErrZeroLength, "Triangle3.Normal"},
+		{"Polygon.Centroid", ignore(Polygon(line).Centroid()), vec.ErrDivideByZero, "Polygon.Centroid"},
+		{"ConvexHull3D", ignore(ConvexHull3D(flat)), vec.ErrInvalidArgument, "ConvexHull3D"},
+		{"Delaunay", ignore(Delaunay(line)), vec.ErrInvalidArgument, "Delaunay"},
+		{"Triangulate self-intersecting", ignore(Triangulate(bowtie)), vec.ErrInvalidArgument, "Triangulate"},
+		{"Triangulate hole outside", ignore(Triangulate(square, Polygon{{X: 5, Y: 5}, {X: 6, Y: 5}, {X: 6, Y: 6}})), vec.ErrInvalidArgument, "Triangulate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.want)
+			}
+
+			var opErr *vec.OpError
+			if !errors.As(tt.err, &opErr) {
+				t.Fatalf("errors.As(%v) found no *vec.OpError", tt.err)
+			}
+			if opErr.Op != tt.wantOp {
+				t.Errorf("Op = %v, want %v", opErr.Op, tt.wantOp)
+			}
+		})
+	}
+}
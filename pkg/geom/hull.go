@@ -0,0 +1,325 @@
+package geom
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// ConvexHull2D returns the convex hull of points as an anticlockwise polygon, using Andrew's monotone chain algorithm.
+//
+// The hull starts from the point with the lowest X coordinate (breaking ties by the lowest Y coordinate).
+// Duplicate points, and points lying on an edge of the hull rather than at a corner, are omitted.
+//
+// If every point is identical the result has a single vertex, and if every point is collinear the result
+// has the two extreme points as its only vertices.
+func ConvexHull2D(points []vec.Vec2) Polygon {
+	sorted := slices.Clone(points)
+	slices.SortFunc(sorted, func(a, b vec.Vec2) int {
+		if c := cmp.Compare(a.X, b.X); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Y, b.Y)
+	})
+	sorted = slices.Compact(sorted)
+
+	if len(sorted) < 3 {
+		return Polygon(sorted)
+	}
+
+	hull := make(Polygon, 0, 2*len(sorted))
+
+	// lower hull, left to right
+	for _, p := range sorted {
+		for len(hull) >= 2 && Orient2D(hull[len(hull)-2], hull[len(hull)-1], p) != Anticlockwise {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+
+	// upper hull, right to left, stopping short of the points already in the lower hull
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		p := sorted[i]
+		for len(hull) >= lower && Orient2D(hull[len(hull)-2], hull[len(hull)-1], p) != Anticlockwise {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+
+	// the last point is the first point again
+	return hull[:len(hull)-1]
+}
+
+// HullFace is a triangular face of a 3D convex hull.
+type HullFace struct {
+	// Indices holds the positions of the face's vertices in the slice passed to [ConvexHull3D].
+	// The vertices appear anticlockwise when viewed from outside the hull.
+	Indices [3]int
+	// Normal is the outward-facing unit normal of the face.
+	Normal vec.Vec3
+}
+
+// ConvexHull3D returns the convex hull of points as a set of triangular faces, using the quickhull algorithm.
+//
+// Duplicate points, and points lying on a face or edge of the hull rather than at a corner, are never used as
+// vertices. Where a duplicate point is a hull vertex, the first occurrence is used. Flat regions of the hull
+// are returned as several coplanar triangles. The faces are sorted by their indices, so the result depends
+// only on the input.
+//
+// If every point is coplanar, the hull has no volume and this function will return an error wrapping [vec.ErrInvalidArgument].
+func ConvexHull3D(points []vec.Vec3) ([]HullFace, error) {
+	h := hull3{points: points}
+	if err := h.initialTetrahedron(); err != nil {
+		return nil, vec.NewOpError("ConvexHull3D", err)
+	}
+
+	h.assignOutside(h.allIndices(), h.faceIndices())
+
+	for {
+		f, p := h.nextConflict()
+		if f < 0 {
+			break
+		}
+		h.addPoint(p)
+	}
+
+	return h.result(), nil
+}
+
+// hull3 holds the working state of a quickhull computation.
+type hull3 struct {
+	points []vec.Vec3
+	faces  []hull3Face
+}
+
+type hull3Face struct {
+	v       [3]int
+	outside []int
+	dead    bool
+}
+
+// initialTetrahedron finds four non-coplanar points and seeds the hull with the tetrahedron between them.
+func (h *hull3) initialTetrahedron() error {
+	if len(h.points) < 4 {
+		return fmt.Errorf("%w: at least 4 non-coplanar points are needed", vec.ErrInvalidArgument)
+	}
+
+	// start with the two points furthest apart along the X axis, then the point furthest from the line
+	// through them, then the point furthest from the plane through all three
+	p0, p1 := 0, 0
+	for i, p := range h.points {
+		if lessVec3(p, h.points[p0]) {
+			p0 = i
+		}
+		if lessVec3(h.points[p1], p) {
+			p1 = i
+		}
+	}
+
+	if h.points[p0].Equals(h.points[p1]) {
+		return fmt.Errorf("%w: every point is identical", vec.ErrInvalidArgument)
+	}
+
+	a, b := h.points[p0], h.points[p1]
+	p2, best := -1, 0.0
+	for i, p := range h.points {
+		if collinear3(a, b, p) {
+			continue
+		}
+		if d := b.Subtract(a).Cross(p.Subtract(a)).Magnitude(); p2 < 0 || d > best {
+			p2, best = i, d
+		}
+	}
+
+	if p2 < 0 {
+		return fmt.Errorf("%w: every point is collinear", vec.ErrInvalidArgument)
+	}
+
+	c := h.points[p2]
+	normal := b.Subtract(a).Cross(c.Subtract(a))
+	p3, best := -1, 0.0
+	for i, p := range h.points {
+		if Orient3D(a, b, c, p) == Coplanar {
+			continue
+		}
+		if d := math.Abs(normal.Dot(p.Subtract(a))); p3 < 0 || d > best {
+			p3, best = i, d
+		}
+	}
+
+	if p3 < 0 {
+		return fmt.Errorf("%w: every point is coplanar", vec.ErrInvalidArgument)
+	}
+
+	// orient the base so that the apex lies behind it, i.e. inside the hull
+	if Orient3D(a, b, c, h.points[p3]) == Above {
+		p1, p2 = p2, p1
+	}
+
+	h.faces = []hull3Face{
+		{v: [3]int{p0, p1, p2}},
+		{v: [3]int{p0, p3, p1}},
+		{v: [3]int{p1, p3, p2}},
+		{v: [3]int{p2, p3, p0}},
+	}
+
+	return nil
+}
+
+// sees returns true if the point at index p lies strictly outside face f.
+func (h *hull3) sees(f, p int) bool {
+	v := h.faces[f].v
+	return Orient3D(h.points[v[0]], h.points[v[1]], h.points[v[2]], h.points[p]) == Above
+}
+
+// assignOutside adds each candidate point to the outside set of the first face in faces that can see it.
+// Points that no face can see lie inside the hull and are discarded.
+func (h *hull3) assignOutside(candidates, faces []int) {
+	for _, p := range candidates {
+		for _, f := range faces {
+			if h.sees(f, p) {
+				h.faces[f].outside = append(h.faces[f].outside, p)
+				break
+			}
+		}
+	}
+}
+
+// nextConflict returns the first live face with a non-empty outside set, and the point in that set
+// furthest from the face. If every point is inside the hull, it returns -1.
+func (h *hull3) nextConflict() (face, point int) {
+	for f, face := range h.faces {
+		if face.dead || len(face.outside) == 0 {
+			continue
+		}
+
+		a, b, c := h.points[face.v[0]], h.points[face.v[1]], h.points[face.v[2]]
+		normal := b.Subtract(a).Cross(c.Subtract(a))
+
+		best, bestDistance := -1, 0.0
+		for _, p := range face.outside {
+			if d := normal.Dot(h.points[p].Subtract(a)); best < 0 || d > bestDistance {
+				best, bestDistance = p, d
+			}
+		}
+
+		return f, best
+	}
+
+	return -1, -1
+}
+
+// addPoint expands the hull to include the point at index p, replacing every face that can see it
+// with a cone of new faces joining p to the horizon.
+func (h *hull3) addPoint(p int) {
+	visible := make(map[int]bool)
+	for f := range h.faces {
+		if !h.faces[f].dead && h.sees(f, p) {
+			visible[f] = true
+		}
+	}
+
+	// an edge is on the horizon if it belongs to a visible face but its reverse does not
+	type edge struct{ from, to int }
+	visibleEdges := make(map[edge]bool)
+	var orphans []int
+	for f := range h.faces {
+		if !visible[f] {
+			continue
+		}
+		v := h.faces[f].v
+		for i := range 3 {
+			visibleEdges[edge{v[i], v[(i+1)%3]}] = true
+		}
+		for _, o := range h.faces[f].outside {
+			if o != p {
+				orphans = append(orphans, o)
+			}
+		}
+		h.faces[f].dead = true
+		h.faces[f].outside = nil
+	}
+
+	var created []int
+	for f := range h.faces {
+		if !visible[f] {
+			continue
+		}
+		v := h.faces[f].v
+		for i := range 3 {
+			e := edge{v[i], v[(i+1)%3]}
+			if !visibleEdges[edge{e.to, e.from}] {
+				created = append(created, len(h.faces))
+				h.faces = append(h.faces, hull3Face{v: [3]int{e.from, e.to, p}})
+			}
+		}
+	}
+
+	h.assignOutside(orphans, created)
+}
+
+// result collects the live faces, normalised so that each starts from its lowest index, and sorted.
+func (h *hull3) result() []HullFace {
+	var faces []HullFace
+
+	for _, face := range h.faces {
+		if face.dead {
+			continue
+		}
+
+		v := face.v
+		for v[0] > v[1] || v[0] > v[2] {
+			v = [3]int{v[1], v[2], v[0]}
+		}
+
+		a, b, c := h.points[v[0]], h.points[v[1]], h.points[v[2]]
+		normal, _ := b.Subtract(a).Cross(c.Subtract(a)).Normalised()
+
+		faces = append(faces, HullFace{Indices: v, Normal: normal})
+	}
+
+	slices.SortFunc(faces, func(f1, f2 HullFace) int {
+		return slices.Compare(f1.Indices[:], f2.Indices[:])
+	})
+
+	return faces
+}
+
+func (h *hull3) allIndices() []int {
+	indices := make([]int, len(h.points))
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+func (h *hull3) faceIndices() []int {
+	indices := make([]int, len(h.faces))
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+// collinear3 returns true if a, b and c lie exactly on one line, which is the case only if their
+// projections onto all three axis planes are collinear.
+func collinear3(a, b, c vec.Vec3) bool {
+	return Orient2D(vec.Vec2{X: a.X, Y: a.Y}, vec.Vec2{X: b.X, Y: b.Y}, vec.Vec2{X: c.X, Y: c.Y}) == Collinear &&
+		Orient2D(vec.Vec2{X: a.Y, Y: a.Z}, vec.Vec2{X: b.Y, Y: b.Z}, vec.Vec2{X: c.Y, Y: c.Z}) == Collinear &&
+		Orient2D(vec.Vec2{X: a.X, Y: a.Z}, vec.Vec2{X: b.X, Y: b.Z}, vec.Vec2{X: c.X, Y: c.Z}) == Collinear
+}
+
+// lessVec3 orders points lexicographically by X, then Y, then Z.
+func lessVec3(a, b vec.Vec3) bool {
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	if a.Y != b.Y {
+		return a.Y < b.Y
+	}
+	return a.Z < b.Z
+}
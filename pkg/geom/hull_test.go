@@ -0,0 +1,150 @@
+package geom
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestConvexHull2D(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []vec.Vec2
+		want   Polygon
+	}{
+		{
+			name:   "empty",
+			points: []vec.Vec2{},
+			want:   Polygon{},
+		},
+		{
+			name:   "duplicates of one point",
+			points: []vec.Vec2{{X: 1, Y: 1}, {X: 1, Y: 1}},
+			want:   Polygon{{X: 1, Y: 1}},
+		},
+		{
+			name:   "collinear",
+			points: []vec.Vec2{{X: 1, Y: 1}, {X: 3, Y: 3}, {X: 0, Y: 0}, {X: 2, Y: 2}},
+			want:   Polygon{{X: 0, Y: 0}, {X: 3, Y: 3}},
+		},
+		{
+			name: "square with interior, edge and duplicate points",
+			points: []vec.Vec2{
+				{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 0, Y: 2}, {X: 0, Y: 0}, {X: 2, Y: 0},
+				{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 0, Y: 0}, {X: 0.5, Y: 1.5},
+			},
+			want: Polygon{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvexHull2D(tt.points); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvexHull2D(points) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvexHull3D(t *testing.T) {
+	cube := []vec.Vec3{
+		{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 1, Y: 1, Z: 0},
+		{X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 1}, {X: 0, Y: 1, Z: 1}, {X: 1, Y: 1, Z: 1},
+		// centre, face centre, edge midpoint and a duplicate corner, none of which should be used
+		{X: 0.5, Y: 0.5, Z: 0.5}, {X: 0.5, Y: 0.5, Z: 1}, {X: 1, Y: 0.5, Z: 0}, {X: 1, Y: 1, Z: 1},
+	}
+
+	faces, err := ConvexHull3D(cube)
+	if err != nil {
+		t.Fatalf("ConvexHull3D(cube) error = %v", err)
+	}
+
+	if len(faces) != 12 {
+		t.Errorf("len(ConvexHull3D(cube)) = %v, want 12", len(faces))
+	}
+
+	centre := vec.Vec3{X: 0.5, Y: 0.5, Z: 0.5}
+	for _, f := range faces {
+		for _, i := range f.Indices {
+			if i > 7 {
+				t.Errorf("face %v uses non-corner point %v", f.Indices, cube[i])
+			}
+		}
+		outward := cube[f.Indices[0]].Subtract(centre)
+		if f.Normal.Dot(outward) <= 0 {
+			t.Errorf("face %v normal %v does not point outwards", f.Indices, f.Normal)
+		}
+	}
+
+	again, _ := ConvexHull3D(cube)
+	if !reflect.DeepEqual(faces, again) {
+		t.Errorf("ConvexHull3D(cube) is not deterministic")
+	}
+}
+
+func TestConvexHull3D_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]vec.Vec3, 500)
+	for i := range points {
+		points[i] = vec.Vec3{X: r.NormFloat64(), Y: r.NormFloat64(), Z: r.NormFloat64()}
+	}
+
+	faces, err := ConvexHull3D(points)
+	if err != nil {
+		t.Fatalf("ConvexHull3D(points) error = %v", err)
+	}
+
+	// every point must be inside or on every face, and the hull must be a closed surface (V - E + F = 2)
+	edges := make(map[[2]int]int)
+	vertices := make(map[int]bool)
+	for _, f := range faces {
+		a, b, c := points[f.Indices[0]], points[f.Indices[1]], points[f.Indices[2]]
+		for i, p := range points {
+			if Orient3D(a, b, c, p) == Above {
+				t.Fatalf("point %v is outside face %v", i, f.Indices)
+			}
+		}
+		for i := range 3 {
+			edges[[2]int{f.Indices[i], f.Indices[(i+1)%3]}]++
+			vertices[f.Indices[i]] = true
+		}
+	}
+
+	for e, n := range edges {
+		if n != 1 || edges[[2]int{e[1], e[0]}] != 1 {
+			t.Fatalf("edge %v is not shared by exactly two faces", e)
+		}
+	}
+
+	if euler := len(vertices) - len(edges)/2 + len(faces); euler != 2 {
+		t.Errorf("V - E + F = %v, want 2", euler)
+	}
+}
+
+func TestConvexHull3D_Degenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []vec.Vec3
+	}{
+		{
+			name:   "too few points",
+			points: []vec.Vec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}},
+		},
+		{
+			name:   "collinear",
+			points: []vec.Vec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 1, Z: 1}, {X: 2, Y: 2, Z: 2}, {X: 3, Y: 3, Z: 3}},
+		},
+		{
+			name:   "coplanar",
+			points: []vec.Vec3{{X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 1}, {X: 0, Y: 1, Z: 1}, {X: 1, Y: 1, Z: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ConvexHull3D(tt.points); err == nil {
+				t.Errorf("ConvexHull3D(points) error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package geom
+
+import (
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// JoinStyle determines how offset edges are joined around corners that the offset pulls them away from.
+type JoinStyle int
+
+const (
+	// MiterJoin extends the offset edges until they meet at a point, falling back to [BevelJoin] where that
+	// point would lie too far from the corner.
+	MiterJoin JoinStyle = iota
+	// RoundJoin joins the offset edges with a circular arc centred on the corner.
+	RoundJoin
+	// BevelJoin joins the ends of the offset edges with a straight line.
+	BevelJoin
+)
+
+// CapStyle determines how the ends of an offset polyline are closed off.
+type CapStyle int
+
+const (
+	// ButtCap ends the offset flush with the end of the polyline.
+	ButtCap CapStyle = iota
+	// SquareCap extends the offset past the end of the polyline by the offset distance, with square corners.
+	SquareCap
+	// RoundCap closes the offset with a semicircle centred on the end of the polyline.
+	RoundCap
+)
+
+// OffsetOptions configures [MultiPolygon.Offset] and [OffsetPolyline].
+type OffsetOptions struct {
+	// Join is the style used at corners.
+	Join JoinStyle
+	// Cap is the style used at the ends of polylines. It is ignored when offsetting polygons.
+	Cap CapStyle
+	// MiterLimit is the furthest a miter may extend from its corner, as a multiple of the offset distance.
+	// Sharper corners are bevelled instead. If MiterLimit is 0, a limit of 2 is used.
+	MiterLimit float64
+	// ArcTolerance is the furthest that the straight edges approximating round joins and caps may stray from
+	// a true arc. If ArcTolerance is 0, a tolerance of 1% of the offset distance is used.
+	ArcTolerance float64
+}
+
+// Offset returns the region within distance delta of mp, growing it if delta is positive and shrinking it if
+// delta is negative. Holes shrink as the region around them grows, and vice versa.
+//
+// Parts of mp that are narrower than twice a negative delta disappear entirely, and parts separated by less than
+// twice a positive delta are merged. As with the boolean operations on [MultiPolygon], points of mp are those with
+// a non-zero winding number once outer rings are anticlockwise and holes clockwise.
+func (mp MultiPolygon) Offset(delta float64, opts OffsetOptions) MultiPolygon {
+	o := newOffsetter(delta, opts)
+
+	var edges []Segment2
+	addRing := func(ring Polygon, want Orientation) {
+		ring = removeDuplicates(ring)
+		for len(ring) > 1 && ring[0].Equals(ring[len(ring)-1]) {
+			ring = ring[:len(ring)-1]
+		}
+
+		switch ring.Orientation() {
+		case Collinear:
+			return
+		case -want:
+			ring = ring.Reversed()
+		}
+
+		o.points = nil
+		for i, p := range ring {
+			prev, next := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+			o.join(p, direction(prev, p), direction(p, next))
+		}
+		edges = append(edges, o.edges()...)
+	}
+
+	for _, p := range mp {
+		addRing(p.Outer, Anticlockwise)
+		for _, hole := range p.Holes {
+			addRing(hole, Clockwise)
+		}
+	}
+
+	// each offset ring winds once around the points it should cover, but may also form loops that wind
+	// backwards where the offset overtakes itself, so only count positive windings
+	return overlay(edges, nil, positive, func(in, _ bool) bool { return in })
+}
+
+// OffsetPolyline returns the region within distance of the open path through points, often called a buffer.
+//
+// If distance is not positive, or points is empty, the result is empty. If points holds a single point,
+// the result is a circle or square around it for [RoundCap] and [SquareCap] respectively, and empty for [ButtCap].
+func OffsetPolyline(points []vec.Vec2, distance float64, opts OffsetOptions) MultiPolygon {
+	points = removeDuplicates(points)
+	if distance <= 0 || len(points) == 0 {
+		return nil
+	}
+
+	o := newOffsetter(distance, opts)
+
+	if len(points) == 1 {
+		p := points[0]
+		switch opts.Cap {
+		case SquareCap:
+			return MultiPolygon{{Outer: Rect{
+				vec.Vec2{X: p.X - distance, Y: p.Y - distance},
+				vec.Vec2{X: p.X + distance, Y: p.Y + distance},
+			}.Polygon()}}
+		case RoundCap:
+			o.arc(p, vec.Vec2{X: distance, Y: 0}, 2*math.Pi)
+			return MultiPolygon{{Outer: o.points[:len(o.points)-1]}}
+		default:
+			return nil
+		}
+	}
+
+	// walk down the right hand side of the path and back up the other, which is the right hand side of the
+	// reversed path, capping each end
+	for _, path := range [][]vec.Vec2{points, Polygon(points).Reversed()} {
+		for i := 1; i < len(path)-1; i++ {
+			o.join(path[i], direction(path[i-1], path[i]), direction(path[i], path[i+1]))
+		}
+		o.cap(path[len(path)-1], direction(path[len(path)-2], path[len(path)-1]))
+	}
+
+	// the path crosses itself wherever the polyline does, but every part of it winds positively
+	return overlay(o.edges(), nil, positive, func(in, _ bool) bool { return in })
+}
+
+// offsetter builds a raw offset ring, shifting each edge to its right by delta.
+// The ring may cross itself, and must be cleaned up with [overlay].
+type offsetter struct {
+	delta  float64
+	opts   OffsetOptions
+	points Polygon
+	// maxStep is the largest angle an arc may turn through between consecutive points
+	maxStep float64
+}
+
+func newOffsetter(delta float64, opts OffsetOptions) *offsetter {
+	if opts.MiterLimit == 0 {
+		opts.MiterLimit = 2
+	}
+	o := &offsetter{delta: delta, opts: opts}
+
+	tolerance := opts.ArcTolerance
+	if tolerance == 0 {
+		tolerance = math.Abs(delta) / 100
+	}
+
+	// an arc of radius |delta| can turn through this angle before its chord strays further than tolerance
+	o.maxStep = math.Pi
+	if r := math.Abs(delta); tolerance < r {
+		o.maxStep = 2 * math.Acos(1-tolerance/r)
+	}
+
+	return o
+}
+
+// join adds the points joining the offsets of the edges either side of the corner p, where the path
+// arrives with unit direction d1 and leaves with unit direction d2.
+func (o *offsetter) join(p, d1, d2 vec.Vec2) {
+	n1, n2 := rightNormal(d1), rightNormal(d2)
+	a, b := p.Add(n1.Multiply(o.delta)), p.Add(n2.Multiply(o.delta))
+	cross, dot := d1.Cross(d2), d1.Dot(d2)
+
+	switch {
+	case cross == 0 && dot > 0:
+		// straight on, so the offset edges already meet
+		o.points = append(o.points, a)
+		return
+	case cross*o.delta < 0:
+		// the offset edges overlap here; routing through the corner itself makes the overlap a loop that
+		// winds positively, which the clean up absorbs
+		o.points = append(o.points, a, p, b)
+		return
+	}
+
+	switch o.opts.Join {
+	case MiterJoin:
+		// the miter point lies along n1 + n2, at 1 / cos(theta / 2) times delta from the corner
+		if math.Sqrt(2/(1+dot)) <= o.opts.MiterLimit {
+			o.points = append(o.points, p.Add(n1.Add(n2).Multiply(o.delta/(1+dot))))
+			return
+		}
+		o.points = append(o.points, a, b)
+	case RoundJoin:
+		angle := math.Atan2(math.Abs(cross), dot)
+		if o.delta < 0 {
+			angle = -angle
+		}
+		o.arc(p, n1.Multiply(o.delta), angle)
+	default:
+		o.points = append(o.points, a, b)
+	}
+}
+
+// cap adds the points closing off the end p of a path, which arrives with unit direction d.
+func (o *offsetter) cap(p, d vec.Vec2) {
+	n := rightNormal(d).Multiply(o.delta)
+
+	switch o.opts.Cap {
+	case SquareCap:
+		forward := d.Multiply(o.delta)
+		o.points = append(o.points, p.Add(n).Add(forward), p.Subtract(n).Add(forward))
+	case RoundCap:
+		o.arc(p, n, math.Pi)
+	default:
+		o.points = append(o.points, p.Add(n), p.Subtract(n))
+	}
+}
+
+// arc adds points along the arc centred on centre, starting at centre + from and turning anticlockwise
+// through angle, which is negative for clockwise arcs.
+func (o *offsetter) arc(centre, from vec.Vec2, angle float64) {
+	steps := int(math.Ceil(math.Abs(angle) / o.maxStep))
+
+	for i := range steps + 1 {
+		rotation := vec.Rotation2D(angle * float64(i) / float64(steps))
+		o.points = append(o.points, centre.Add(rotation.MulDirection(from)))
+	}
+}
+
+// edges returns the edges of the ring built so far, skipping any of zero length.
+func (o *offsetter) edges() []Segment2 {
+	var edges []Segment2
+
+	for _, edge := range o.points.Edges() {
+		if !edge.A.Equals(edge.B) {
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges
+}
+
+// direction returns the unit vector pointing from a towards b, which must be distinct.
+func direction(a, b vec.Vec2) vec.Vec2 {
+	d, _ := b.Subtract(a).Normalised()
+	return d
+}
+
+// rightNormal returns d rotated a quarter turn clockwise.
+func rightNormal(d vec.Vec2) vec.Vec2 {
+	return vec.Vec2{X: d.Y, Y: -d.X}
+}
+
+// removeDuplicates returns a copy of points without consecutive repeats.
+func removeDuplicates(points []vec.Vec2) Polygon {
+	var cleaned Polygon
+
+	for _, p := range points {
+		if len(cleaned) == 0 || !cleaned[len(cleaned)-1].Equals(p) {
+			cleaned = append(cleaned, p)
+		}
+	}
+
+	return cleaned
+}
@@ -0,0 +1,260 @@
+package geom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestMultiPolygon_Offset(t *testing.T) {
+	lShape := Polygon{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2}}
+	withHole := MultiPolygon{{Outer: box(0, 0, 10, 10), Holes: []Polygon{box(4, 4, 6, 6)}}}
+
+	tests := []struct {
+		name      string
+		mp        MultiPolygon
+		delta     float64
+		opts      OffsetOptions
+		wantArea  float64
+		tolerance float64
+	}{
+		{
+			name:     "miter square",
+			mp:       MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+			delta:    1,
+			opts:     OffsetOptions{Join: MiterJoin},
+			wantArea: 16,
+		},
+		{
+			name:     "clockwise miter square",
+			mp:       MultiPolygon{{Outer: box(0, 0, 2, 2).Reversed()}},
+			delta:    1,
+			opts:     OffsetOptions{Join: MiterJoin},
+			wantArea: 16,
+		},
+		{
+			name:     "bevel square",
+			mp:       MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+			delta:    1,
+			opts:     OffsetOptions{Join: BevelJoin},
+			wantArea: 14,
+		},
+		{
+			name:     "miter beyond limit is bevelled",
+			mp:       MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+			delta:    1,
+			opts:     OffsetOptions{Join: MiterJoin, MiterLimit: 1.2},
+			wantArea: 14,
+		},
+		{
+			name:      "round square",
+			mp:        MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+			delta:     1,
+			opts:      OffsetOptions{Join: RoundJoin},
+			wantArea:  12 + math.Pi,
+			tolerance: 0.05,
+		},
+		{
+			name:     "shrink square",
+			mp:       MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+			delta:    -0.5,
+			opts:     OffsetOptions{Join: RoundJoin},
+			wantArea: 1,
+		},
+		{
+			name:     "shrink square away",
+			mp:       MultiPolygon{{Outer: box(0, 0, 2, 2)}},
+			delta:    -1.5,
+			wantArea: 0,
+		},
+		{
+			name:     "concave corner",
+			mp:       MultiPolygon{{Outer: lShape}},
+			delta:    1,
+			wantArea: 15,
+		},
+		{
+			name:  "shrink concave corner",
+			mp:    MultiPolygon{{Outer: lShape}},
+			delta: -0.25,
+			opts:  OffsetOptions{Join: BevelJoin},
+			// the bevel across the inner corner keeps a small triangle a miter would remove
+			wantArea: 1.5*0.5 + 0.5*1 + 0.25*0.25/2,
+		},
+		{
+			name:     "hole shrinks",
+			mp:       withHole,
+			delta:    0.5,
+			wantArea: 121 - 1,
+		},
+		{
+			name:     "hole closes",
+			mp:       withHole,
+			delta:    1.5,
+			wantArea: 169,
+		},
+		{
+			name:     "hole grows",
+			mp:       withHole,
+			delta:    -1,
+			wantArea: 64 - 16,
+		},
+		{
+			name:     "nearby squares merge",
+			mp:       MultiPolygon{{Outer: box(0, 0, 1, 1)}, {Outer: box(1.5, 0, 2.5, 1)}},
+			delta:    0.5,
+			wantArea: 7,
+		},
+		{
+			name:     "zero distance",
+			mp:       MultiPolygon{{Outer: lShape}},
+			delta:    0,
+			wantArea: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.mp.Offset(tt.delta, tt.opts)
+			if area := got.Area(); math.Abs(area-tt.wantArea) > tt.tolerance+1e-9 {
+				t.Errorf("Offset() area = %v, want %v", area, tt.wantArea)
+			}
+		})
+	}
+}
+
+func TestOffsetPolyline(t *testing.T) {
+	segment := []vec.Vec2{{X: 0, Y: 0}, {X: 4, Y: 0}}
+	corner := []vec.Vec2{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}}
+
+	tests := []struct {
+		name      string
+		points    []vec.Vec2
+		distance  float64
+		opts      OffsetOptions
+		wantArea  float64
+		tolerance float64
+	}{
+		{
+			name:     "butt segment",
+			points:   segment,
+			distance: 1,
+			opts:     OffsetOptions{Cap: ButtCap},
+			wantArea: 8,
+		},
+		{
+			name:     "square segment",
+			points:   segment,
+			distance: 1,
+			opts:     OffsetOptions{Cap: SquareCap},
+			wantArea: 12,
+		},
+		{
+			name:      "round segment",
+			points:    segment,
+			distance:  1,
+			opts:      OffsetOptions{Cap: RoundCap},
+			wantArea:  8 + math.Pi,
+			tolerance: 0.05,
+		},
+		{
+			name:     "miter corner",
+			points:   corner,
+			distance: 1,
+			opts:     OffsetOptions{Join: MiterJoin},
+			wantArea: 16,
+		},
+		{
+			name:     "bevel corner",
+			points:   corner,
+			distance: 1,
+			opts:     OffsetOptions{Join: BevelJoin},
+			wantArea: 15.5,
+		},
+		{
+			name:      "round corner",
+			points:    corner,
+			distance:  1,
+			opts:      OffsetOptions{Join: RoundJoin},
+			wantArea:  15 + math.Pi/4,
+			tolerance: 0.05,
+		},
+		{
+			name:      "doubling back",
+			points:    []vec.Vec2{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 0}},
+			distance:  1,
+			opts:      OffsetOptions{Join: RoundJoin, Cap: RoundCap},
+			wantArea:  8 + math.Pi,
+			tolerance: 0.05,
+		},
+		{
+			name:     "repeated points",
+			points:   []vec.Vec2{{X: 0, Y: 0}, {X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 0}},
+			distance: 1,
+			wantArea: 8,
+		},
+		{
+			name:      "single point round",
+			points:    []vec.Vec2{{X: 1, Y: 1}},
+			distance:  1,
+			opts:      OffsetOptions{Cap: RoundCap},
+			wantArea:  math.Pi,
+			tolerance: 0.05,
+		},
+		{
+			name:     "single point square",
+			points:   []vec.Vec2{{X: 1, Y: 1}},
+			distance: 1,
+			opts:     OffsetOptions{Cap: SquareCap},
+			wantArea: 4,
+		},
+		{
+			name:     "single point butt",
+			points:   []vec.Vec2{{X: 1, Y: 1}},
+			distance: 1,
+			wantArea: 0,
+		},
+		{
+			name:     "zero distance",
+			points:   segment,
+			distance: 0,
+			wantArea: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OffsetPolyline(tt.points, tt.distance, tt.opts)
+			if area := got.Area(); math.Abs(area-tt.wantArea) > tt.tolerance+1e-9 {
+				t.Errorf("OffsetPolyline() area = %v, want %v", area, tt.wantArea)
+			}
+		})
+	}
+}
+
+func TestOffsetPolyline_Distance(t *testing.T) {
+	// a zigzag that crosses itself, buffered with round joins and caps, should cover exactly the points
+	// within the distance of the path, give or take the arc tolerance
+	points := []vec.Vec2{{X: 0, Y: 0}, {X: 6, Y: 3}, {X: 6, Y: 0}, {X: 1, Y: 4}, {X: 2, Y: 4.5}}
+	const distance = 1.2
+	got := OffsetPolyline(points, distance, OffsetOptions{Join: RoundJoin, Cap: RoundCap, ArcTolerance: 0.01})
+
+	for x := -2.0; x < 9; x += 0.1 {
+		for y := -2.0; y < 7; y += 0.1 {
+			p := vec.Vec2{X: x, Y: y}
+
+			d := math.Inf(1)
+			for i := range len(points) - 1 {
+				d = math.Min(d, Segment2{points[i], points[i+1]}.Distance(p))
+			}
+
+			if d < distance-0.02 && !got.Contains(p) {
+				t.Fatalf("point %v at distance %v is not covered", p, d)
+			}
+			if d > distance+0.02 && got.Contains(p) {
+				t.Fatalf("point %v at distance %v is covered", p, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package geom
+
+import (
+	"fmt"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Plane represents an infinite plane in 3D space, consisting of all points p such that Normal . p = D.
+//
+// Normal is expected to be of unit length. Use [NewPlane] or [PlaneFromPoints] to guarantee this.
+type Plane struct {
+	Normal vec.Vec3
+	D      float64
+}
+
+// NewPlane returns the plane with the given normal that passes through point.
+//
+// Since a 0-length normal has no direction, if |normal| = 0 then this function will return an error wrapping [vec.ErrZeroLength].
+func NewPlane(normal, point vec.Vec3) (Plane, error) {
+	unit, err := normal.Normalised()
+	if err != nil {
+		return Plane{}, vec.NewOpError("NewPlane", vec.ErrZeroLength, normal, point)
+	}
+
+	return Plane{
+		Normal: unit,
+		D:      unit.Dot(point),
+	}, nil
+}
+
+// PlaneFromPoints returns the plane passing through a, b and c.
+// The normal points towards the side from which a, b and c appear anticlockwise.
+//
+// If the three points are collinear, they do not define a unique plane and this function will return an error
+// wrapping [vec.ErrZeroLength], as the normal they give has no length.
+func PlaneFromPoints(a, b, c vec.Vec3) (Plane, error) {
+	normal, err := b.Subtract(a).Cross(c.Subtract(a)).Normalised()
+	if err != nil {
+		return Plane{}, vec.NewOpError("PlaneFromPoints", fmt.Errorf("%w: points are collinear", vec.ErrZeroLength), a, b, c)
+	}
+
+	return Plane{
+		Normal: normal,
+		D:      normal.Dot(a),
+	}, nil
+}
+
+// SignedDistance returns the distance from the plane to p, positive on the side the normal points towards.
+func (pl Plane) SignedDistance(p vec.Vec3) float64 {
+	return pl.Normal.Dot(p) - pl.D
+}
+
+// ClosestPoint returns the point on the plane nearest to p.
+func (pl Plane) ClosestPoint(p vec.Vec3) vec.Vec3 {
+	return p.Subtract(pl.Normal.Multiply(pl.SignedDistance(p)))
+}
@@ -0,0 +1,165 @@
+package geom
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestNewPlane(t *testing.T) {
+	tests := []struct {
+		name    string
+		normal  vec.Vec3
+		point   vec.Vec3
+		want    Plane
+		wantErr error
+	}{
+		{
+			name:   "unit normal through origin",
+			normal: vec.Vec3{X: 0, Y: 1, Z: 0},
+			point:  vec.Vec3{X: 3, Y: 0, Z: -2},
+			want:   Plane{vec.Vec3{X: 0, Y: 1, Z: 0}, 0},
+		},
+		{
+			name:   "normal is normalised",
+			normal: vec.Vec3{X: 0, Y: 0, Z: -5},
+			point:  vec.Vec3{X: 1, Y: 1, Z: 2},
+			want:   Plane{vec.Vec3{X: 0, Y: 0, Z: -1}, -2},
+		},
+		{
+			name:    "0-length normal",
+			normal:  vec.Vec3{},
+			point:   vec.Vec3{X: 1, Y: 1, Z: 1},
+			wantErr: vec.ErrZeroLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPlane(tt.normal, tt.point)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewPlane() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (!got.Normal.AlmostEquals(tt.want.Normal, 1e-12) || math.Abs(got.D-tt.want.D) > 1e-12) {
+				t.Errorf("NewPlane() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaneFromPoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c vec.Vec3
+		want    Plane
+		wantErr error
+	}{
+		{
+			name: "anticlockwise from above",
+			a:    vec.Vec3{X: 0, Y: 0, Z: 2},
+			b:    vec.Vec3{X: 1, Y: 0, Z: 2},
+			c:    vec.Vec3{X: 0, Y: 1, Z: 2},
+			want: Plane{vec.Vec3{X: 0, Y: 0, Z: 1}, 2},
+		},
+		{
+			name: "clockwise from above",
+			a:    vec.Vec3{X: 0, Y: 0, Z: 2},
+			b:    vec.Vec3{X: 0, Y: 1, Z: 2},
+			c:    vec.Vec3{X: 1, Y: 0, Z: 2},
+			want: Plane{vec.Vec3{X: 0, Y: 0, Z: -1}, -2},
+		},
+		{
+			name:    "collinear",
+			a:       vec.Vec3{X: 0, Y: 0, Z: 0},
+			b:       vec.Vec3{X: 1, Y: 1, Z: 1},
+			c:       vec.Vec3{X: 3, Y: 3, Z: 3},
+			wantErr: vec.ErrZeroLength,
+		},
+		{
+			name:    "repeated point",
+			a:       vec.Vec3{X: 1, Y: 2, Z: 3},
+			b:       vec.Vec3{X: 1, Y: 2, Z: 3},
+			c:       vec.Vec3{X: 0, Y: 0, Z: 0},
+			wantErr: vec.ErrZeroLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlaneFromPoints(tt.a, tt.b, tt.c)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlaneFromPoints() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (!got.Normal.AlmostEquals(tt.want.Normal, 1e-12) || math.Abs(got.D-tt.want.D) > 1e-12) {
+				t.Errorf("PlaneFromPoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlane_SignedDistance(t *testing.T) {
+	// the plane x + y = 2, through (1, 1, 0)
+	pl := Plane{vec.Vec3{X: math.Sqrt2 / 2, Y: math.Sqrt2 / 2, Z: 0}, math.Sqrt2}
+
+	tests := []struct {
+		name string
+		p    vec.Vec3
+		want float64
+	}{
+		{
+			name: "on plane",
+			p:    vec.Vec3{X: 2, Y: 0, Z: 7},
+			want: 0,
+		},
+		{
+			name: "in front",
+			p:    vec.Vec3{X: 2, Y: 2, Z: 0},
+			want: math.Sqrt2,
+		},
+		{
+			name: "behind",
+			p:    vec.Vec3{X: 0, Y: 0, Z: -3},
+			want: -math.Sqrt2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pl.SignedDistance(tt.p); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("pl.SignedDistance(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlane_ClosestPoint(t *testing.T) {
+	floor := Plane{Normal: vec.Vec3{X: 0, Y: 1, Z: 0}, D: 1}
+
+	tests := []struct {
+		name string
+		p    vec.Vec3
+		want vec.Vec3
+	}{
+		{
+			name: "above",
+			p:    vec.Vec3{X: 3, Y: 5, Z: -1},
+			want: vec.Vec3{X: 3, Y: 1, Z: -1},
+		},
+		{
+			name: "below",
+			p:    vec.Vec3{X: 0, Y: -2, Z: 4},
+			want: vec.Vec3{X: 0, Y: 1, Z: 4},
+		},
+		{
+			name: "on plane",
+			p:    vec.Vec3{X: 2, Y: 1, Z: 2},
+			want: vec.Vec3{X: 2, Y: 1, Z: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := floor.ClosestPoint(tt.p); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("floor.ClosestPoint(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Orientation describes the winding direction of a sequence of points.
+type Orientation int
+
+const (
+	// Clockwise indicates the points turn to the right.
+	Clockwise Orientation = -1
+	// Collinear indicates the points lie on a single line, and so turn neither way.
+	Collinear Orientation = 0
+	// Anticlockwise indicates the points turn to the left.
+	Anticlockwise Orientation = 1
+)
+
+// Polygon represents a polygon in 2D space as an ordered list of its vertices.
+// The polygon is implicitly closed: an edge joins the last vertex back to the first.
+//
+// Unless otherwise stated, methods do not require the polygon to be simple.
+type Polygon []vec.Vec2
+
+// Edges returns the edges of the polygon, in order, with edge i running from vertex i to vertex i+1.
+func (poly Polygon) Edges() []Segment2 {
+	edges := make([]Segment2, len(poly))
+
+	for i := range poly {
+		edges[i] = Segment2{poly[i], poly[(i+1)%len(poly)]}
+	}
+
+	return edges
+}
+
+// SignedArea returns the area enclosed by the polygon, which is positive if the vertices
+// are in anticlockwise order and negative if they are clockwise.
+func (poly Polygon) SignedArea() float64 {
+	// shoelace formula
+	var sum float64
+
+	for i := range poly {
+		sum += poly[i].Cross(poly[(i+1)%len(poly)])
+	}
+
+	return sum / 2
+}
+
+// Area returns the area enclosed by the polygon, regardless of its orientation.
+func (poly Polygon) Area() float64 {
+	return math.Abs(poly.SignedArea())
+}
+
+// Orientation returns whether the polygon's vertices wind clockwise or anticlockwise.
+// Polygons with no area are [Collinear].
+func (poly Polygon) Orientation() Orientation {
+	area := poly.SignedArea()
+
+	switch {
+	case area > 0:
+		return Anticlockwise
+	case area < 0:
+		return Clockwise
+	default:
+		return Collinear
+	}
+}
+
+// Reversed returns a copy of the polygon with its vertices in the opposite order.
+func (poly Polygon) Reversed() Polygon {
+	reversed := make(Polygon, len(poly))
+
+	for i, p := range poly {
+		reversed[len(poly)-1-i] = p
+	}
+
+	return reversed
+}
+
+// Centroid returns the centre of mass of the area enclosed by the polygon.
+//
+// Since a polygon with no area has no well-defined centroid, if the area is 0 this function will return an error
+// wrapping [vec.ErrDivideByZero].
+func (poly Polygon) Centroid() (vec.Vec2, error) {
+	var cx, cy, area float64
+
+	for i := range poly {
+		p, q := poly[i], poly[(i+1)%len(poly)]
+		cross := p.Cross(q)
+
+		area += cross
+		cx += (p.X + q.X) * cross
+		cy += (p.Y + q.Y) * cross
+	}
+
+	if area == 0 {
+		return vec.Vec2{}, vec.NewOpError("Polygon.Centroid", fmt.Errorf("%w: polygon has no area", vec.ErrDivideByZero))
+	}
+
+	// area above is twice the signed area, so 1/(6A) becomes 1/(3 * area)
+	return vec.Vec2{X: cx / (3 * area), Y: cy / (3 * area)}, nil
+}
+
+// WindingNumber returns the number of times the polygon winds anticlockwise around p.
+// Clockwise windings count negatively.
+//
+// If p lies exactly on the boundary, the result is undefined. Use [Polygon.Contains] to treat boundary points consistently.
+func (poly Polygon) WindingNumber(p vec.Vec2) int {
+	winding := 0
+
+	for i := range poly {
+		a, b := poly[i], poly[(i+1)%len(poly)]
+		side := b.Subtract(a).Cross(p.Subtract(a))
+
+		if a.Y <= p.Y {
+			if b.Y > p.Y && side > 0 {
+				// upward crossing with p to the left
+				winding++
+			}
+		} else if b.Y <= p.Y && side < 0 {
+			// downward crossing with p to the right
+			winding--
+		}
+	}
+
+	return winding
+}
+
+// Contains returns true if p lies inside or on the boundary of the polygon, using the non-zero winding rule.
+func (poly Polygon) Contains(p vec.Vec2) bool {
+	for _, edge := range poly.Edges() {
+		if edge.Contains(p) {
+			return true
+		}
+	}
+
+	return poly.WindingNumber(p) != 0
+}
+
+// IsConvex returns true if the polygon is convex, meaning it is simple and every interior angle is at most pi.
+//
+// Consecutive collinear vertices are permitted. Polygons with fewer than three vertices, or no area, are not convex.
+func (poly Polygon) IsConvex() bool {
+	if len(poly) < 3 {
+		return false
+	}
+
+	var sign float64
+	var turning float64
+
+	for i := range poly {
+		a, b, c := poly[i], poly[(i+1)%len(poly)], poly[(i+2)%len(poly)]
+		e1, e2 := b.Subtract(a), c.Subtract(b)
+		cross := e1.Cross(e2)
+
+		if cross != 0 {
+			if sign == 0 {
+				sign = cross
+			} else if (cross > 0) != (sign > 0) {
+				return false
+			}
+		}
+
+		turning += math.Atan2(cross, e1.Dot(e2))
+	}
+
+	// every turn being the same way is not enough on its own: a pentagram turns consistently but
+	// winds around twice, so also require the total turning to be exactly one revolution
+	return sign != 0 && math.Abs(math.Abs(turning)-2*math.Pi) < 1e-9
+}
@@ -0,0 +1,185 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+var (
+	square = Polygon{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}}
+	lShape = Polygon{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2}}
+	star   = Polygon{{X: 0, Y: 3}, {X: 2, Y: -3}, {X: -3, Y: 1}, {X: 3, Y: 1}, {X: -2, Y: -3}}
+)
+
+func TestPolygon_SignedArea(t *testing.T) {
+	tests := []struct {
+		name string
+		poly Polygon
+		want float64
+	}{
+		{
+			name: "anticlockwise square",
+			poly: square,
+			want: 4,
+		},
+		{
+			name: "clockwise square",
+			poly: square.Reversed(),
+			want: -4,
+		},
+		{
+			name: "L shape",
+			poly: lShape,
+			want: 3,
+		},
+		{
+			name: "degenerate",
+			poly: Polygon{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.poly.SignedArea(); got != tt.want {
+				t.Errorf("poly.SignedArea() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygon_Centroid(t *testing.T) {
+	tests := []struct {
+		name    string
+		poly    Polygon
+		want    vec.Vec2
+		wantErr bool
+	}{
+		{
+			name: "square",
+			poly: square,
+			want: vec.Vec2{X: 1, Y: 1},
+		},
+		{
+			name: "clockwise L shape",
+			poly: lShape.Reversed(),
+			want: vec.Vec2{X: 5.0 / 6, Y: 5.0 / 6},
+		},
+		{
+			name:    "degenerate",
+			poly:    Polygon{{X: 0, Y: 0}, {X: 1, Y: 1}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.poly.Centroid()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("poly.Centroid() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("poly.Centroid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	tests := []struct {
+		name string
+		poly Polygon
+		p    vec.Vec2
+		want bool
+	}{
+		{
+			name: "inside square",
+			poly: square,
+			p:    vec.Vec2{X: 1, Y: 1},
+			want: true,
+		},
+		{
+			name: "inside clockwise square",
+			poly: square.Reversed(),
+			p:    vec.Vec2{X: 1, Y: 1},
+			want: true,
+		},
+		{
+			name: "on edge",
+			poly: square,
+			p:    vec.Vec2{X: 2, Y: 1},
+			want: true,
+		},
+		{
+			name: "in notch of L shape",
+			poly: lShape,
+			p:    vec.Vec2{X: 1.5, Y: 1.5},
+			want: false,
+		},
+		{
+			name: "in centre of pentagram",
+			poly: star,
+			p:    vec.Vec2{X: 0, Y: 0},
+			want: true,
+		},
+		{
+			name: "outside",
+			poly: square,
+			p:    vec.Vec2{X: -1, Y: 1},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.poly.Contains(tt.p); got != tt.want {
+				t.Errorf("poly.Contains(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygon_IsConvex(t *testing.T) {
+	tests := []struct {
+		name string
+		poly Polygon
+		want bool
+	}{
+		{
+			name: "square",
+			poly: square,
+			want: true,
+		},
+		{
+			name: "clockwise square",
+			poly: square.Reversed(),
+			want: true,
+		},
+		{
+			name: "square with collinear vertex",
+			poly: Polygon{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}},
+			want: true,
+		},
+		{
+			name: "L shape",
+			poly: lShape,
+			want: false,
+		},
+		{
+			name: "pentagram",
+			poly: star,
+			want: false,
+		},
+		{
+			name: "line",
+			poly: Polygon{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.poly.IsConvex(); got != tt.want {
+				t.Errorf("poly.IsConvex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
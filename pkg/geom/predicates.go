@@ -0,0 +1,290 @@
+package geom
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// The predicates in this file follow Shewchuk's "Adaptive Precision Floating-Point Arithmetic and Fast
+// Robust Geometric Predicates". Each one first evaluates its determinant in ordinary floating point
+// and checks the result against a forward error bound. Only if the sign could be wrong is the
+// determinant re-evaluated exactly, which is rare in practice.
+//
+// All inputs are assumed to be finite, and intermediate results are assumed not to overflow or underflow.
+
+// epsilon is half an ulp of 1, the largest relative error of a single rounded float64 operation.
+var epsilon = math.Ldexp(1, -53)
+
+var (
+	orient2DErrorBound = (3 + 16*epsilon) * epsilon
+	orient3DErrorBound = (7 + 56*epsilon) * epsilon
+	inCircleErrorBound = (10 + 96*epsilon) * epsilon
+	inSphereErrorBound = (16 + 224*epsilon) * epsilon
+)
+
+// PlaneSide describes which side of a plane a point lies on, as reported by [Orient3D].
+type PlaneSide int
+
+const (
+	// Below indicates the point lies behind the plane.
+	Below PlaneSide = -1
+	// Coplanar indicates the point lies exactly on the plane.
+	Coplanar PlaneSide = 0
+	// Above indicates the point lies in front of the plane.
+	Above PlaneSide = 1
+)
+
+// Side describes whether a point lies inside or outside a circle or sphere, as reported by [InCircle]
+// and [InSphere].
+type Side int
+
+const (
+	// Outside indicates the point lies outside the circle or sphere.
+	Outside Side = -1
+	// OnBoundary indicates the point lies exactly on the circle or sphere.
+	OnBoundary Side = 0
+	// Inside indicates the point lies inside the circle or sphere.
+	Inside Side = 1
+)
+
+// Orient2D returns the orientation of the triangle a, b, c: [Anticlockwise] if c lies to the left
+// of the directed line from a to b, [Clockwise] if it lies to the right, and [Collinear] if the
+// three points lie exactly on one line.
+//
+// Unlike a naive computation using [vec.Vec2.Cross], the result is guaranteed to be correct even
+// when the points are very nearly collinear.
+func Orient2D(a, b, c vec.Vec2) Orientation {
+	detLeft := (a.X - c.X) * (b.Y - c.Y)
+	detRight := (a.Y - c.Y) * (b.X - c.X)
+	det := detLeft - detRight
+
+	// when the two terms have opposite signs there is no cancellation, so the sign is already exact
+	var detSum float64
+	switch {
+	case detLeft > 0:
+		if detRight <= 0 {
+			return Orientation(sign(det))
+		}
+		detSum = detLeft + detRight
+	case detLeft < 0:
+		if detRight >= 0 {
+			return Orientation(sign(det))
+		}
+		detSum = -detLeft - detRight
+	default:
+		return Orientation(sign(det))
+	}
+
+	errorBound := orient2DErrorBound * detSum
+	if det >= errorBound || -det >= errorBound {
+		return Orientation(sign(det))
+	}
+
+	acx, acy := ratDiff(a.X, c.X), ratDiff(a.Y, c.Y)
+	bcx, bcy := ratDiff(b.X, c.X), ratDiff(b.Y, c.Y)
+
+	return Orientation(ratSub(ratMul(acx, bcy), ratMul(acy, bcx)).Sign())
+}
+
+// Orient3D returns which side of the plane through a, b and c the point d lies on. The front of the plane
+// is the side from which a, b and c appear anticlockwise, so the result is [Above] if d lies in front of
+// the plane, [Below] if it lies behind it, and [Coplanar] if the four points lie on one plane.
+//
+// For example, if a, b and c are a face of a convex polyhedron, wound anticlockwise when viewed from outside,
+// then points inside the polyhedron are [Below] it.
+//
+// Unlike a naive computation using [vec.Vec3.Cross] and [vec.Vec3.Dot], the result is guaranteed to be
+// correct even when the points are very nearly coplanar.
+func Orient3D(a, b, c, d vec.Vec3) PlaneSide {
+	adx, ady, adz := a.X-d.X, a.Y-d.Y, a.Z-d.Z
+	bdx, bdy, bdz := b.X-d.X, b.Y-d.Y, b.Z-d.Z
+	cdx, cdy, cdz := c.X-d.X, c.Y-d.Y, c.Z-d.Z
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+
+	det := adz*(bdxcdy-cdxbdy) + bdz*(cdxady-adxcdy) + cdz*(adxbdy-bdxady)
+
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*math.Abs(adz) +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*math.Abs(bdz) +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*math.Abs(cdz)
+
+	// the determinant is positive when d lies behind the plane
+	errorBound := orient3DErrorBound * permanent
+	if det > errorBound || -det > errorBound {
+		return PlaneSide(-sign(det))
+	}
+
+	return PlaneSide(-ratDet3(
+		[3]*big.Rat{ratDiff(a.X, d.X), ratDiff(a.Y, d.Y), ratDiff(a.Z, d.Z)},
+		[3]*big.Rat{ratDiff(b.X, d.X), ratDiff(b.Y, d.Y), ratDiff(b.Z, d.Z)},
+		[3]*big.Rat{ratDiff(c.X, d.X), ratDiff(c.Y, d.Y), ratDiff(c.Z, d.Z)},
+	).Sign())
+}
+
+// InCircle returns [Inside] if d lies inside the circle through a, b and c, [Outside] if it lies outside,
+// and [OnBoundary] if the four points are cocircular.
+//
+// a, b and c must be in anticlockwise order, as reported by [Orient2D]. If they are clockwise, [Inside] and
+// [Outside] are swapped.
+//
+// The result is guaranteed to be correct even when the points are very nearly cocircular.
+func InCircle(a, b, c, d vec.Vec2) Side {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+
+	aLift := adx*adx + ady*ady
+	bLift := bdx*bdx + bdy*bdy
+	cLift := cdx*cdx + cdy*cdy
+
+	det := aLift*(bdxcdy-cdxbdy) + bLift*(cdxady-adxcdy) + cLift*(adxbdy-bdxady)
+
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*aLift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*bLift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*cLift
+
+	errorBound := inCircleErrorBound * permanent
+	if det > errorBound || -det > errorBound {
+		return Side(sign(det))
+	}
+
+	exactRow := func(p vec.Vec2) [3]*big.Rat {
+		x, y := ratDiff(p.X, d.X), ratDiff(p.Y, d.Y)
+		return [3]*big.Rat{x, y, ratAdd(ratMul(x, x), ratMul(y, y))}
+	}
+
+	return Side(ratDet3(exactRow(a), exactRow(b), exactRow(c)).Sign())
+}
+
+// InSphere returns [Inside] if e lies inside the sphere through a, b, c and d, [Outside] if it lies outside,
+// and [OnBoundary] if the five points are cospherical.
+//
+// d must lie behind the plane through a, b and c, so that [Orient3D] reports it as [Inside]. If it lies in
+// front, [Inside] and [Outside] are swapped.
+//
+// The result is guaranteed to be correct even when the points are very nearly cospherical.
+func InSphere(a, b, c, d, e vec.Vec3) Side {
+	aex, aey, aez := a.X-e.X, a.Y-e.Y, a.Z-e.Z
+	bex, bey, bez := b.X-e.X, b.Y-e.Y, b.Z-e.Z
+	cex, cey, cez := c.X-e.X, c.Y-e.Y, c.Z-e.Z
+	dex, dey, dez := d.X-e.X, d.Y-e.Y, d.Z-e.Z
+
+	aexbey, bexaey := aex*bey, bex*aey
+	bexcey, cexbey := bex*cey, cex*bey
+	cexdey, dexcey := cex*dey, dex*cey
+	dexaey, aexdey := dex*aey, aex*dey
+	aexcey, cexaey := aex*cey, cex*aey
+	bexdey, dexbey := bex*dey, dex*bey
+
+	ab := aexbey - bexaey
+	bc := bexcey - cexbey
+	cd := cexdey - dexcey
+	da := dexaey - aexdey
+	ac := aexcey - cexaey
+	bd := bexdey - dexbey
+
+	abc := aez*bc - bez*ac + cez*ab
+	bcd := bez*cd - cez*bd + dez*bc
+	cda := cez*da + dez*ac + aez*cd
+	dab := dez*ab + aez*bd + bez*da
+
+	aLift := aex*aex + aey*aey + aez*aez
+	bLift := bex*bex + bey*bey + bez*bez
+	cLift := cex*cex + cey*cey + cez*cez
+	dLift := dex*dex + dey*dey + dez*dez
+
+	det := (dLift*abc - cLift*dab) + (bLift*cda - aLift*bcd)
+
+	aezAbs, bezAbs, cezAbs, dezAbs := math.Abs(aez), math.Abs(bez), math.Abs(cez), math.Abs(dez)
+	abAbs := math.Abs(aexbey) + math.Abs(bexaey)
+	bcAbs := math.Abs(bexcey) + math.Abs(cexbey)
+	cdAbs := math.Abs(cexdey) + math.Abs(dexcey)
+	daAbs := math.Abs(dexaey) + math.Abs(aexdey)
+	acAbs := math.Abs(aexcey) + math.Abs(cexaey)
+	bdAbs := math.Abs(bexdey) + math.Abs(dexbey)
+
+	permanent := (cdAbs*bezAbs+bdAbs*cezAbs+bcAbs*dezAbs)*aLift +
+		(daAbs*cezAbs+acAbs*dezAbs+cdAbs*aezAbs)*bLift +
+		(abAbs*dezAbs+bdAbs*aezAbs+daAbs*bezAbs)*cLift +
+		(bcAbs*aezAbs+acAbs*bezAbs+abAbs*cezAbs)*dLift
+
+	errorBound := inSphereErrorBound * permanent
+	if det > errorBound || -det > errorBound {
+		return Side(sign(det))
+	}
+
+	// repeat the same cofactor expansion exactly
+	type row struct{ x, y, z, lift *big.Rat }
+	exactRow := func(p vec.Vec3) row {
+		x, y, z := ratDiff(p.X, e.X), ratDiff(p.Y, e.Y), ratDiff(p.Z, e.Z)
+		return row{x, y, z, ratAdd(ratAdd(ratMul(x, x), ratMul(y, y)), ratMul(z, z))}
+	}
+	ra, rb, rc, rd := exactRow(a), exactRow(b), exactRow(c), exactRow(d)
+
+	minor := func(p, q row) *big.Rat {
+		return ratSub(ratMul(p.x, q.y), ratMul(q.x, p.y))
+	}
+	exactAB, exactBC, exactCD := minor(ra, rb), minor(rb, rc), minor(rc, rd)
+	exactDA, exactAC, exactBD := minor(rd, ra), minor(ra, rc), minor(rb, rd)
+
+	exactABC := ratAdd(ratSub(ratMul(ra.z, exactBC), ratMul(rb.z, exactAC)), ratMul(rc.z, exactAB))
+	exactBCD := ratAdd(ratSub(ratMul(rb.z, exactCD), ratMul(rc.z, exactBD)), ratMul(rd.z, exactBC))
+	exactCDA := ratAdd(ratAdd(ratMul(rc.z, exactDA), ratMul(rd.z, exactAC)), ratMul(ra.z, exactCD))
+	exactDAB := ratAdd(ratAdd(ratMul(rd.z, exactAB), ratMul(ra.z, exactBD)), ratMul(rb.z, exactDA))
+
+	return Side(ratAdd(
+		ratSub(ratMul(rd.lift, exactABC), ratMul(rc.lift, exactDAB)),
+		ratSub(ratMul(rb.lift, exactCDA), ratMul(ra.lift, exactBCD)),
+	).Sign())
+}
+
+// sign returns 1, -1 or 0 according to the sign of x.
+func sign(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// ratDiff returns the exact difference x - y.
+func ratDiff(x, y float64) *big.Rat {
+	rx := new(big.Rat).SetFloat64(x)
+	ry := new(big.Rat).SetFloat64(y)
+	return rx.Sub(rx, ry)
+}
+
+// ratSub returns the exact difference x - y.
+func ratSub(x, y *big.Rat) *big.Rat {
+	return new(big.Rat).Sub(x, y)
+}
+
+// ratAdd returns the exact sum x + y.
+func ratAdd(x, y *big.Rat) *big.Rat {
+	return new(big.Rat).Add(x, y)
+}
+
+// ratMul returns the exact product x * y.
+func ratMul(x, y *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(x, y)
+}
+
+// ratDet3 returns the exact determinant of the 3x3 matrix with rows r0, r1 and r2.
+func ratDet3(r0, r1, r2 [3]*big.Rat) *big.Rat {
+	m0 := ratSub(ratMul(r1[1], r2[2]), ratMul(r1[2], r2[1]))
+	m1 := ratSub(ratMul(r1[0], r2[2]), ratMul(r1[2], r2[0]))
+	m2 := ratSub(ratMul(r1[0], r2[1]), ratMul(r1[1], r2[0]))
+
+	return ratAdd(ratSub(ratMul(r0[0], m0), ratMul(r0[1], m1)), ratMul(r0[2], m2))
+}
@@ -0,0 +1,216 @@
+package geom
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestOrient2D(t *testing.T) {
+	tests := []struct {
+		name string
+		a    vec.Vec2
+		b    vec.Vec2
+		c    vec.Vec2
+		want Orientation
+	}{
+		{
+			name: "anticlockwise",
+			a:    vec.Vec2{X: 0, Y: 0},
+			b:    vec.Vec2{X: 1, Y: 0},
+			c:    vec.Vec2{X: 0, Y: 1},
+			want: Anticlockwise,
+		},
+		{
+			name: "clockwise",
+			a:    vec.Vec2{X: 0, Y: 0},
+			b:    vec.Vec2{X: 0, Y: 1},
+			c:    vec.Vec2{X: 1, Y: 0},
+			want: Clockwise,
+		},
+		{
+			name: "collinear",
+			a:    vec.Vec2{X: 0.5, Y: 0.5},
+			b:    vec.Vec2{X: 12, Y: 12},
+			c:    vec.Vec2{X: 24, Y: 24},
+			want: Collinear,
+		},
+		{
+			name: "one ulp off collinear",
+			a:    vec.Vec2{X: math.Nextafter(0.5, 1), Y: 0.5},
+			b:    vec.Vec2{X: 12, Y: 12},
+			c:    vec.Vec2{X: 24, Y: 24},
+			want: Clockwise,
+		},
+		{
+			name: "collinear with non-representable slope",
+			a:    vec.Vec2{X: 0.1, Y: 0.3},
+			b:    vec.Vec2{X: 0.2, Y: 0.6},
+			c:    vec.Vec2{X: 0.3, Y: 0.9},
+			want: exactOrient2D(vec.Vec2{X: 0.1, Y: 0.3}, vec.Vec2{X: 0.2, Y: 0.6}, vec.Vec2{X: 0.3, Y: 0.9}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Orient2D(tt.a, tt.b, tt.c); got != tt.want {
+				t.Errorf("Orient2D(a, b, c) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// exactOrient2D is a reference implementation that always uses exact arithmetic.
+func exactOrient2D(a, b, c vec.Vec2) Orientation {
+	r := func(x float64) *big.Rat { return new(big.Rat).SetFloat64(x) }
+	sub := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Sub(x, y) }
+	mul := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Mul(x, y) }
+
+	left := mul(sub(r(a.X), r(c.X)), sub(r(b.Y), r(c.Y)))
+	right := mul(sub(r(a.Y), r(c.Y)), sub(r(b.X), r(c.X)))
+
+	return Orientation(sub(left, right).Sign())
+}
+
+func TestOrient2D_NearlyCollinearGrid(t *testing.T) {
+	// points perturbed by a few ulps around the line y = x, the classic case where a naive
+	// floating point determinant returns inconsistent signs
+	b := vec.Vec2{X: 12, Y: 12}
+	c := vec.Vec2{X: 24, Y: 24}
+
+	x := 0.5
+	for range 32 {
+		y := 0.5
+		for range 32 {
+			a := vec.Vec2{X: x, Y: y}
+			if got, want := Orient2D(a, b, c), exactOrient2D(a, b, c); got != want {
+				t.Errorf("Orient2D(%v, b, c) = %v, want %v", a, got, want)
+			}
+			y = math.Nextafter(y, 1)
+		}
+		x = math.Nextafter(x, 1)
+	}
+}
+
+func TestOrient3D(t *testing.T) {
+	a := vec.Vec3{X: 0, Y: 0, Z: 0}
+	b := vec.Vec3{X: 1, Y: 0, Z: 0}
+	c := vec.Vec3{X: 0, Y: 1, Z: 0}
+
+	tests := []struct {
+		name string
+		d    vec.Vec3
+		want PlaneSide
+	}{
+		{
+			name: "below",
+			d:    vec.Vec3{X: 0.2, Y: 0.2, Z: -1},
+			want: Below,
+		},
+		{
+			name: "above",
+			d:    vec.Vec3{X: 0.2, Y: 0.2, Z: 1},
+			want: Above,
+		},
+		{
+			name: "coplanar",
+			d:    vec.Vec3{X: 5, Y: -3, Z: 0},
+			want: Coplanar,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Orient3D(a, b, c, tt.d); got != tt.want {
+				t.Errorf("Orient3D(a, b, c, d) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	a := vec.Vec2{X: 1, Y: 0}
+	b := vec.Vec2{X: 0, Y: 1}
+	c := vec.Vec2{X: -1, Y: 0}
+
+	tests := []struct {
+		name string
+		d    vec.Vec2
+		want Side
+	}{
+		{
+			name: "centre",
+			d:    vec.Vec2{X: 0, Y: 0},
+			want: Inside,
+		},
+		{
+			name: "on circle",
+			d:    vec.Vec2{X: 0, Y: -1},
+			want: OnBoundary,
+		},
+		{
+			name: "one ulp inside",
+			d:    vec.Vec2{X: 0, Y: math.Nextafter(-1, 0)},
+			want: Inside,
+		},
+		{
+			name: "one ulp outside",
+			d:    vec.Vec2{X: 0, Y: math.Nextafter(-1, -2)},
+			want: Outside,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InCircle(a, b, c, tt.d); got != tt.want {
+				t.Errorf("InCircle(a, b, c, d) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInSphere(t *testing.T) {
+	// a, b and c appear clockwise from d, so d lies behind their plane
+	a := vec.Vec3{X: 0, Y: 1, Z: 0}
+	b := vec.Vec3{X: 1, Y: 0, Z: 0}
+	c := vec.Vec3{X: -1, Y: 0, Z: 0}
+	d := vec.Vec3{X: 0, Y: 0, Z: 1}
+
+	tests := []struct {
+		name string
+		e    vec.Vec3
+		want Side
+	}{
+		{
+			name: "centre",
+			e:    vec.Vec3{X: 0, Y: 0, Z: 0},
+			want: Inside,
+		},
+		{
+			name: "on sphere",
+			e:    vec.Vec3{X: 0, Y: 0, Z: -1},
+			want: OnBoundary,
+		},
+		{
+			name: "one ulp inside",
+			e:    vec.Vec3{X: 0, Y: 0, Z: math.Nextafter(-1, 0)},
+			want: Inside,
+		},
+		{
+			name: "one ulp outside",
+			e:    vec.Vec3{X: 0, Y: math.Nextafter(-1, -2), Z: 0},
+			want: Outside,
+		},
+		{
+			name: "far outside",
+			e:    vec.Vec3{X: 5, Y: 5, Z: 5},
+			want: Outside,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InSphere(a, b, c, d, tt.e); got != tt.want {
+				t.Errorf("InSphere(a, b, c, d, e) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
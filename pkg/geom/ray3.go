@@ -0,0 +1,216 @@
+package geom
+
+import (
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Ray3 represents a half-line in 3D space, starting at Origin and extending infinitely along Direction.
+//
+// Direction does not need to be normalised, but if it is, the distances reported by intersection
+// queries are true distances rather than multiples of |Direction|.
+type Ray3 struct {
+	Origin, Direction vec.Vec3
+}
+
+// Hit3 describes where a ray intersects a primitive.
+type Hit3 struct {
+	// Distance is the ray parameter t at which the hit occurs, such that Point = Origin + Direction * t.
+	Distance float64
+	// Point is the position of the hit.
+	Point vec.Vec3
+	// Normal is the unit surface normal at the hit.
+	Normal vec.Vec3
+}
+
+// At returns the point Origin + Direction * t.
+func (r Ray3) At(t float64) vec.Vec3 {
+	return r.Origin.Add(r.Direction.Multiply(t))
+}
+
+// IntersectPlane returns the point at which r crosses p.
+// The reported normal faces back towards the ray origin.
+//
+// If r is parallel to p or points away from it, there is no hit and this function returns false.
+func (r Ray3) IntersectPlane(p Plane) (Hit3, bool) {
+	denominator := p.Normal.Dot(r.Direction)
+
+	if denominator == 0 {
+		return Hit3{}, false
+	}
+
+	t := (p.D - p.Normal.Dot(r.Origin)) / denominator
+
+	if t < 0 {
+		return Hit3{}, false
+	}
+
+	normal := p.Normal
+	if denominator > 0 {
+		normal = normal.Multiply(-1)
+	}
+
+	return Hit3{
+		Distance: t,
+		Point:    r.At(t),
+		Normal:   normal,
+	}, true
+}
+
+// IntersectSphere returns the first point at which r meets the surface of s.
+// The reported normal always points out of the sphere.
+//
+// If r starts inside s, the hit is where the ray leaves the sphere.
+// If r misses s entirely, or s is behind the ray, this function returns false.
+// A sphere with a radius of 0 or less is empty, as described by [Sphere], so this function also returns false for it.
+func (r Ray3) IntersectSphere(s Sphere) (Hit3, bool) {
+	if s.Radius <= 0 {
+		return Hit3{}, false
+	}
+
+	// solve |origin + t*direction - centre|^2 = radius^2 for t
+	offset := r.Origin.Subtract(s.Centre)
+	a := r.Direction.Dot(r.Direction)
+	halfB := offset.Dot(r.Direction)
+	c := offset.Dot(offset) - s.Radius*s.Radius
+
+	if a == 0 {
+		return Hit3{}, false
+	}
+
+	discriminant := halfB*halfB - a*c
+	if discriminant < 0 {
+		return Hit3{}, false
+	}
+
+	root := math.Sqrt(discriminant)
+	t := (-halfB - root) / a
+	if t < 0 {
+		t = (-halfB + root) / a
+	}
+	if t < 0 {
+		return Hit3{}, false
+	}
+
+	point := r.At(t)
+
+	return Hit3{
+		Distance: t,
+		Point:    point,
+		Normal:   point.Subtract(s.Centre).Multiply(1 / s.Radius),
+	}, true
+}
+
+// IntersectAABB returns the first point at which r meets the surface of b.
+// The reported normal always points out of the box, along one of the coordinate axes.
+//
+// If r starts inside b, the hit is where the ray leaves the box.
+// If r misses b entirely, or b is behind the ray, this function returns false.
+func (r Ray3) IntersectAABB(b AABB3) (Hit3, bool) {
+	origin := [3]float64{r.Origin.X, r.Origin.Y, r.Origin.Z}
+	direction := [3]float64{r.Direction.X, r.Direction.Y, r.Direction.Z}
+	lo := [3]float64{b.Min.X, b.Min.Y, b.Min.Z}
+	hi := [3]float64{b.Max.X, b.Max.Y, b.Max.Z}
+
+	// slab method: track the latest entry and earliest exit across the three pairs of planes,
+	// along with which axis and side each happened on
+	tNear, tFar := math.Inf(-1), math.Inf(1)
+	nearAxis, farAxis := -1, -1
+	var nearSign, farSign float64
+
+	for axis := range 3 {
+		if direction[axis] == 0 {
+			if origin[axis] < lo[axis] || origin[axis] > hi[axis] {
+				return Hit3{}, false
+			}
+			continue
+		}
+
+		t1 := (lo[axis] - origin[axis]) / direction[axis]
+		t2 := (hi[axis] - origin[axis]) / direction[axis]
+		sign1, sign2 := -1.0, 1.0
+		if t1 > t2 {
+			t1, t2 = t2, t1
+			sign1, sign2 = sign2, sign1
+		}
+
+		if t1 > tNear {
+			tNear, nearAxis, nearSign = t1, axis, sign1
+		}
+		if t2 < tFar {
+			tFar, farAxis, farSign = t2, axis, sign2
+		}
+
+		if tNear > tFar {
+			return Hit3{}, false
+		}
+	}
+
+	t, axis, sign := tNear, nearAxis, nearSign
+	if t < 0 {
+		t, axis, sign = tFar, farAxis, farSign
+	}
+	if t < 0 || axis < 0 {
+		return Hit3{}, false
+	}
+
+	var normal [3]float64
+	normal[axis] = sign
+
+	return Hit3{
+		Distance: t,
+		Point:    r.At(t),
+		Normal:   vec.Vec3{X: normal[0], Y: normal[1], Z: normal[2]},
+	}, true
+}
+
+// IntersectTriangle returns the point at which r crosses tri, using the Möller-Trumbore algorithm.
+// The reported normal faces back towards the ray origin.
+//
+// If r misses tri, is parallel to it, or tri is behind the ray, this function returns false.
+// Degenerate triangles are never hit.
+func (r Ray3) IntersectTriangle(tri Triangle3) (Hit3, bool) {
+	edge1 := tri.B.Subtract(tri.A)
+	edge2 := tri.C.Subtract(tri.A)
+
+	p := r.Direction.Cross(edge2)
+	det := edge1.Dot(p)
+
+	if det == 0 {
+		return Hit3{}, false
+	}
+
+	invDet := 1 / det
+	s := r.Origin.Subtract(tri.A)
+
+	u := s.Dot(p) * invDet
+	if u < 0 || u > 1 {
+		return Hit3{}, false
+	}
+
+	q := s.Cross(edge1)
+	v := r.Direction.Dot(q) * invDet
+	if v < 0 || u+v > 1 {
+		return Hit3{}, false
+	}
+
+	t := edge2.Dot(q) * invDet
+	if t < 0 {
+		return Hit3{}, false
+	}
+
+	normal, err := tri.Normal()
+	if err != nil {
+		return Hit3{}, false
+	}
+	if normal.Dot(r.Direction) > 0 {
+		normal = normal.Multiply(-1)
+	}
+
+	return Hit3{
+		Distance: t,
+		Point:    r.At(t),
+		Normal:   normal,
+	}, true
+}
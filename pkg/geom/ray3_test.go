@@ -0,0 +1,237 @@
+package geom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func hitAlmostEquals(h1, h2 Hit3, threshold float64) bool {
+	return math.Abs(h1.Distance-h2.Distance) <= threshold &&
+		h1.Point.AlmostEquals(h2.Point, threshold) &&
+		h1.Normal.AlmostEquals(h2.Normal, threshold)
+}
+
+func TestRay3_IntersectPlane(t *testing.T) {
+	floor := Plane{Normal: vec.Vec3{X: 0, Y: 1, Z: 0}, D: 0}
+
+	tests := []struct {
+		name   string
+		r      Ray3
+		p      Plane
+		want   Hit3
+		wantOk bool
+	}{
+		{
+			name:   "straight down onto floor",
+			r:      Ray3{vec.Vec3{X: 1, Y: 5, Z: 2}, vec.Vec3{X: 0, Y: -1, Z: 0}},
+			p:      floor,
+			want:   Hit3{5, vec.Vec3{X: 1, Y: 0, Z: 2}, vec.Vec3{X: 0, Y: 1, Z: 0}},
+			wantOk: true,
+		},
+		{
+			name:   "up through floor from below, normal faces ray",
+			r:      Ray3{vec.Vec3{X: 0, Y: -2, Z: 0}, vec.Vec3{X: 0, Y: 1, Z: 0}},
+			p:      floor,
+			want:   Hit3{2, vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 0, Y: -1, Z: 0}},
+			wantOk: true,
+		},
+		{
+			name:   "parallel = miss",
+			r:      Ray3{vec.Vec3{X: 0, Y: 1, Z: 0}, vec.Vec3{X: 1, Y: 0, Z: 0}},
+			p:      floor,
+			wantOk: false,
+		},
+		{
+			name:   "pointing away = miss",
+			r:      Ray3{vec.Vec3{X: 0, Y: 1, Z: 0}, vec.Vec3{X: 0, Y: 1, Z: 0}},
+			p:      floor,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.r.IntersectPlane(tt.p)
+			if ok != tt.wantOk {
+				t.Errorf("r.IntersectPlane(p) ok = %v, want %v", ok, tt.wantOk)
+				return
+			}
+			if ok && !hitAlmostEquals(got, tt.want, 1e-12) {
+				t.Errorf("r.IntersectPlane(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRay3_IntersectSphere(t *testing.T) {
+	unit := Sphere{vec.Vec3{X: 0, Y: 0, Z: 0}, 1}
+
+	tests := []struct {
+		name   string
+		r      Ray3
+		s      Sphere
+		want   Hit3
+		wantOk bool
+	}{
+		{
+			name:   "head on",
+			r:      Ray3{vec.Vec3{X: -5, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 0, Z: 0}},
+			s:      unit,
+			want:   Hit3{4, vec.Vec3{X: -1, Y: 0, Z: 0}, vec.Vec3{X: -1, Y: 0, Z: 0}},
+			wantOk: true,
+		},
+		{
+			name:   "from inside",
+			r:      Ray3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 0, Y: 0, Z: 2}},
+			s:      unit,
+			want:   Hit3{0.5, vec.Vec3{X: 0, Y: 0, Z: 1}, vec.Vec3{X: 0, Y: 0, Z: 1}},
+			wantOk: true,
+		},
+		{
+			name:   "miss",
+			r:      Ray3{vec.Vec3{X: -5, Y: 2, Z: 0}, vec.Vec3{X: 1, Y: 0, Z: 0}},
+			s:      unit,
+			wantOk: false,
+		},
+		{
+			name:   "radius 0 = miss",
+			r:      Ray3{vec.Vec3{X: -5, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 0, Z: 0}},
+			s:      Sphere{vec.Vec3{X: 0, Y: 0, Z: 0}, 0},
+			wantOk: false,
+		},
+		{
+			name:   "negative radius = miss",
+			r:      Ray3{vec.Vec3{X: -5, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 0, Z: 0}},
+			s:      Sphere{vec.Vec3{X: 0, Y: 0, Z: 0}, -1},
+			wantOk: false,
+		},
+		{
+			name:   "behind",
+			r:      Ray3{vec.Vec3{X: 5, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 0, Z: 0}},
+			s:      unit,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.r.IntersectSphere(tt.s)
+			if ok != tt.wantOk {
+				t.Errorf("r.IntersectSphere(s) ok = %v, want %v", ok, tt.wantOk)
+				return
+			}
+			if ok && !hitAlmostEquals(got, tt.want, 1e-12) {
+				t.Errorf("r.IntersectSphere(s) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRay3_IntersectAABB(t *testing.T) {
+	box := AABB3{vec.Vec3{X: -1, Y: -1, Z: -1}, vec.Vec3{X: 1, Y: 1, Z: 1}}
+
+	tests := []struct {
+		name   string
+		r      Ray3
+		b      AABB3
+		want   Hit3
+		wantOk bool
+	}{
+		{
+			name:   "head on along x",
+			r:      Ray3{vec.Vec3{X: -5, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 0, Z: 0}},
+			b:      box,
+			want:   Hit3{4, vec.Vec3{X: -1, Y: 0, Z: 0}, vec.Vec3{X: -1, Y: 0, Z: 0}},
+			wantOk: true,
+		},
+		{
+			name:   "down onto top face",
+			r:      Ray3{vec.Vec3{X: 0.5, Y: 3, Z: 0.5}, vec.Vec3{X: 0, Y: -1, Z: 0}},
+			b:      box,
+			want:   Hit3{2, vec.Vec3{X: 0.5, Y: 1, Z: 0.5}, vec.Vec3{X: 0, Y: 1, Z: 0}},
+			wantOk: true,
+		},
+		{
+			name:   "from inside",
+			r:      Ray3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 0, Y: 0, Z: -1}},
+			b:      box,
+			want:   Hit3{1, vec.Vec3{X: 0, Y: 0, Z: -1}, vec.Vec3{X: 0, Y: 0, Z: -1}},
+			wantOk: true,
+		},
+		{
+			name:   "diagonal miss",
+			r:      Ray3{vec.Vec3{X: -5, Y: 3, Z: 0}, vec.Vec3{X: 1, Y: 0.1, Z: 0}},
+			b:      box,
+			wantOk: false,
+		},
+		{
+			name:   "parallel outside slab",
+			r:      Ray3{vec.Vec3{X: -5, Y: 2, Z: 0}, vec.Vec3{X: 1, Y: 0, Z: 0}},
+			b:      box,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.r.IntersectAABB(tt.b)
+			if ok != tt.wantOk {
+				t.Errorf("r.IntersectAABB(b) ok = %v, want %v", ok, tt.wantOk)
+				return
+			}
+			if ok && !hitAlmostEquals(got, tt.want, 1e-12) {
+				t.Errorf("r.IntersectAABB(b) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRay3_IntersectTriangle(t *testing.T) {
+	tri := Triangle3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 0, Z: 0}, vec.Vec3{X: 0, Y: 1, Z: 0}}
+
+	tests := []struct {
+		name   string
+		r      Ray3
+		tri    Triangle3
+		want   Hit3
+		wantOk bool
+	}{
+		{
+			name:   "from front",
+			r:      Ray3{vec.Vec3{X: 0.25, Y: 0.25, Z: 3}, vec.Vec3{X: 0, Y: 0, Z: -1}},
+			tri:    tri,
+			want:   Hit3{3, vec.Vec3{X: 0.25, Y: 0.25, Z: 0}, vec.Vec3{X: 0, Y: 0, Z: 1}},
+			wantOk: true,
+		},
+		{
+			name:   "from behind, normal faces ray",
+			r:      Ray3{vec.Vec3{X: 0.25, Y: 0.25, Z: -1}, vec.Vec3{X: 0, Y: 0, Z: 1}},
+			tri:    tri,
+			want:   Hit3{1, vec.Vec3{X: 0.25, Y: 0.25, Z: 0}, vec.Vec3{X: 0, Y: 0, Z: -1}},
+			wantOk: true,
+		},
+		{
+			name:   "outside edge",
+			r:      Ray3{vec.Vec3{X: 0.75, Y: 0.75, Z: 3}, vec.Vec3{X: 0, Y: 0, Z: -1}},
+			tri:    tri,
+			wantOk: false,
+		},
+		{
+			name:   "parallel",
+			r:      Ray3{vec.Vec3{X: 0, Y: 0, Z: 1}, vec.Vec3{X: 1, Y: 0, Z: 0}},
+			tri:    tri,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.r.IntersectTriangle(tt.tri)
+			if ok != tt.wantOk {
+				t.Errorf("r.IntersectTriangle(tri) ok = %v, want %v", ok, tt.wantOk)
+				return
+			}
+			if ok && !hitAlmostEquals(got, tt.want, 1e-12) {
+				t.Errorf("r.IntersectTriangle(tri) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package geom
+
+import (
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Rect represents an axis-aligned rectangle in 2D space, spanning from Min to Max inclusive.
+//
+// Each component of Min is expected to be no greater than the corresponding component of Max.
+type Rect struct {
+	Min, Max vec.Vec2
+}
+
+// RectFromPoints returns the smallest rectangle containing every point in ps.
+//
+// If ps is empty, this function returns false.
+func RectFromPoints(ps []vec.Vec2) (Rect, bool) {
+	if len(ps) == 0 {
+		return Rect{}, false
+	}
+
+	r := Rect{ps[0], ps[0]}
+	for _, p := range ps[1:] {
+		r = r.Expand(p)
+	}
+
+	return r, true
+}
+
+// Width returns the extent of the rectangle along the X axis.
+func (r Rect) Width() float64 {
+	return r.Max.X - r.Min.X
+}
+
+// Height returns the extent of the rectangle along the Y axis.
+func (r Rect) Height() float64 {
+	return r.Max.Y - r.Min.Y
+}
+
+// Area returns the area of the rectangle.
+func (r Rect) Area() float64 {
+	return r.Width() * r.Height()
+}
+
+// Centre returns the point at the middle of the rectangle.
+func (r Rect) Centre() vec.Vec2 {
+	return r.Min.Lerp(r.Max, 0.5)
+}
+
+// Expand returns the smallest rectangle containing both r and p.
+func (r Rect) Expand(p vec.Vec2) Rect {
+	return Rect{
+		Min: vec.Vec2{X: math.Min(r.Min.X, p.X), Y: math.Min(r.Min.Y, p.Y)},
+		Max: vec.Vec2{X: math.Max(r.Max.X, p.X), Y: math.Max(r.Max.Y, p.Y)},
+	}
+}
+
+// Union returns the smallest rectangle containing both r1 and r2.
+func (r1 Rect) Union(r2 Rect) Rect {
+	return r1.Expand(r2.Min).Expand(r2.Max)
+}
+
+// Intersect returns the rectangle of points shared by r1 and r2.
+//
+// If the rectangles do not overlap, this function returns false.
+func (r1 Rect) Intersect(r2 Rect) (Rect, bool) {
+	if !r1.Overlaps(r2) {
+		return Rect{}, false
+	}
+
+	return Rect{
+		Min: vec.Vec2{X: math.Max(r1.Min.X, r2.Min.X), Y: math.Max(r1.Min.Y, r2.Min.Y)},
+		Max: vec.Vec2{X: math.Min(r1.Max.X, r2.Max.X), Y: math.Min(r1.Max.Y, r2.Max.Y)},
+	}, true
+}
+
+// Contains returns true if p lies inside or on the boundary of r.
+func (r Rect) Contains(p vec.Vec2) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// ClosestPoint returns the point in r nearest to p. If p is inside r, this is p itself.
+func (r Rect) ClosestPoint(p vec.Vec2) vec.Vec2 {
+	return vec.Vec2{
+		X: math.Max(r.Min.X, math.Min(p.X, r.Max.X)),
+		Y: math.Max(r.Min.Y, math.Min(p.Y, r.Max.Y)),
+	}
+}
+
+// Overlaps returns true if r1 and r2 share at least one point.
+func (r1 Rect) Overlaps(r2 Rect) bool {
+	return r1.Min.X <= r2.Max.X && r1.Max.X >= r2.Min.X && r1.Min.Y <= r2.Max.Y && r1.Max.Y >= r2.Min.Y
+}
+
+// Polygon returns the corners of the rectangle as an anticlockwise polygon, starting from Min.
+func (r Rect) Polygon() Polygon {
+	return Polygon{
+		r.Min,
+		{X: r.Max.X, Y: r.Min.Y},
+		r.Max,
+		{X: r.Min.X, Y: r.Max.Y},
+	}
+}
@@ -0,0 +1,220 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestRectFromPoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		ps     []vec.Vec2
+		want   Rect
+		wantOk bool
+	}{
+		{
+			name:   "empty",
+			ps:     nil,
+			wantOk: false,
+		},
+		{
+			name:   "single point",
+			ps:     []vec.Vec2{{X: 2, Y: 3}},
+			want:   Rect{vec.Vec2{X: 2, Y: 3}, vec.Vec2{X: 2, Y: 3}},
+			wantOk: true,
+		},
+		{
+			name:   "scattered",
+			ps:     []vec.Vec2{{X: 1, Y: 5}, {X: -2, Y: 0}, {X: 4, Y: 2}},
+			want:   Rect{vec.Vec2{X: -2, Y: 0}, vec.Vec2{X: 4, Y: 5}},
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RectFromPoints(tt.ps)
+			if ok != tt.wantOk {
+				t.Fatalf("RectFromPoints() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && got != tt.want {
+				t.Errorf("RectFromPoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRect_Measurements(t *testing.T) {
+	r := Rect{vec.Vec2{X: -1, Y: 2}, vec.Vec2{X: 3, Y: 3}}
+
+	if got := r.Width(); got != 4 {
+		t.Errorf("r.Width() = %v, want 4", got)
+	}
+	if got := r.Height(); got != 1 {
+		t.Errorf("r.Height() = %v, want 1", got)
+	}
+	if got := r.Area(); got != 4 {
+		t.Errorf("r.Area() = %v, want 4", got)
+	}
+	if got, want := r.Centre(), (vec.Vec2{X: 1, Y: 2.5}); got != want {
+		t.Errorf("r.Centre() = %v, want %v", got, want)
+	}
+}
+
+func TestRect_Intersect(t *testing.T) {
+	unit := Rect{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 1, Y: 1}}
+
+	tests := []struct {
+		name   string
+		r1     Rect
+		r2     Rect
+		want   Rect
+		wantOk bool
+	}{
+		{
+			name:   "partial overlap",
+			r1:     unit,
+			r2:     Rect{vec.Vec2{X: 0.5, Y: -1}, vec.Vec2{X: 2, Y: 0.5}},
+			want:   Rect{vec.Vec2{X: 0.5, Y: 0}, vec.Vec2{X: 1, Y: 0.5}},
+			wantOk: true,
+		},
+		{
+			name:   "touching edges",
+			r1:     unit,
+			r2:     Rect{vec.Vec2{X: 1, Y: 0}, vec.Vec2{X: 2, Y: 1}},
+			want:   Rect{vec.Vec2{X: 1, Y: 0}, vec.Vec2{X: 1, Y: 1}},
+			wantOk: true,
+		},
+		{
+			name:   "contained",
+			r1:     unit,
+			r2:     Rect{vec.Vec2{X: 0.25, Y: 0.25}, vec.Vec2{X: 0.75, Y: 0.5}},
+			want:   Rect{vec.Vec2{X: 0.25, Y: 0.25}, vec.Vec2{X: 0.75, Y: 0.5}},
+			wantOk: true,
+		},
+		{
+			name:   "separated",
+			r1:     unit,
+			r2:     Rect{vec.Vec2{X: 2, Y: 0}, vec.Vec2{X: 3, Y: 1}},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.r1.Intersect(tt.r2)
+			if ok != tt.wantOk {
+				t.Fatalf("r1.Intersect(r2) ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && got != tt.want {
+				t.Errorf("r1.Intersect(r2) = %v, want %v", got, tt.want)
+			}
+			if ok != tt.r1.Overlaps(tt.r2) {
+				t.Errorf("r1.Overlaps(r2) = %v, want %v", !ok, ok)
+			}
+		})
+	}
+}
+
+func TestRect_Union(t *testing.T) {
+	r1 := Rect{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 1, Y: 1}}
+	r2 := Rect{vec.Vec2{X: 3, Y: -2}, vec.Vec2{X: 4, Y: 0}}
+	want := Rect{vec.Vec2{X: 0, Y: -2}, vec.Vec2{X: 4, Y: 1}}
+
+	if got := r1.Union(r2); got != want {
+		t.Errorf("r1.Union(r2) = %v, want %v", got, want)
+	}
+}
+
+func TestRect_Contains(t *testing.T) {
+	r := Rect{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 2, Y: 1}}
+
+	tests := []struct {
+		name string
+		p    vec.Vec2
+		want bool
+	}{
+		{
+			name: "inside",
+			p:    vec.Vec2{X: 1, Y: 0.5},
+			want: true,
+		},
+		{
+			name: "corner",
+			p:    vec.Vec2{X: 2, Y: 1},
+			want: true,
+		},
+		{
+			name: "on edge",
+			p:    vec.Vec2{X: 0, Y: 0.3},
+			want: true,
+		},
+		{
+			name: "outside",
+			p:    vec.Vec2{X: 2.1, Y: 0.5},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Contains(tt.p); got != tt.want {
+				t.Errorf("r.Contains(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRect_ClosestPoint(t *testing.T) {
+	r := Rect{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 2, Y: 1}}
+
+	tests := []struct {
+		name string
+		p    vec.Vec2
+		want vec.Vec2
+	}{
+		{
+			name: "inside",
+			p:    vec.Vec2{X: 1, Y: 0.5},
+			want: vec.Vec2{X: 1, Y: 0.5},
+		},
+		{
+			name: "beside an edge",
+			p:    vec.Vec2{X: 1, Y: 4},
+			want: vec.Vec2{X: 1, Y: 1},
+		},
+		{
+			name: "beyond a corner",
+			p:    vec.Vec2{X: -3, Y: -3},
+			want: vec.Vec2{X: 0, Y: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.ClosestPoint(tt.p); got != tt.want {
+				t.Errorf("r.ClosestPoint(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRect_Polygon(t *testing.T) {
+	r := Rect{vec.Vec2{X: -1, Y: 2}, vec.Vec2{X: 3, Y: 5}}
+	want := Polygon{{X: -1, Y: 2}, {X: 3, Y: 2}, {X: 3, Y: 5}, {X: -1, Y: 5}}
+
+	got := r.Polygon()
+	if len(got) != len(want) {
+		t.Fatalf("r.Polygon() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equals(want[i]) {
+			t.Errorf("r.Polygon() = %v, want %v", got, want)
+			break
+		}
+	}
+
+	if got.Orientation() != Anticlockwise {
+		t.Errorf("r.Polygon().Orientation() = %v, want %v", got.Orientation(), Anticlockwise)
+	}
+	if got.Area() != r.Area() {
+		t.Errorf("r.Polygon().Area() = %v, want %v", got.Area(), r.Area())
+	}
+}
@@ -0,0 +1,113 @@
+package geom
+
+import (
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Segment2 represents the line segment between A and B in 2D space.
+type Segment2 struct {
+	A, B vec.Vec2
+}
+
+// Length returns the length of the segment.
+func (s Segment2) Length() float64 {
+	return s.B.Subtract(s.A).Magnitude()
+}
+
+// At returns the point A + (B - A) * t, such that t = 0 gives A and t = 1 gives B.
+func (s Segment2) At(t float64) vec.Vec2 {
+	return s.A.Lerp(s.B, t)
+}
+
+// ClosestPoint returns the point on the segment nearest to p.
+func (s Segment2) ClosestPoint(p vec.Vec2) vec.Vec2 {
+	d := s.B.Subtract(s.A)
+	lengthSquared := d.Dot(d)
+
+	if lengthSquared == 0 {
+		return s.A
+	}
+
+	t := p.Subtract(s.A).Dot(d) / lengthSquared
+	return s.A.LerpClamped(s.B, t)
+}
+
+// Distance returns the shortest distance from the segment to p.
+func (s Segment2) Distance(p vec.Vec2) float64 {
+	return s.ClosestPoint(p).Subtract(p).Magnitude()
+}
+
+// Contains returns true if p lies exactly on the segment.
+func (s Segment2) Contains(p vec.Vec2) bool {
+	d := s.B.Subtract(s.A)
+	offset := p.Subtract(s.A)
+
+	if d.Cross(offset) != 0 {
+		return false
+	}
+
+	t := offset.Dot(d)
+	return t >= 0 && t <= d.Dot(d)
+}
+
+// Intersect returns the set of points shared by s1 and s2.
+//
+// If the segments cross or touch at a single point, the result is a segment with A = B at that point.
+// If the segments are collinear and overlap, the result is the overlapping segment, running in the
+// same direction as s1.
+// If the segments do not meet, this function returns false.
+func (s1 Segment2) Intersect(s2 Segment2) (Segment2, bool) {
+	d1 := s1.B.Subtract(s1.A)
+	d2 := s2.B.Subtract(s2.A)
+
+	// a segment of length 0 is just a point, which either lies on the other segment or doesn't
+	if d1.Dot(d1) == 0 {
+		if s2.Contains(s1.A) {
+			return Segment2{s1.A, s1.A}, true
+		}
+		return Segment2{}, false
+	}
+	if d2.Dot(d2) == 0 {
+		if s1.Contains(s2.A) {
+			return Segment2{s2.A, s2.A}, true
+		}
+		return Segment2{}, false
+	}
+
+	offset := s2.A.Subtract(s1.A)
+	denominator := d1.Cross(d2)
+
+	if denominator == 0 {
+		if offset.Cross(d1) != 0 {
+			// parallel, but on different lines
+			return Segment2{}, false
+		}
+
+		// collinear: express s2's endpoints as parameters along s1 and clip the interval to [0, 1]
+		lengthSquared := d1.Dot(d1)
+		t0 := offset.Dot(d1) / lengthSquared
+		t1 := t0 + d2.Dot(d1)/lengthSquared
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+
+		lo, hi := math.Max(t0, 0), math.Min(t1, 1)
+		if lo > hi {
+			return Segment2{}, false
+		}
+
+		return Segment2{s1.At(lo), s1.At(hi)}, true
+	}
+
+	t := offset.Cross(d2) / denominator
+	u := offset.Cross(d1) / denominator
+
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Segment2{}, false
+	}
+
+	p := s1.At(t)
+	return Segment2{p, p}, true
+}
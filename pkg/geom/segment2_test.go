@@ -0,0 +1,123 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestSegment2_Intersect(t *testing.T) {
+	tests := []struct {
+		name   string
+		s1     Segment2
+		s2     Segment2
+		want   Segment2
+		wantOk bool
+	}{
+		{
+			name:   "crossing",
+			s1:     Segment2{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 2, Y: 2}},
+			s2:     Segment2{vec.Vec2{X: 0, Y: 2}, vec.Vec2{X: 2, Y: 0}},
+			want:   Segment2{vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 1}},
+			wantOk: true,
+		},
+		{
+			name:   "touching at endpoint",
+			s1:     Segment2{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 1, Y: 0}},
+			s2:     Segment2{vec.Vec2{X: 1, Y: 0}, vec.Vec2{X: 1, Y: 5}},
+			want:   Segment2{vec.Vec2{X: 1, Y: 0}, vec.Vec2{X: 1, Y: 0}},
+			wantOk: true,
+		},
+		{
+			name:   "not reaching",
+			s1:     Segment2{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 1, Y: 0}},
+			s2:     Segment2{vec.Vec2{X: 2, Y: -1}, vec.Vec2{X: 2, Y: 1}},
+			wantOk: false,
+		},
+		{
+			name:   "parallel",
+			s1:     Segment2{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 1, Y: 0}},
+			s2:     Segment2{vec.Vec2{X: 0, Y: 1}, vec.Vec2{X: 1, Y: 1}},
+			wantOk: false,
+		},
+		{
+			name:   "collinear overlapping",
+			s1:     Segment2{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 4, Y: 0}},
+			s2:     Segment2{vec.Vec2{X: 5, Y: 0}, vec.Vec2{X: 2, Y: 0}},
+			want:   Segment2{vec.Vec2{X: 2, Y: 0}, vec.Vec2{X: 4, Y: 0}},
+			wantOk: true,
+		},
+		{
+			name:   "collinear containing",
+			s1:     Segment2{vec.Vec2{X: 4, Y: 4}, vec.Vec2{X: 0, Y: 0}},
+			s2:     Segment2{vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 2, Y: 2}},
+			want:   Segment2{vec.Vec2{X: 2, Y: 2}, vec.Vec2{X: 1, Y: 1}},
+			wantOk: true,
+		},
+		{
+			name:   "collinear touching end to end",
+			s1:     Segment2{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 1, Y: 0}},
+			s2:     Segment2{vec.Vec2{X: 1, Y: 0}, vec.Vec2{X: 2, Y: 0}},
+			want:   Segment2{vec.Vec2{X: 1, Y: 0}, vec.Vec2{X: 1, Y: 0}},
+			wantOk: true,
+		},
+		{
+			name:   "collinear disjoint",
+			s1:     Segment2{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 1, Y: 0}},
+			s2:     Segment2{vec.Vec2{X: 2, Y: 0}, vec.Vec2{X: 3, Y: 0}},
+			wantOk: false,
+		},
+		{
+			name:   "point on segment",
+			s1:     Segment2{vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 1}},
+			s2:     Segment2{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 2, Y: 2}},
+			want:   Segment2{vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 1, Y: 1}},
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.s1.Intersect(tt.s2)
+			if ok != tt.wantOk {
+				t.Errorf("s1.Intersect(s2) ok = %v, want %v", ok, tt.wantOk)
+				return
+			}
+			if ok && (!got.A.AlmostEquals(tt.want.A, 1e-12) || !got.B.AlmostEquals(tt.want.B, 1e-12)) {
+				t.Errorf("s1.Intersect(s2) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegment2_ClosestPoint(t *testing.T) {
+	s := Segment2{vec.Vec2{X: 0, Y: 0}, vec.Vec2{X: 4, Y: 0}}
+
+	tests := []struct {
+		name string
+		p    vec.Vec2
+		want vec.Vec2
+	}{
+		{
+			name: "above middle",
+			p:    vec.Vec2{X: 2, Y: 3},
+			want: vec.Vec2{X: 2, Y: 0},
+		},
+		{
+			name: "before start",
+			p:    vec.Vec2{X: -2, Y: 1},
+			want: vec.Vec2{X: 0, Y: 0},
+		},
+		{
+			name: "beyond end",
+			p:    vec.Vec2{X: 7, Y: -1},
+			want: vec.Vec2{X: 4, Y: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.ClosestPoint(tt.p); !got.Equals(tt.want) {
+				t.Errorf("s.ClosestPoint(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
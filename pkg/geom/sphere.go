@@ -0,0 +1,47 @@
+package geom
+
+import "github.com/michael-ryan/mikelib/pkg/vec"
+
+// Sphere represents a solid ball in 3D space.
+//
+// A sphere with a radius of 0 or less is empty: it contains no points, overlaps nothing, and is never hit by a ray.
+type Sphere struct {
+	Centre vec.Vec3
+	Radius float64
+}
+
+// Contains returns true if p lies inside or on the surface of s.
+func (s Sphere) Contains(p vec.Vec3) bool {
+	if s.Radius <= 0 {
+		return false
+	}
+
+	offset := p.Subtract(s.Centre)
+	return offset.Dot(offset) <= s.Radius*s.Radius
+}
+
+// Overlaps returns true if s1 and s2 share at least one point.
+func (s1 Sphere) Overlaps(s2 Sphere) bool {
+	if s1.Radius <= 0 || s2.Radius <= 0 {
+		return false
+	}
+
+	offset := s1.Centre.Subtract(s2.Centre)
+	radii := s1.Radius + s2.Radius
+	return offset.Dot(offset) <= radii*radii
+}
+
+// OverlapsAABB returns true if s and b share at least one point.
+func (s Sphere) OverlapsAABB(b AABB3) bool {
+	return s.Contains(b.ClosestPoint(s.Centre))
+}
+
+// OverlapsPlane returns true if s touches or crosses p.
+func (s Sphere) OverlapsPlane(p Plane) bool {
+	if s.Radius <= 0 {
+		return false
+	}
+
+	distance := p.SignedDistance(s.Centre)
+	return distance <= s.Radius && distance >= -s.Radius
+}
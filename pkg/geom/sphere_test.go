@@ -0,0 +1,219 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestSphere_Contains(t *testing.T) {
+	s := Sphere{vec.Vec3{X: 1, Y: 2, Z: 3}, 2}
+
+	tests := []struct {
+		name string
+		p    vec.Vec3
+		want bool
+	}{
+		{
+			name: "centre",
+			p:    vec.Vec3{X: 1, Y: 2, Z: 3},
+			want: true,
+		},
+		{
+			name: "on surface",
+			p:    vec.Vec3{X: 1, Y: 2, Z: 5},
+			want: true,
+		},
+		{
+			name: "just outside",
+			p:    vec.Vec3{X: 1, Y: 2, Z: 5.001},
+			want: false,
+		},
+		{
+			name: "outside diagonally",
+			p:    vec.Vec3{X: 2.5, Y: 3.5, Z: 4.5},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Contains(tt.p); got != tt.want {
+				t.Errorf("s.Contains(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSphere_Overlaps(t *testing.T) {
+	unit := Sphere{vec.Vec3{X: 0, Y: 0, Z: 0}, 1}
+
+	tests := []struct {
+		name string
+		s1   Sphere
+		s2   Sphere
+		want bool
+	}{
+		{
+			name: "identical",
+			s1:   unit,
+			s2:   unit,
+			want: true,
+		},
+		{
+			name: "one inside the other",
+			s1:   unit,
+			s2:   Sphere{vec.Vec3{X: 0.2, Y: 0, Z: 0}, 0.1},
+			want: true,
+		},
+		{
+			name: "touching",
+			s1:   unit,
+			s2:   Sphere{vec.Vec3{X: 0, Y: 3, Z: 0}, 2},
+			want: true,
+		},
+		{
+			name: "separated",
+			s1:   unit,
+			s2:   Sphere{vec.Vec3{X: 2, Y: 2, Z: 0}, 1},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s1.Overlaps(tt.s2); got != tt.want {
+				t.Errorf("s1.Overlaps(s2) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSphere_OverlapsAABB(t *testing.T) {
+	unit := AABB3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 1, Z: 1}}
+
+	tests := []struct {
+		name string
+		s    Sphere
+		b    AABB3
+		want bool
+	}{
+		{
+			name: "centre inside",
+			s:    Sphere{vec.Vec3{X: 0.5, Y: 0.5, Z: 0.5}, 0.1},
+			b:    unit,
+			want: true,
+		},
+		{
+			name: "near face",
+			s:    Sphere{vec.Vec3{X: 1.5, Y: 0.5, Z: 0.5}, 0.6},
+			b:    unit,
+			want: true,
+		},
+		{
+			name: "touching corner",
+			s:    Sphere{vec.Vec3{X: 2, Y: 1, Z: 1}, 1},
+			b:    unit,
+			want: true,
+		},
+		{
+			name: "near corner but outside",
+			s:    Sphere{vec.Vec3{X: 2, Y: 2, Z: 2}, 1.5},
+			b:    unit,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.OverlapsAABB(tt.b); got != tt.want {
+				t.Errorf("s.OverlapsAABB(b) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSphere_OverlapsPlane(t *testing.T) {
+	floor := Plane{Normal: vec.Vec3{X: 0, Y: 1, Z: 0}, D: 0}
+
+	tests := []struct {
+		name string
+		s    Sphere
+		p    Plane
+		want bool
+	}{
+		{
+			name: "above",
+			s:    Sphere{vec.Vec3{X: 0, Y: 2, Z: 0}, 1},
+			p:    floor,
+			want: false,
+		},
+		{
+			name: "crossing",
+			s:    Sphere{vec.Vec3{X: 0, Y: 0.5, Z: 0}, 1},
+			p:    floor,
+			want: true,
+		},
+		{
+			name: "touching from above",
+			s:    Sphere{vec.Vec3{X: 0, Y: 1, Z: 0}, 1},
+			p:    floor,
+			want: true,
+		},
+		{
+			name: "touching from below",
+			s:    Sphere{vec.Vec3{X: 3, Y: -1, Z: 4}, 1},
+			p:    floor,
+			want: true,
+		},
+		{
+			name: "below",
+			s:    Sphere{vec.Vec3{X: 0, Y: -2, Z: 0}, 1},
+			p:    floor,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.OverlapsPlane(tt.p); got != tt.want {
+				t.Errorf("s.OverlapsPlane(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSphere_NonPositiveRadius(t *testing.T) {
+	unit := Sphere{vec.Vec3{X: 0, Y: 0, Z: 0}, 1}
+	box := AABB3{vec.Vec3{X: -1, Y: -1, Z: -1}, vec.Vec3{X: 1, Y: 1, Z: 1}}
+	floor := Plane{Normal: vec.Vec3{X: 0, Y: 1, Z: 0}, D: 0}
+
+	tests := []struct {
+		name string
+		s    Sphere
+		p    vec.Vec3
+	}{
+		{
+			name: "radius 0",
+			s:    Sphere{vec.Vec3{X: 0, Y: 0, Z: 0}, 0},
+			p:    vec.Vec3{X: 0, Y: 0, Z: 0},
+		},
+		{
+			name: "negative radius",
+			s:    Sphere{vec.Vec3{X: 0, Y: 0, Z: 0}, -2},
+			p:    vec.Vec3{X: 0, Y: 0, Z: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.s.Contains(tt.p) {
+				t.Errorf("s.Contains(%v) = true, want false", tt.p)
+			}
+			if tt.s.Overlaps(unit) || unit.Overlaps(tt.s) {
+				t.Errorf("s.Overlaps(unit) = true, want false")
+			}
+			if tt.s.OverlapsAABB(box) {
+				t.Errorf("s.OverlapsAABB(box) = true, want false")
+			}
+			if tt.s.OverlapsPlane(floor) {
+				t.Errorf("s.OverlapsPlane(floor) = true, want false")
+			}
+		})
+	}
+}
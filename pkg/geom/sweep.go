@@ -0,0 +1,273 @@
+package geom
+
+import (
+	"cmp"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// sweepEvent is an endpoint of an edge, as met by a line sweeping across the plane from left to right.
+//
+// Each edge has a left event, at whichever endpoint the sweep line meets first, and a right event. The left
+// event also holds the state of the edge as a whole. An edge is said to be below another where the vertical
+// line through both meets it lower down. Below a vertical edge means to the right of it, looking up it.
+type sweepEvent struct {
+	point vec.Vec2
+	left  bool
+	other *sweepEvent
+	// id counts up as events are created, so that ties between events are always broken the same way.
+	id int
+
+	// wind holds how much the winding number of each input increases on crossing the edge from below to above.
+	wind [2]int
+	// below holds the winding number of each input just below the edge.
+	below [2]int
+	// node is the edge's place in the sweep line, while the sweep line crosses it.
+	node *statusNode
+	// boundary is the edge's index in the boundary of the result, or -1 if it is not part of it.
+	boundary int
+	// boundaryNode is the edge's place in the sweep line that holds only boundary edges.
+	boundaryNode *statusNode
+}
+
+// comparePoints orders points by X, then by Y, which is the order the sweep line meets them in.
+func comparePoints(p1, p2 vec.Vec2) int {
+	if c := cmp.Compare(p1.X, p2.X); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(p1.Y, p2.Y)
+}
+
+// eventBefore returns true if the sweep line should process e1 before e2.
+func eventBefore(e1, e2 *sweepEvent) bool {
+	if c := comparePoints(e1.point, e2.point); c != 0 {
+		return c < 0
+	}
+
+	// edges ending at a point leave the sweep line before edges starting there join it
+	if e1.left != e2.left {
+		return !e1.left
+	}
+
+	// edges starting at the same point join from the bottom up, so that each finds the edge below it
+	if e1.left {
+		if o := Orient2D(e1.point, e1.other.point, e2.other.point); o != Collinear {
+			return o == Anticlockwise
+		}
+	}
+
+	return e1.id < e2.id
+}
+
+// edgeBelow returns true if the edge of left event e1 lies below the edge of left event e2, where the sweep
+// line crosses them both.
+func edgeBelow(e1, e2 *sweepEvent) bool {
+	p1, q1 := e1.point, e1.other.point
+	p2, q2 := e2.point, e2.other.point
+
+	if Orient2D(p1, q1, p2) == Collinear && Orient2D(p1, q1, q2) == Collinear {
+		// edges along the same line are stacked in the order they were created
+		return e1.id < e2.id
+	}
+
+	// the edge that joined the sweep line first decides, by which side of it the other edge starts on,
+	// or if it starts on the edge, which side it heads towards
+	if eventBefore(e1, e2) {
+		o := Orient2D(p1, q1, p2)
+		if o == Collinear {
+			o = Orient2D(p1, q1, q2)
+		}
+		return o == Anticlockwise
+	}
+
+	o := Orient2D(p2, q2, p1)
+	if o == Collinear {
+		o = Orient2D(p2, q2, q1)
+	}
+	return o == Clockwise
+}
+
+// eventQueue is a priority queue of sweep events, yielding them in the order given by [eventBefore].
+type eventQueue struct {
+	events []*sweepEvent
+}
+
+func (q *eventQueue) len() int {
+	return len(q.events)
+}
+
+// push adds e to the queue.
+func (q *eventQueue) push(e *sweepEvent) {
+	q.events = append(q.events, e)
+
+	// sift the new event up the binary heap until its parent comes before it
+	i := len(q.events) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !eventBefore(q.events[i], q.events[parent]) {
+			break
+		}
+		q.events[parent], q.events[i] = q.events[i], q.events[parent]
+		i = parent
+	}
+}
+
+// pop removes and returns the first event. The queue must not be empty.
+func (q *eventQueue) pop() *sweepEvent {
+	top := q.events[0]
+	last := len(q.events) - 1
+	q.events[0] = q.events[last]
+	q.events = q.events[:last]
+
+	// sift the moved event down until neither child comes before it
+	i := 0
+	for {
+		first := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(q.events) && eventBefore(q.events[child], q.events[first]) {
+				first = child
+			}
+		}
+		if first == i {
+			break
+		}
+		q.events[first], q.events[i] = q.events[i], q.events[first]
+		i = first
+	}
+
+	return top
+}
+
+// sweepStatus holds the edges crossed by the sweep line, ordered from bottom to top by [edgeBelow].
+//
+// It is a treap: a binary search tree whose nodes also form a heap on a priority derived from the event id,
+// which keeps the tree balanced in expectation. Edges are only compared when they are inserted. Removal and
+// finding neighbours work from a node's position alone, so they still work if rounding has left the
+// edges slightly out of order.
+type sweepStatus struct {
+	root *statusNode
+}
+
+type statusNode struct {
+	event               *sweepEvent
+	priority            uint64
+	parent, left, right *statusNode
+}
+
+// insert adds the edge of left event e to the sweep line, returning its node.
+func (s *sweepStatus) insert(e *sweepEvent) *statusNode {
+	// splitmix64 scatters the sequential ids into well mixed priorities
+	z := uint64(e.id) + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	n := &statusNode{event: e, priority: z ^ (z >> 31)}
+
+	if s.root == nil {
+		s.root = n
+		return n
+	}
+
+	for parent := s.root; ; {
+		child := &parent.right
+		if edgeBelow(e, parent.event) {
+			child = &parent.left
+		}
+		if *child == nil {
+			*child = n
+			n.parent = parent
+			break
+		}
+		parent = *child
+	}
+
+	for n.parent != nil && n.parent.priority < n.priority {
+		s.rotateUp(n)
+	}
+
+	return n
+}
+
+// remove takes n out of the sweep line.
+func (s *sweepStatus) remove(n *statusNode) {
+	// rotate n down to a leaf, keeping the heap order among the rest of the nodes
+	for n.left != nil || n.right != nil {
+		child := n.left
+		if child == nil || (n.right != nil && n.right.priority > child.priority) {
+			child = n.right
+		}
+		s.rotateUp(child)
+	}
+
+	switch {
+	case n.parent == nil:
+		s.root = nil
+	case n.parent.left == n:
+		n.parent.left = nil
+	default:
+		n.parent.right = nil
+	}
+	n.parent = nil
+}
+
+// rotateUp swaps n with its parent, preserving the order of the tree.
+func (s *sweepStatus) rotateUp(n *statusNode) {
+	p, g := n.parent, n.parent.parent
+
+	if p.left == n {
+		p.left = n.right
+		if n.right != nil {
+			n.right.parent = p
+		}
+		n.right = p
+	} else {
+		p.right = n.left
+		if n.left != nil {
+			n.left.parent = p
+		}
+		n.left = p
+	}
+	p.parent = n
+	n.parent = g
+
+	switch {
+	case g == nil:
+		s.root = n
+	case g.left == p:
+		g.left = n
+	default:
+		g.right = n
+	}
+}
+
+// prev returns the node of the edge immediately below n, or nil if there is none.
+func (n *statusNode) prev() *statusNode {
+	if n.left != nil {
+		n = n.left
+		for n.right != nil {
+			n = n.right
+		}
+		return n
+	}
+
+	for n.parent != nil && n.parent.left == n {
+		n = n.parent
+	}
+	return n.parent
+}
+
+// next returns the node of the edge immediately above n, or nil if there is none.
+func (n *statusNode) next() *statusNode {
+	if n.right != nil {
+		n = n.right
+		for n.left != nil {
+			n = n.left
+		}
+		return n
+	}
+
+	for n.parent != nil && n.parent.right == n {
+		n = n.parent
+	}
+	return n.parent
+}
@@ -0,0 +1,40 @@
+package geom
+
+import (
+	"fmt"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Triangle3 represents a triangle in 3D space with vertices A, B and C.
+type Triangle3 struct {
+	A, B, C vec.Vec3
+}
+
+// Normal returns the unit normal of the triangle, pointing towards the side from which A, B and C appear anticlockwise.
+//
+// If the triangle is degenerate (its vertices are collinear), it has no normal and this function will return an error
+// wrapping [vec.ErrZeroLength].
+func (t Triangle3) Normal() (vec.Vec3, error) {
+	normal, err := t.B.Subtract(t.A).Cross(t.C.Subtract(t.A)).Normalised()
+	if err != nil {
+		return vec.Vec3{}, vec.NewOpError("Triangle3.Normal", fmt.Errorf("%w: triangle is degenerate", vec.ErrZeroLength), t)
+	}
+
+	return normal, nil
+}
+
+// Area returns the area of the triangle.
+func (t Triangle3) Area() float64 {
+	return t.B.Subtract(t.A).Cross(t.C.Subtract(t.A)).Magnitude() / 2
+}
+
+// Centroid returns the mean of the triangle's vertices.
+func (t Triangle3) Centroid() vec.Vec3 {
+	return t.A.Add(t.B).Add(t.C).Multiply(1.0 / 3)
+}
+
+// Bounds returns the smallest axis-aligned box containing the triangle.
+func (t Triangle3) Bounds() AABB3 {
+	return AABB3{t.A, t.A}.Expand(t.B).Expand(t.C)
+}
@@ -0,0 +1,104 @@
+package geom
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestTriangle3_Normal(t *testing.T) {
+	tests := []struct {
+		name    string
+		tri     Triangle3
+		want    vec.Vec3
+		wantErr error
+	}{
+		{
+			name: "anticlockwise in xy plane",
+			tri:  Triangle3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 2, Y: 0, Z: 0}, vec.Vec3{X: 0, Y: 3, Z: 0}},
+			want: vec.Vec3{X: 0, Y: 0, Z: 1},
+		},
+		{
+			name: "clockwise in xy plane",
+			tri:  Triangle3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 0, Y: 3, Z: 0}, vec.Vec3{X: 2, Y: 0, Z: 0}},
+			want: vec.Vec3{X: 0, Y: 0, Z: -1},
+		},
+		{
+			name: "tilted",
+			tri:  Triangle3{vec.Vec3{X: 1, Y: 0, Z: 0}, vec.Vec3{X: 0, Y: 1, Z: 0}, vec.Vec3{X: 0, Y: 0, Z: 1}},
+			want: vec.Vec3{X: 1 / math.Sqrt(3), Y: 1 / math.Sqrt(3), Z: 1 / math.Sqrt(3)},
+		},
+		{
+			name:    "collinear vertices",
+			tri:     Triangle3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 2, Z: 3}, vec.Vec3{X: 2, Y: 4, Z: 6}},
+			wantErr: vec.ErrZeroLength,
+		},
+		{
+			name:    "all vertices equal",
+			tri:     Triangle3{vec.Vec3{X: 1, Y: 1, Z: 1}, vec.Vec3{X: 1, Y: 1, Z: 1}, vec.Vec3{X: 1, Y: 1, Z: 1}},
+			wantErr: vec.ErrZeroLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tri.Normal()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("tri.Normal() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("tri.Normal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriangle3_Area(t *testing.T) {
+	tests := []struct {
+		name string
+		tri  Triangle3
+		want float64
+	}{
+		{
+			name: "right angled",
+			tri:  Triangle3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 2, Y: 0, Z: 0}, vec.Vec3{X: 0, Y: 3, Z: 0}},
+			want: 3,
+		},
+		{
+			name: "equilateral",
+			tri:  Triangle3{vec.Vec3{X: 1, Y: 0, Z: 0}, vec.Vec3{X: 0, Y: 1, Z: 0}, vec.Vec3{X: 0, Y: 0, Z: 1}},
+			want: math.Sqrt(3) / 2,
+		},
+		{
+			name: "degenerate",
+			tri:  Triangle3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 1, Y: 1, Z: 1}, vec.Vec3{X: 2, Y: 2, Z: 2}},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tri.Area(); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("tri.Area() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriangle3_Centroid(t *testing.T) {
+	tri := Triangle3{vec.Vec3{X: 0, Y: 0, Z: 0}, vec.Vec3{X: 3, Y: 0, Z: 6}, vec.Vec3{X: 0, Y: 3, Z: -3}}
+	want := vec.Vec3{X: 1, Y: 1, Z: 1}
+
+	if got := tri.Centroid(); !got.AlmostEquals(want, 1e-12) {
+		t.Errorf("tri.Centroid() = %v, want %v", got, want)
+	}
+}
+
+func TestTriangle3_Bounds(t *testing.T) {
+	tri := Triangle3{vec.Vec3{X: 1, Y: -2, Z: 0}, vec.Vec3{X: -1, Y: 4, Z: 2}, vec.Vec3{X: 0, Y: 0, Z: -3}}
+	want := AABB3{vec.Vec3{X: -1, Y: -2, Z: -3}, vec.Vec3{X: 1, Y: 4, Z: 2}}
+
+	if got := tri.Bounds(); got != want {
+		t.Errorf("tri.Bounds() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,350 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Triangulate splits the polygon outer, with the given holes cut out of it, into triangles using ear clipping.
+//
+// The result lists each triangle as three vertex indices in anticlockwise order. Indices count through
+// the vertices of outer first, then each hole in turn, so with an outer ring of 5 vertices, index 5 refers
+// to holes[0][0].
+//
+// Each ring may be given in either orientation. Holes are joined to the outer ring by bridge edges before
+// clipping, so every vertex of every ring is used. Vertices lying on a straight line between their neighbours
+// may be dropped from the result without affecting the area covered.
+//
+// Every ring must be simple, have at least three vertices and enclose some area, and the holes must lie
+// strictly inside outer without touching it or each other. No hole may lie inside another. Otherwise, this function will return an error
+// wrapping [vec.ErrInvalidArgument] that describes the problem.
+func Triangulate(outer Polygon, holes ...Polygon) ([][3]int, error) {
+	rings := append([]Polygon{outer}, holes...)
+
+	if err := validateRings(rings); err != nil {
+		return nil, vec.NewOpError("Triangulate", err)
+	}
+
+	// flatten every ring into one list of points, remembering where each ring starts
+	var points []vec.Vec2
+	starts := make([]int, len(rings))
+	for r, ring := range rings {
+		starts[r] = len(points)
+		points = append(points, ring...)
+	}
+
+	ringIndices := func(r int, want Orientation) []int {
+		indices := make([]int, len(rings[r]))
+		for i := range indices {
+			indices[i] = starts[r] + i
+		}
+		if rings[r].Orientation() != want {
+			for i, j := 0, len(indices)-1; i < j; i, j = i+1, j-1 {
+				indices[i], indices[j] = indices[j], indices[i]
+			}
+		}
+		return indices
+	}
+
+	// the outer ring runs anticlockwise and the holes clockwise, so that the region to be triangulated
+	// is always on the left
+	ring := ringIndices(0, Anticlockwise)
+
+	holeRings := make([][]int, len(holes))
+	for h := range holes {
+		holeRings[h] = ringIndices(h+1, Clockwise)
+	}
+
+	// bridge the holes in order of their rightmost point, right to left, so that each bridge cannot
+	// cross a hole that is yet to be joined
+	order := make([]int, len(holes))
+	for i := range order {
+		order[i] = i
+	}
+	rightmost := func(h int) int {
+		best := 0
+		for i, p := range holeRings[h] {
+			if points[p].X > points[holeRings[h][best]].X {
+				best = i
+			}
+		}
+		return best
+	}
+	for i := 1; i < len(order); i++ {
+		for j := i; j > 0 && points[holeRings[order[j]][rightmost(order[j])]].X > points[holeRings[order[j-1]][rightmost(order[j-1])]].X; j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+		}
+	}
+
+	for _, h := range order {
+		var err error
+		ring, err = bridgeHole(points, ring, holeRings[h], rightmost(h))
+		if err != nil {
+			return nil, vec.NewOpError("Triangulate", err)
+		}
+	}
+
+	triangles, err := clipEars(points, ring)
+	if err != nil {
+		return nil, vec.NewOpError("Triangulate", err)
+	}
+
+	return triangles, nil
+}
+
+// validateRings checks that every ring is non-degenerate, that no two edges cross or touch, other than
+// consecutive edges of the same ring meeting at their shared vertex, and that every hole lies inside
+// the outer ring but outside every other hole.
+func validateRings(rings []Polygon) error {
+	for r, ring := range rings {
+		if len(ring) < 3 {
+			return fmt.Errorf("%w: ring %v has %v vertices, at least 3 are needed", vec.ErrInvalidArgument, r, len(ring))
+		}
+		if ring.SignedArea() == 0 {
+			return fmt.Errorf("%w: ring %v has no area", vec.ErrInvalidArgument, r)
+		}
+	}
+
+	for r1, ring1 := range rings {
+		for i := range ring1 {
+			e1 := Segment2{ring1[i], ring1[(i+1)%len(ring1)]}
+
+			for r2 := r1; r2 < len(rings); r2++ {
+				ring2 := rings[r2]
+
+				j := 0
+				if r1 == r2 {
+					j = i + 1
+				}
+				for ; j < len(ring2); j++ {
+					adjacent := r1 == r2 && (j == i+1 || (i == 0 && j == len(ring1)-1))
+					e2 := Segment2{ring2[j], ring2[(j+1)%len(ring2)]}
+
+					if adjacent {
+						// consecutive edges may only share their common vertex, not fold back over each other
+						if Orient2D(e1.A, e1.B, e2.B) == Collinear && Orient2D(e1.A, e1.B, e2.A) == Collinear &&
+							e1.B.Subtract(e1.A).Dot(e2.B.Subtract(e2.A)) < 0 {
+							return fmt.Errorf("%w: polygon is self-intersecting: edges %v and %v of ring %v overlap", vec.ErrInvalidArgument, i, j, r1)
+						}
+						continue
+					}
+
+					if segmentsTouch(e1, e2) {
+						if r1 == r2 {
+							return fmt.Errorf("%w: polygon is self-intersecting: edges %v and %v of ring %v intersect", vec.ErrInvalidArgument, i, j, r1)
+						}
+						return fmt.Errorf("%w: polygon is self-intersecting: edge %v of ring %v intersects edge %v of ring %v", vec.ErrInvalidArgument, i, r1, j, r2)
+					}
+				}
+			}
+		}
+	}
+
+	for r := 1; r < len(rings); r++ {
+		if !rings[0].Contains(rings[r][0]) {
+			return fmt.Errorf("%w: hole %v lies outside the outer ring", vec.ErrInvalidArgument, r-1)
+		}
+	}
+
+	// the rings don't touch, so one hole lies inside another exactly when any of its vertices does
+	for r1 := 1; r1 < len(rings); r1++ {
+		for r2 := 1; r2 < len(rings); r2++ {
+			if r1 != r2 && rings[r2].Contains(rings[r1][0]) {
+				return fmt.Errorf("%w: hole %v lies inside hole %v", vec.ErrInvalidArgument, r1-1, r2-1)
+			}
+		}
+	}
+
+	return nil
+}
+
+// segmentsTouch returns true if s1 and s2 share at least one point, using exact predicates.
+func segmentsTouch(s1, s2 Segment2) bool {
+	o1 := Orient2D(s1.A, s1.B, s2.A)
+	o2 := Orient2D(s1.A, s1.B, s2.B)
+	o3 := Orient2D(s2.A, s2.B, s1.A)
+	o4 := Orient2D(s2.A, s2.B, s1.B)
+
+	if o1*o2 < 0 && o3*o4 < 0 {
+		return true
+	}
+
+	// a collinear endpoint only touches if it lies within the other segment's bounding box
+	within := func(s Segment2, p vec.Vec2) bool {
+		return p.X >= math.Min(s.A.X, s.B.X) && p.X <= math.Max(s.A.X, s.B.X) &&
+			p.Y >= math.Min(s.A.Y, s.B.Y) && p.Y <= math.Max(s.A.Y, s.B.Y)
+	}
+
+	return (o1 == Collinear && within(s1, s2.A)) ||
+		(o2 == Collinear && within(s1, s2.B)) ||
+		(o3 == Collinear && within(s2, s1.A)) ||
+		(o4 == Collinear && within(s2, s1.B))
+}
+
+// bridgeHole splices hole into ring by joining hole[m], its rightmost vertex, to a vertex of ring that
+// is visible from it, following Eberly's "Triangulation by Ear Clipping".
+func bridgeHole(points []vec.Vec2, ring, hole []int, m int) ([]int, error) {
+	mp := points[hole[m]]
+
+	// cast a ray from M towards +X, and find the nearest ring edge it hits
+	hit := -1
+	var hitX float64
+	for i := range ring {
+		a, b := points[ring[i]], points[ring[(i+1)%len(ring)]]
+
+		if (a.Y > mp.Y) == (b.Y > mp.Y) && a.Y != mp.Y && b.Y != mp.Y {
+			continue
+		}
+		if a.Y == b.Y {
+			// a horizontal edge along the ray: its nearest endpoint is where the ray first meets it
+			if a.Y != mp.Y {
+				continue
+			}
+			x := math.Min(a.X, b.X)
+			if x >= mp.X && (hit < 0 || x < hitX) {
+				hit, hitX = i, x
+			}
+			continue
+		}
+
+		x := a.X + (mp.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+		if x >= mp.X && (hit < 0 || x < hitX) {
+			hit, hitX = i, x
+		}
+	}
+
+	if hit < 0 {
+		return nil, fmt.Errorf("%w: could not find a bridge from a hole to the outer ring", vec.ErrInvalidArgument)
+	}
+
+	// the candidate vertex is whichever end of the hit edge is furthest along the ray
+	i := vec.Vec2{X: hitX, Y: mp.Y}
+	a, b := hit, (hit+1)%len(ring)
+	p := a
+	if points[ring[b]].X > points[ring[a]].X {
+		p = b
+	}
+
+	pp := points[ring[p]]
+	if !pp.Equals(i) {
+		// another vertex may be hiding P from M; if so, the reflex vertex in triangle M, I, P making the
+		// smallest angle with the ray is visible instead
+		best := p
+		bestAngle := math.Inf(1)
+		bestDistance := math.Inf(1)
+		for k := range ring {
+			v := points[ring[k]]
+			if k == p || v.Equals(pp) {
+				continue
+			}
+			prev, next := points[ring[(k+len(ring)-1)%len(ring)]], points[ring[(k+1)%len(ring)]]
+			if Orient2D(prev, v, next) != Clockwise || !inTriangle(mp, i, pp, v) {
+				continue
+			}
+
+			d := v.Subtract(mp)
+			angle := math.Abs(math.Atan2(d.Y, d.X))
+			if distance := d.Magnitude(); angle < bestAngle || (angle == bestAngle && distance < bestDistance) {
+				best, bestAngle, bestDistance = k, angle, distance
+			}
+		}
+		p = best
+	}
+
+	bridged := make([]int, 0, len(ring)+len(hole)+2)
+	bridged = append(bridged, ring[:p+1]...)
+	for k := range hole {
+		bridged = append(bridged, hole[(m+k)%len(hole)])
+	}
+	bridged = append(bridged, hole[m], ring[p])
+	bridged = append(bridged, ring[p+1:]...)
+
+	return bridged, nil
+}
+
+// inTriangle returns true if p lies inside or on the boundary of the triangle a, b, c, in either orientation.
+func inTriangle(a, b, c, p vec.Vec2) bool {
+	o := Orient2D(a, b, c)
+	if o == Collinear {
+		return false
+	}
+
+	return Orient2D(a, b, p) != -o && Orient2D(b, c, p) != -o && Orient2D(c, a, p) != -o
+}
+
+// clipEars triangulates the anticlockwise ring by repeatedly cutting off an ear: a convex vertex whose
+// triangle with its neighbours contains no other vertex.
+func clipEars(points []vec.Vec2, ring []int) ([][3]int, error) {
+	triangles := make([][3]int, 0, len(ring)-2)
+	ring = append([]int(nil), ring...)
+
+	for len(ring) > 3 {
+		ear := findEar(points, ring, true)
+		if ear < 0 {
+			// the bridges make the ring only weakly simple, which can leave vertices lying exactly on
+			// the edge of every candidate ear, so fall back to only rejecting vertices strictly inside
+			ear = findEar(points, ring, false)
+		}
+		if ear < 0 {
+			return nil, fmt.Errorf("%w: could not find an ear to clip, the polygon may be self-intersecting", vec.ErrInvalidArgument)
+		}
+
+		prev, next := (ear+len(ring)-1)%len(ring), (ear+1)%len(ring)
+		a, b, c := ring[prev], ring[ear], ring[next]
+		if Orient2D(points[a], points[b], points[c]) == Anticlockwise {
+			triangles = append(triangles, [3]int{a, b, c})
+		}
+
+		ring = append(ring[:ear], ring[ear+1:]...)
+	}
+
+	if Orient2D(points[ring[0]], points[ring[1]], points[ring[2]]) == Anticlockwise {
+		triangles = append(triangles, [3]int{ring[0], ring[1], ring[2]})
+	}
+
+	return triangles, nil
+}
+
+// findEar returns the position in ring of a vertex that can be clipped, or -1 if there is none.
+//
+// Vertices lying on a straight line between their neighbours are always clippable, as they add no area.
+// If strict is true, any other vertex on the boundary of a candidate ear prevents it being clipped.
+func findEar(points []vec.Vec2, ring []int, strict bool) int {
+	for i := range ring {
+		prev, next := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+		a, b, c := points[prev], points[ring[i]], points[next]
+
+		switch Orient2D(a, b, c) {
+		case Collinear:
+			return i
+		case Clockwise:
+			continue
+		}
+
+		ear := true
+		for _, k := range ring {
+			v := points[k]
+			if v.Equals(a) || v.Equals(b) || v.Equals(c) {
+				continue
+			}
+
+			inside := Orient2D(a, b, v) != Clockwise && Orient2D(b, c, v) != Clockwise && Orient2D(c, a, v) != Clockwise
+			if !strict {
+				inside = Orient2D(a, b, v) == Anticlockwise && Orient2D(b, c, v) == Anticlockwise && Orient2D(c, a, v) == Anticlockwise
+			}
+
+			if inside {
+				ear = false
+				break
+			}
+		}
+
+		if ear {
+			return i
+		}
+	}
+
+	return -1
+}
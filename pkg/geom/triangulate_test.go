@@ -0,0 +1,237 @@
+package geom
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// checkTriangulation verifies that triangles are anticlockwise, lie within the polygon, and exactly cover its area.
+func checkTriangulation(t *testing.T, triangles [][3]int, outer Polygon, holes ...Polygon) {
+	t.Helper()
+
+	var points []vec.Vec2
+	points = append(points, outer...)
+	wantArea := outer.Area()
+	for _, hole := range holes {
+		points = append(points, hole...)
+		wantArea -= hole.Area()
+	}
+
+	var area float64
+	for _, tri := range triangles {
+		a, b, c := points[tri[0]], points[tri[1]], points[tri[2]]
+
+		if Orient2D(a, b, c) != Anticlockwise {
+			t.Fatalf("triangle %v is not anticlockwise", tri)
+		}
+
+		centroid := a.Add(b).Add(c).Multiply(1.0 / 3)
+		if !outer.Contains(centroid) {
+			t.Fatalf("triangle %v lies outside the outer ring", tri)
+		}
+		for h, hole := range holes {
+			if hole.WindingNumber(centroid) != 0 {
+				t.Fatalf("triangle %v lies inside hole %v", tri, h)
+			}
+		}
+
+		area += Polygon{a, b, c}.Area()
+	}
+
+	if math.Abs(area-wantArea) > 1e-9*wantArea {
+		t.Errorf("triangles cover area %v, want %v", area, wantArea)
+	}
+}
+
+func TestTriangulate(t *testing.T) {
+	square := Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+
+	tests := []struct {
+		name          string
+		outer         Polygon
+		holes         []Polygon
+		wantTriangles int
+		wantErr       bool
+	}{
+		{
+			name:          "triangle",
+			outer:         Polygon{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}},
+			wantTriangles: 1,
+		},
+		{
+			name:          "anticlockwise square",
+			outer:         square,
+			wantTriangles: 2,
+		},
+		{
+			name:          "clockwise square",
+			outer:         square.Reversed(),
+			wantTriangles: 2,
+		},
+		{
+			name:          "concave arrow",
+			outer:         Polygon{{X: 0, Y: 0}, {X: 4, Y: 2}, {X: 0, Y: 4}, {X: 1, Y: 2}},
+			wantTriangles: 2,
+		},
+		{
+			name: "comb",
+			outer: Polygon{
+				{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 5, Y: 3}, {X: 4, Y: 3}, {X: 4, Y: 1},
+				{X: 3, Y: 1}, {X: 3, Y: 3}, {X: 2, Y: 3}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 3}, {X: 0, Y: 3},
+			},
+			wantTriangles: 10,
+		},
+		{
+			name:          "square with square hole",
+			outer:         square,
+			holes:         []Polygon{{{X: 4, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 6}, {X: 4, Y: 6}}},
+			wantTriangles: 8,
+		},
+		{
+			name:  "square with two holes in either orientation",
+			outer: square.Reversed(),
+			holes: []Polygon{
+				{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 3}, {X: 1, Y: 3}},
+				{{X: 7, Y: 7}, {X: 7, Y: 9}, {X: 9, Y: 9}, {X: 9, Y: 7}},
+			},
+			wantTriangles: 14,
+		},
+		{
+			name:  "holes sharing a horizontal line with a vertex",
+			outer: square,
+			holes: []Polygon{
+				{{X: 1, Y: 4}, {X: 2, Y: 5}, {X: 1, Y: 6}},
+				{{X: 5, Y: 4}, {X: 6, Y: 5}, {X: 5, Y: 6}},
+			},
+			wantTriangles: 12,
+		},
+		{
+			name:  "hole hidden behind a reflex vertex",
+			outer: Polygon{{X: 0, Y: 0}, {X: 6, Y: 0}, {X: 7, Y: 2.5}, {X: 7.5, Y: 0}, {X: 10, Y: 0}, {X: 8, Y: 10}, {X: 0, Y: 10}},
+			holes: []Polygon{
+				{{X: 1, Y: 4}, {X: 2, Y: 5}, {X: 1, Y: 6}},
+			},
+			wantTriangles: 10,
+		},
+		{
+			name:    "too few vertices",
+			outer:   Polygon{{X: 0, Y: 0}, {X: 1, Y: 0}},
+			wantErr: true,
+		},
+		{
+			name:    "no area",
+			outer:   Polygon{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}},
+			wantErr: true,
+		},
+		{
+			name:    "bowtie",
+			outer:   Polygon{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "vertex touching an edge",
+			outer:   Polygon{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 2, Y: 0}, {X: 0, Y: 4}},
+			wantErr: true,
+		},
+		{
+			name:    "spike",
+			outer:   Polygon{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 4}},
+			wantErr: true,
+		},
+		{
+			name:    "hole crossing the outer ring",
+			outer:   square,
+			holes:   []Polygon{{{X: 8, Y: 4}, {X: 12, Y: 4}, {X: 12, Y: 6}, {X: 8, Y: 6}}},
+			wantErr: true,
+		},
+		{
+			name:    "hole outside the outer ring",
+			outer:   square,
+			holes:   []Polygon{{{X: 20, Y: 20}, {X: 21, Y: 20}, {X: 21, Y: 21}}},
+			wantErr: true,
+		},
+		{
+			name:  "overlapping holes",
+			outer: square,
+			holes: []Polygon{
+				{{X: 1, Y: 1}, {X: 5, Y: 1}, {X: 5, Y: 5}, {X: 1, Y: 5}},
+				{{X: 3, Y: 3}, {X: 7, Y: 3}, {X: 7, Y: 7}, {X: 3, Y: 7}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Triangulate(tt.outer, tt.holes...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Triangulate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != tt.wantTriangles {
+				t.Errorf("Triangulate() returned %v triangles, want %v", len(got), tt.wantTriangles)
+			}
+			checkTriangulation(t, got, tt.outer, tt.holes...)
+		})
+	}
+}
+
+func TestTriangulate_NestedHoles(t *testing.T) {
+	square := Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	big := Polygon{{X: 2, Y: 2}, {X: 8, Y: 2}, {X: 8, Y: 8}, {X: 2, Y: 8}}
+	small := Polygon{{X: 4, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 6}, {X: 4, Y: 6}}
+
+	tests := []struct {
+		name    string
+		holes   []Polygon
+		wantMsg string
+	}{
+		{
+			name:    "inner hole last",
+			holes:   []Polygon{big, small},
+			wantMsg: "hole 1 lies inside hole 0",
+		},
+		{
+			name:    "inner hole first",
+			holes:   []Polygon{small, big},
+			wantMsg: "hole 0 lies inside hole 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Triangulate(square, tt.holes...)
+			if !errors.Is(err, vec.ErrInvalidArgument) {
+				t.Fatalf("Triangulate() error = %v, want %v", err, vec.ErrInvalidArgument)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Triangulate() error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestTriangulate_Circle(t *testing.T) {
+	// a many-sided ring with a concentric hole, where every vertex is used
+	const n = 64
+	var outer, hole Polygon
+	for i := range n {
+		angle := 2 * math.Pi * float64(i) / n
+		outer = append(outer, vec.Vec2{X: 10 * math.Cos(angle), Y: 10 * math.Sin(angle)})
+		hole = append(hole, vec.Vec2{X: 3 * math.Cos(angle), Y: 3 * math.Sin(angle)})
+	}
+
+	got, err := Triangulate(outer, hole)
+	if err != nil {
+		t.Fatalf("Triangulate() error = %v", err)
+	}
+	if len(got) != 2*n {
+		t.Errorf("Triangulate() returned %v triangles, want %v", len(got), 2*n)
+	}
+	checkTriangulation(t, got, outer, hole)
+}
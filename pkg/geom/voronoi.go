@@ -0,0 +1,59 @@
+package geom
+
+import "github.com/michael-ryan/mikelib/pkg/vec"
+
+// Voronoi returns the Voronoi diagram dual to the triangulation t, which must be a Delaunay triangulation
+// as returned by [Delaunay].
+//
+// Cell i is the region of bounds closer to t.Points[i] than to any other point, as an anticlockwise polygon.
+// Cells that would otherwise be unbounded are clipped to bounds. Points that are not part of the
+// triangulation, such as duplicates, have an empty cell, as do points whose cell lies entirely outside bounds.
+func (t Triangulation) Voronoi(bounds Rect) []Polygon {
+	cells := make([]Polygon, len(t.Points))
+
+	for i, neighbours := range t.Neighbours() {
+		if len(neighbours) == 0 {
+			continue
+		}
+
+		// a Voronoi cell is the intersection of the half-planes closer to the site than to each of its
+		// Delaunay neighbours
+		site := t.Points[i]
+		cell := bounds.Polygon()
+		for _, j := range neighbours {
+			other := t.Points[j]
+			cell = clipHalfPlane(cell, site.Lerp(other, 0.5), other.Subtract(site))
+		}
+
+		cells[i] = cell
+	}
+
+	return cells
+}
+
+// clipHalfPlane returns the part of poly lying on the side of the line through point opposite to normal,
+// i.e. where (x - point) . normal <= 0. If poly is concave, the result may include zero-width slivers
+// along the line.
+func clipHalfPlane(poly Polygon, point, normal vec.Vec2) Polygon {
+	clipped := make(Polygon, 0, len(poly)+1)
+
+	for i := range poly {
+		a, b := poly[i], poly[(i+1)%len(poly)]
+		da, db := a.Subtract(point).Dot(normal), b.Subtract(point).Dot(normal)
+
+		if da <= 0 {
+			clipped = append(clipped, a)
+		}
+
+		// the edge crosses the line, so add the crossing point
+		if (da < 0 && db > 0) || (da > 0 && db < 0) {
+			clipped = append(clipped, a.Lerp(b, da/(da-db)))
+		}
+	}
+
+	if len(clipped) == 0 {
+		return nil
+	}
+
+	return clipped
+}
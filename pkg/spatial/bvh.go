@@ -0,0 +1,335 @@
+package spatial
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+const (
+	// bvhBins is the number of candidate split planes, less one, evaluated along each axis.
+	bvhBins = 16
+	// bvhMaxLeafSize is the most triangles a leaf may hold, whatever the surface area heuristic prefers.
+	bvhMaxLeafSize = 8
+	// bvhTraversalCost is the cost of visiting a node, relative to testing a ray against one triangle.
+	bvhTraversalCost = 1.0
+)
+
+// BVH is a bounding volume hierarchy over a mesh of triangles, for fast ray casting.
+//
+// The hierarchy is built with the surface area heuristic, splitting each node where the expected cost of
+// tracing a ray through the two halves is lowest. Once built, the triangles may be moved with [BVH.Refit],
+// which keeps the hierarchy's structure but updates its bounds.
+type BVH struct {
+	triangles []geom.Triangle3
+	// order lists the triangle indices, arranged so each leaf covers a contiguous range
+	order []int
+	nodes []bvhNode
+
+	// centroids and boxes cache each triangle's centroid and bounds while the hierarchy is being built
+	centroids []vec.Vec3
+	boxes     []geom.AABB3
+}
+
+type bvhNode struct {
+	bounds geom.AABB3
+	// start and end delimit the node's triangles in order
+	start, end int
+	// left and right are the indices of the child nodes, or -1 for a leaf
+	left, right int
+}
+
+// TriangleHit describes where a ray hits a triangle in a [BVH].
+type TriangleHit struct {
+	geom.Hit3
+	// Triangle is the index of the triangle that was hit.
+	Triangle int
+}
+
+// NewBVH builds a bounding volume hierarchy over triangles. The hierarchy holds its own copy of triangles.
+func NewBVH(triangles []geom.Triangle3) *BVH {
+	b := &BVH{
+		triangles: append([]geom.Triangle3(nil), triangles...),
+		order:     make([]int, len(triangles)),
+	}
+	b.centroids = make([]vec.Vec3, len(triangles))
+	b.boxes = make([]geom.AABB3, len(triangles))
+	for i, tri := range triangles {
+		b.order[i] = i
+		b.centroids[i] = tri.Centroid()
+		b.boxes[i] = tri.Bounds()
+	}
+
+	if len(triangles) > 0 {
+		b.build(0, len(triangles))
+	}
+	b.centroids, b.boxes = nil, nil
+
+	return b
+}
+
+// Len returns the number of triangles in the hierarchy.
+func (b *BVH) Len() int {
+	return len(b.triangles)
+}
+
+// build creates the node covering order[start:end], along with its descendants, and returns its index.
+func (b *BVH) build(start, end int) int {
+	bounds := b.boxes[b.order[start]]
+	centroids := geom.AABB3{Min: b.centroids[b.order[start]], Max: b.centroids[b.order[start]]}
+	for _, t := range b.order[start+1 : end] {
+		bounds = bounds.Union(b.boxes[t])
+		centroids = centroids.Expand(b.centroids[t])
+	}
+
+	index := len(b.nodes)
+	b.nodes = append(b.nodes, bvhNode{bounds: bounds, start: start, end: end, left: -1, right: -1})
+
+	count := end - start
+	if count == 1 {
+		return index
+	}
+
+	axis, split, cost := b.bestSplit(start, end, centroids)
+
+	// compare against making this node a leaf, with the costs of both relative to the chance of a ray
+	// hitting this node
+	leafCost := float64(count)
+	if area := bounds.SurfaceArea(); area > 0 {
+		cost = bvhTraversalCost + cost/area
+	} else {
+		cost = math.Inf(1)
+	}
+
+	var mid int
+	switch {
+	case axis >= 0 && (cost < leafCost || count > bvhMaxLeafSize):
+		// partition by which side of the chosen plane each centroid falls
+		lo, hi := component(centroids.Min, axis), component(centroids.Max, axis)
+		mid = start
+		for i := start; i < end; i++ {
+			if bin(component(b.centroids[b.order[i]], axis), lo, hi) < split {
+				b.order[i], b.order[mid] = b.order[mid], b.order[i]
+				mid++
+			}
+		}
+	case count > bvhMaxLeafSize:
+		// every centroid coincides, so no plane separates them; split the list in half instead
+		mid = (start + end) / 2
+	default:
+		return index
+	}
+
+	left := b.build(start, mid)
+	right := b.build(mid, end)
+	b.nodes[index].left, b.nodes[index].right = left, right
+
+	return index
+}
+
+// bestSplit evaluates the surface area heuristic at evenly spaced planes through the centroid bounds of
+// order[start:end], returning the best axis, the first bin on the far side of the plane, and the cost,
+// which is the sum over both sides of surface area multiplied by triangle count.
+//
+// If the centroids all coincide, there is no plane to split at and the returned axis is -1.
+func (b *BVH) bestSplit(start, end int, centroids geom.AABB3) (axis, split int, cost float64) {
+	axis, cost = -1, math.Inf(1)
+
+	for a := range 3 {
+		lo, hi := component(centroids.Min, a), component(centroids.Max, a)
+		if lo == hi {
+			continue
+		}
+
+		var counts [bvhBins]int
+		var bounds [bvhBins]geom.AABB3
+		for _, t := range b.order[start:end] {
+			i := bin(component(b.centroids[t], a), lo, hi)
+			if counts[i] == 0 {
+				bounds[i] = b.boxes[t]
+			} else {
+				bounds[i] = bounds[i].Union(b.boxes[t])
+			}
+			counts[i]++
+		}
+
+		// sweep from the right to find the area and count beyond each plane, then from the left to
+		// combine them with the area and count before it
+		var rightArea [bvhBins]float64
+		var rightCount [bvhBins]int
+		var accumulated geom.AABB3
+		n := 0
+		for i := bvhBins - 1; i > 0; i-- {
+			if counts[i] > 0 {
+				if n == 0 {
+					accumulated = bounds[i]
+				} else {
+					accumulated = accumulated.Union(bounds[i])
+				}
+				n += counts[i]
+			}
+			rightArea[i], rightCount[i] = accumulated.SurfaceArea(), n
+		}
+
+		n = 0
+		for i := 0; i < bvhBins-1; i++ {
+			if counts[i] > 0 {
+				if n == 0 {
+					accumulated = bounds[i]
+				} else {
+					accumulated = accumulated.Union(bounds[i])
+				}
+				n += counts[i]
+			}
+
+			if n == 0 || rightCount[i+1] == 0 {
+				continue
+			}
+
+			c := accumulated.SurfaceArea()*float64(n) + rightArea[i+1]*float64(rightCount[i+1])
+			if c < cost {
+				axis, split, cost = a, i+1, c
+			}
+		}
+	}
+
+	return axis, split, cost
+}
+
+// bin returns which of the bvhBins equal intervals spanning lo to hi contains x.
+func bin(x, lo, hi float64) int {
+	i := int(bvhBins * (x - lo) / (hi - lo))
+	return max(0, min(i, bvhBins-1))
+}
+
+// component returns the X, Y or Z component of v, for axis 0, 1 or 2 respectively.
+func component(v vec.Vec3, axis int) float64 {
+	switch axis {
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	default:
+		return v.Z
+	}
+}
+
+// ClosestHit returns the first point at which r hits any triangle.
+//
+// If r hits no triangle, this function returns false.
+func (b *BVH) ClosestHit(r geom.Ray3) (TriangleHit, bool) {
+	best := TriangleHit{Hit3: geom.Hit3{Distance: math.Inf(1)}}
+	found := false
+
+	b.traverse(r, func(t int) bool {
+		if hit, ok := r.IntersectTriangle(b.triangles[t]); ok && hit.Distance < best.Distance {
+			best = TriangleHit{Hit3: hit, Triangle: t}
+			found = true
+		}
+		return false
+	}, func() float64 { return best.Distance })
+
+	return best, found
+}
+
+// AnyHit returns true if r hits any triangle at a distance less than maxDistance, stopping at the
+// first such hit found. This is cheaper than [BVH.ClosestHit], and suits shadow and visibility tests.
+func (b *BVH) AnyHit(r geom.Ray3, maxDistance float64) bool {
+	hit := false
+
+	b.traverse(r, func(t int) bool {
+		h, ok := r.IntersectTriangle(b.triangles[t])
+		hit = ok && h.Distance < maxDistance
+		return hit
+	}, func() float64 { return maxDistance })
+
+	return hit
+}
+
+// traverse visits the leaves r passes through, nearest first, calling visit on each of their triangles
+// until it returns true. Nodes that r only enters beyond limit() are skipped.
+func (b *BVH) traverse(r geom.Ray3, visit func(t int) bool, limit func() float64) {
+	if len(b.nodes) == 0 {
+		return
+	}
+
+	type entry struct {
+		node  int
+		entry float64
+	}
+	t, ok := rayEntry(r, b.nodes[0].bounds, 0)
+	if !ok {
+		return
+	}
+	stack := []entry{{0, t}}
+
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if e.entry > limit() {
+			continue
+		}
+
+		n := b.nodes[e.node]
+		if n.left < 0 {
+			for _, t := range b.order[n.start:n.end] {
+				if visit(t) {
+					return
+				}
+			}
+			continue
+		}
+
+		// push the further child first, so the nearer is visited first
+		leftEntry, leftOK := rayEntry(r, b.nodes[n.left].bounds, 0)
+		rightEntry, rightOK := rayEntry(r, b.nodes[n.right].bounds, 0)
+		near, far := entry{n.left, leftEntry}, entry{n.right, rightEntry}
+		nearOK, farOK := leftOK, rightOK
+		if rightOK && (!leftOK || rightEntry < leftEntry) {
+			near, far = far, near
+			nearOK, farOK = farOK, nearOK
+		}
+
+		if farOK {
+			stack = append(stack, far)
+		}
+		if nearOK {
+			stack = append(stack, near)
+		}
+	}
+}
+
+// Refit replaces the hierarchy's triangles with triangles, which must correspond one to one with those
+// it was built from, and updates the bounds of every node to match.
+//
+// The structure of the hierarchy is kept, so refitting is much faster than building a new one, but queries
+// slow down as the triangles move further from where they were when it was built.
+//
+// If triangles has a different length to the hierarchy, this function will return an error wrapping [vec.ErrInvalidArgument].
+func (b *BVH) Refit(triangles []geom.Triangle3) error {
+	if len(triangles) != len(b.triangles) {
+		return vec.NewOpError("BVH.Refit", fmt.Errorf("%w: got %v triangles, want %v", vec.ErrInvalidArgument, len(triangles), len(b.triangles)))
+	}
+
+	copy(b.triangles, triangles)
+
+	// children always come after their parents, so a reverse sweep updates them first
+	for i := len(b.nodes) - 1; i >= 0; i-- {
+		n := &b.nodes[i]
+
+		if n.left >= 0 {
+			n.bounds = b.nodes[n.left].bounds.Union(b.nodes[n.right].bounds)
+			continue
+		}
+
+		n.bounds = b.triangles[b.order[n.start]].Bounds()
+		for _, t := range b.order[n.start+1 : n.end] {
+			n.bounds = n.bounds.Union(b.triangles[t].Bounds())
+		}
+	}
+
+	return nil
+}
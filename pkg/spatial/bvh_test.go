@@ -0,0 +1,136 @@
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func randomTriangles(r *rand.Rand, n int, size float64) []geom.Triangle3 {
+	triangles := make([]geom.Triangle3, n)
+	for i := range triangles {
+		centre := randomVec3s(r, 1, size)[0]
+		corner := func() vec.Vec3 {
+			return centre.Add(vec.Vec3{X: r.Float64()*4 - 2, Y: r.Float64()*4 - 2, Z: r.Float64()*4 - 2})
+		}
+		triangles[i] = geom.Triangle3{A: corner(), B: corner(), C: corner()}
+	}
+	return triangles
+}
+
+func randomRays(r *rand.Rand, n int, size float64) []geom.Ray3 {
+	rays := make([]geom.Ray3, n)
+	for i := range rays {
+		origin := randomVec3s(r, 1, size)[0]
+		target := randomVec3s(r, 1, size)[0]
+		rays[i] = geom.Ray3{Origin: origin, Direction: target.Subtract(origin)}
+	}
+	return rays
+}
+
+// bruteClosestHit returns the index and distance of the nearest triangle hit by r, or -1 if there is none.
+func bruteClosestHit(triangles []geom.Triangle3, r geom.Ray3) (int, float64) {
+	best, bestDistance := -1, math.Inf(1)
+	for i, tri := range triangles {
+		if hit, ok := r.IntersectTriangle(tri); ok && hit.Distance < bestDistance {
+			best, bestDistance = i, hit.Distance
+		}
+	}
+	return best, bestDistance
+}
+
+func checkBVH(t *testing.T, b *BVH, triangles []geom.Triangle3, rays []geom.Ray3) {
+	t.Helper()
+
+	hits := 0
+	for _, r := range rays {
+		want, wantDistance := bruteClosestHit(triangles, r)
+
+		got, ok := b.ClosestHit(r)
+		if ok != (want >= 0) {
+			t.Fatalf("ClosestHit(%v) hit = %v, want %v", r, ok, want >= 0)
+		}
+		if ok {
+			hits++
+			if got.Triangle != want || got.Distance != wantDistance {
+				t.Fatalf("ClosestHit(%v) = triangle %v at %v, want triangle %v at %v", r, got.Triangle, got.Distance, want, wantDistance)
+			}
+		}
+
+		for _, maxDistance := range []float64{math.Inf(1), wantDistance, wantDistance * 1.0001} {
+			if got, want := b.AnyHit(r, maxDistance), wantDistance < maxDistance; got != want {
+				t.Fatalf("AnyHit(%v, %v) = %v, want %v", r, maxDistance, got, want)
+			}
+		}
+	}
+
+	// make sure the test is meaningful
+	if len(triangles) > 0 && (hits == 0 || hits == len(rays)) {
+		t.Errorf("%v of %v rays hit, want some hits and some misses", hits, len(rays))
+	}
+}
+
+func TestBVH(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+
+	tests := []struct {
+		name      string
+		triangles []geom.Triangle3
+	}{
+		{name: "empty"},
+		{name: "single", triangles: []geom.Triangle3{{
+			A: vec.Vec3{X: 0, Y: 0, Z: 50}, B: vec.Vec3{X: 100, Y: 0, Z: 50}, C: vec.Vec3{X: 0, Y: 100, Z: 50},
+		}}},
+		{name: "random", triangles: randomTriangles(r, 2000, 100)},
+		{
+			// identical triangles have coincident centroids, so cannot be split by any plane
+			name: "stacked",
+			triangles: func() []geom.Triangle3 {
+				triangles := randomTriangles(r, 100, 100)
+				for range 50 {
+					triangles = append(triangles, triangles[0])
+				}
+				return triangles
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBVH(tt.triangles)
+			if b.Len() != len(tt.triangles) {
+				t.Errorf("Len() = %v, want %v", b.Len(), len(tt.triangles))
+			}
+
+			checkBVH(t, b, tt.triangles, randomRays(r, 500, 100))
+		})
+	}
+}
+
+func TestBVH_Refit(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	triangles := randomTriangles(r, 1000, 100)
+	b := NewBVH(triangles)
+
+	if err := b.Refit(triangles[1:]); err == nil {
+		t.Errorf("Refit() with too few triangles error = nil, want error")
+	}
+
+	// squash the mesh and shift it, so that every bound changes
+	moved := make([]geom.Triangle3, len(triangles))
+	transform := func(p vec.Vec3) vec.Vec3 {
+		return vec.Vec3{X: p.X*0.5 + 30, Y: p.Y, Z: p.Z*0.8 - 10}
+	}
+	for i, tri := range triangles {
+		moved[i] = geom.Triangle3{A: transform(tri.A), B: transform(tri.B), C: transform(tri.C)}
+	}
+
+	if err := b.Refit(moved); err != nil {
+		t.Fatalf("Refit() error = %v", err)
+	}
+
+	checkBVH(t, b, moved, randomRays(r, 500, 100))
+}
@@ -0,0 +1,44 @@
+package spatial
+
+import (
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// distanceSquared2 returns the square of the distance between a and b.
+func distanceSquared2(a, b vec.Vec2) float64 {
+	d := a.Subtract(b)
+	return d.Dot(d)
+}
+
+// distanceSquared3 returns the square of the distance between a and b.
+func distanceSquared3(a, b vec.Vec3) float64 {
+	d := a.Subtract(b)
+	return d.Dot(d)
+}
+
+// rayClosest returns the parameter of the point on r nearest to p, and the square of the distance between them.
+func rayClosest(r geom.Ray3, p vec.Vec3) (t, distanceSquared float64) {
+	lengthSquared := r.Direction.Dot(r.Direction)
+	if lengthSquared > 0 {
+		t = max(0, p.Subtract(r.Origin).Dot(r.Direction)/lengthSquared)
+	}
+
+	return t, distanceSquared3(r.At(t), p)
+}
+
+// rayEntry returns the parameter at which r enters b, grown by margin on every side. If r starts inside
+// the grown box, the result is 0. If r misses it, this function returns false.
+//
+// Every point of r within margin of a point in b lies in the grown box, so no such point comes before the entry.
+func rayEntry(r geom.Ray3, b geom.AABB3, margin float64) (float64, bool) {
+	grow := vec.Vec3{X: margin, Y: margin, Z: margin}
+	b = geom.AABB3{Min: b.Min.Subtract(grow), Max: b.Max.Add(grow)}
+
+	if b.Contains(r.Origin) {
+		return 0, true
+	}
+
+	hit, ok := r.IntersectAABB(b)
+	return hit.Distance, ok
+}
@@ -0,0 +1,58 @@
+package spatial
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestErrors(t *testing.T) {
+	ignore := func(_ any, err error) error { return err }
+
+	rect := geom.Rect{Min: vec.Vec2{X: 0, Y: 0}, Max: vec.Vec2{X: 10, Y: 10}}
+	box := geom.AABB3{Min: vec.Vec3{X: 0, Y: 0, Z: 0}, Max: vec.Vec3{X: 10, Y: 10, Z: 10}}
+
+	quadtree, err := NewQuadtree[int](rect, 4)
+	if err != nil {
+		t.Fatalf("NewQuadtree() error = %v", err)
+	}
+	octree, err := NewOctree[int](box, 4)
+	if err != nil {
+		t.Fatalf("NewOctree() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		want   error
+		wantOp string
+	}{
+		{"NewQuadtree", ignore(NewQuadtree[int](rect, 0)), vec.ErrInvalidArgument, "NewQuadtree"},
+		{"Quadtree.Insert", quadtree.Insert(vec.Vec2{X: 11, Y: 0}, 1), vec.ErrInvalidArgument, "Quadtree.Insert"},
+		{"Quadtree.Move outside", quadtree.Move(vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: -1, Y: 0}, 1), vec.ErrInvalidArgument, "Quadtree.Move"},
+		{"Quadtree.Move missing", quadtree.Move(vec.Vec2{X: 1, Y: 1}, vec.Vec2{X: 2, Y: 2}, 1), ErrNotFound, "Quadtree.Move"},
+		{"NewOctree", ignore(NewOctree[int](box, -1)), vec.ErrInvalidArgument, "NewOctree"},
+		{"Octree.Insert", octree.Insert(vec.Vec3{X: 0, Y: 0, Z: 11}, 1), vec.ErrInvalidArgument, "Octree.Insert"},
+		{"Octree.Move outside", octree.Move(vec.Vec3{X: 1, Y: 1, Z: 1}, vec.Vec3{X: 0, Y: -1, Z: 0}, 1), vec.ErrInvalidArgument, "Octree.Move"},
+		{"Octree.Move missing", octree.Move(vec.Vec3{X: 1, Y: 1, Z: 1}, vec.Vec3{X: 2, Y: 2, Z: 2}, 1), ErrNotFound, "Octree.Move"},
+		{"BVH.Refit", NewBVH([]geom.Triangle3{{}}).Refit(nil), vec.ErrInvalidArgument, "BVH.Refit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.want)
+			}
+
+			var opErr *vec.OpError
+			if !errors.As(tt.err, &opErr) {
+				t.Fatalf("errors.As(%v) found no *vec.OpError", tt.err)
+			}
+			if opErr.Op != tt.wantOp {
+				t.Errorf("Op = %v, want %v", opErr.Op, tt.wantOp)
+			}
+		})
+	}
+}
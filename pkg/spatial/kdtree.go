@@ -0,0 +1,205 @@
+package spatial
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// kdLeafSize is the most values a k-d tree leaf holds.
+const kdLeafSize = 8
+
+// KDTree is a static spatial index over values positioned in 3D space.
+//
+// The tree is built once from a fixed set of values, splitting each node at the median along the axis
+// in which its values are most spread out. This makes it more compact and usually faster to query than
+// an [Octree], but it cannot be modified after it is built.
+type KDTree[T any] struct {
+	items []Item3[T]
+	nodes []kdNode
+}
+
+type kdNode struct {
+	// bounds is the smallest box around the node's values
+	bounds geom.AABB3
+	// start and end delimit the node's values in the tree's items
+	start, end int
+	// left and right are the indices of the child nodes, or -1 for a leaf
+	left, right int
+}
+
+// NewKDTree builds a k-d tree over items. The tree holds its own copy of items.
+func NewKDTree[T any](items []Item3[T]) *KDTree[T] {
+	t := &KDTree[T]{items: slices.Clone(items)}
+
+	if len(t.items) > 0 {
+		t.build(0, len(t.items))
+	}
+
+	return t
+}
+
+// Len returns the number of values in the tree.
+func (t *KDTree[T]) Len() int {
+	return len(t.items)
+}
+
+// build creates the node holding items[start:end], along with its descendants, and returns its index.
+func (t *KDTree[T]) build(start, end int) int {
+	bounds := geom.AABB3{Min: t.items[start].Position, Max: t.items[start].Position}
+	for _, item := range t.items[start+1 : end] {
+		bounds = bounds.Expand(item.Position)
+	}
+
+	index := len(t.nodes)
+	t.nodes = append(t.nodes, kdNode{bounds: bounds, start: start, end: end, left: -1, right: -1})
+
+	if end-start <= kdLeafSize {
+		return index
+	}
+
+	size := bounds.Size()
+	axis := func(p vec.Vec3) float64 { return p.X }
+	if size.Y > size.X && size.Y >= size.Z {
+		axis = func(p vec.Vec3) float64 { return p.Y }
+	} else if size.Z > size.X && size.Z > size.Y {
+		axis = func(p vec.Vec3) float64 { return p.Z }
+	}
+
+	slices.SortFunc(t.items[start:end], func(a, b Item3[T]) int {
+		return cmp.Compare(axis(a.Position), axis(b.Position))
+	})
+
+	mid := (start + end) / 2
+	left := t.build(start, mid)
+	right := t.build(mid, end)
+	t.nodes[index].left, t.nodes[index].right = left, right
+
+	return index
+}
+
+// QueryAABB returns every value positioned inside or on the boundary of b, in no particular order.
+func (t *KDTree[T]) QueryAABB(b geom.AABB3) []Item3[T] {
+	var found []Item3[T]
+
+	t.query(0, b.Overlaps, b.Contains, &found)
+
+	return found
+}
+
+// QuerySphere returns every value positioned inside or on the boundary of s, in no particular order.
+func (t *KDTree[T]) QuerySphere(s geom.Sphere) []Item3[T] {
+	var found []Item3[T]
+
+	t.query(0, s.OverlapsAABB, s.Contains, &found)
+
+	return found
+}
+
+// query appends to found every value below node that satisfies contains, skipping any node whose
+// bounds fail overlaps.
+func (t *KDTree[T]) query(node int, overlaps func(geom.AABB3) bool, contains func(vec.Vec3) bool, found *[]Item3[T]) {
+	if len(t.nodes) == 0 {
+		return
+	}
+
+	n := t.nodes[node]
+	if !overlaps(n.bounds) {
+		return
+	}
+
+	if n.left >= 0 {
+		t.query(n.left, overlaps, contains, found)
+		t.query(n.right, overlaps, contains, found)
+		return
+	}
+
+	for _, item := range t.items[n.start:n.end] {
+		if contains(item.Position) {
+			*found = append(*found, item)
+		}
+	}
+}
+
+// Nearest returns the k values positioned closest to p, nearest first. Values at the same distance
+// are returned in no particular order.
+//
+// If the tree holds fewer than k values, all of them are returned.
+func (t *KDTree[T]) Nearest(p vec.Vec3, k int) []Item3[T] {
+	var found []Item3[T]
+	if len(t.nodes) == 0 {
+		return found
+	}
+
+	// best-first search, as in [Quadtree.Nearest]. Entries are node indices, or for values, -1 minus
+	// the value's index.
+	var queue minQueue[int]
+	queue.push(0, 0)
+
+	for queue.len() > 0 && len(found) < k {
+		_, e := queue.pop()
+
+		if e < 0 {
+			found = append(found, t.items[-1-e])
+			continue
+		}
+
+		n := t.nodes[e]
+		if n.left >= 0 {
+			for _, child := range []int{n.left, n.right} {
+				queue.push(distanceSquared3(t.nodes[child].bounds.ClosestPoint(p), p), child)
+			}
+			continue
+		}
+
+		for i := n.start; i < n.end; i++ {
+			queue.push(distanceSquared3(t.items[i].Position, p), -1-i)
+		}
+	}
+
+	return found
+}
+
+// Raycast returns every value positioned within distance radius of r, ordered by how far along r
+// the nearest point to each value lies. A radius of 0 finds only values lying exactly on the ray.
+func (t *KDTree[T]) Raycast(r geom.Ray3, radius float64) []Item3[T] {
+	var found []Item3[T]
+	if len(t.nodes) == 0 {
+		return found
+	}
+
+	// best-first search by distance along the ray, with entries encoded as in [KDTree.Nearest]
+	var queue minQueue[int]
+	if entry, ok := rayEntry(r, t.nodes[0].bounds, radius); ok {
+		queue.push(entry, 0)
+	}
+
+	for queue.len() > 0 {
+		_, e := queue.pop()
+
+		if e < 0 {
+			found = append(found, t.items[-1-e])
+			continue
+		}
+
+		n := t.nodes[e]
+		if n.left >= 0 {
+			for _, child := range []int{n.left, n.right} {
+				if entry, ok := rayEntry(r, t.nodes[child].bounds, radius); ok {
+					queue.push(entry, child)
+				}
+			}
+			continue
+		}
+
+		for i := n.start; i < n.end; i++ {
+			if along, d := rayClosest(r, t.items[i].Position); d <= radius*radius {
+				queue.push(along, -1-i)
+			}
+		}
+	}
+
+	return found
+}
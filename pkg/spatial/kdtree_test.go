@@ -0,0 +1,80 @@
+package spatial
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestKDTree_Queries(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []vec.Vec3
+	}{
+		{name: "empty"},
+		{name: "single", points: []vec.Vec3{{X: 50, Y: 50, Z: 50}}},
+		{name: "random", points: randomVec3s(rand.New(rand.NewSource(6)), 3000, 100)},
+		{
+			name: "duplicates",
+			points: append(randomVec3s(rand.New(rand.NewSource(7)), 100, 100),
+				vec.Vec3{X: 1, Y: 2, Z: 3}, vec.Vec3{X: 1, Y: 2, Z: 3}, vec.Vec3{X: 1, Y: 2, Z: 3}),
+		},
+		{
+			name:   "flat",
+			points: []vec.Vec3{{X: 0, Y: 0, Z: 5}, {X: 10, Y: 0, Z: 5}, {X: 0, Y: 10, Z: 5}, {X: 10, Y: 10, Z: 5}, {X: 5, Y: 5, Z: 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]Item3[int], len(tt.points))
+			for i, p := range tt.points {
+				items[i] = Item3[int]{p, i}
+			}
+
+			tree := NewKDTree(items)
+			if tree.Len() != len(items) {
+				t.Errorf("Len() = %v, want %v", tree.Len(), len(items))
+			}
+
+			checkIndex3(t, tree, tt.points)
+		})
+	}
+}
+
+func TestKDTree_Raycast(t *testing.T) {
+	items := []Item3[int]{
+		{vec.Vec3{X: 0, Y: 0, Z: 3}, 0},
+		{vec.Vec3{X: 0, Y: 0, Z: 1}, 1},
+		{vec.Vec3{X: 0, Y: 0.5, Z: 2}, 2},
+		{vec.Vec3{X: 0, Y: 0, Z: -1}, 3},
+		{vec.Vec3{X: 2, Y: 0, Z: 2}, 4},
+	}
+	tree := NewKDTree(items)
+
+	tests := []struct {
+		name   string
+		radius float64
+		want   []int
+	}{
+		{name: "exact", radius: 0, want: []int{1, 0}},
+		{name: "thick", radius: 0.5, want: []int{1, 2, 0}},
+		{name: "behind the origin", radius: 1, want: []int{3, 1, 2, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tree.Raycast(geom.Ray3{Direction: vec.Vec3{X: 0, Y: 0, Z: 1}}, tt.radius)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Raycast() = %v, want values %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Value != tt.want[i] {
+					t.Errorf("Raycast()[%v] = %v, want %v", i, got[i].Value, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package spatial
+
+import (
+	"fmt"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// Item3 is a value stored at a position in 3D space.
+type Item3[T any] struct {
+	Position vec.Vec3
+	Value    T
+}
+
+// Octree is a spatial index over values positioned within a fixed box in 3D space.
+//
+// Each node holds up to a configurable number of values before splitting into eight equal octants.
+// Values are identified by their position together with the value itself, so the same value may be
+// stored at several positions, and several values at the same position.
+//
+// The zero value is not usable; create octrees with [NewOctree].
+type Octree[T comparable] struct {
+	root       *octNode[T]
+	bucketSize int
+	size       int
+}
+
+type octNode[T comparable] struct {
+	bounds geom.AABB3
+	depth  int
+	// items holds the values in a leaf, and is always empty for internal nodes
+	items []Item3[T]
+	// children is nil for leaves, and otherwise holds the octants, indexed by octant
+	children *[8]octNode[T]
+	// count is the number of values in this subtree
+	count int
+}
+
+// NewOctree returns an empty octree covering bounds, in which each node holds up to bucketSize values
+// before it is split.
+//
+// If bucketSize is not positive, this function will return an error wrapping [vec.ErrInvalidArgument].
+func NewOctree[T comparable](bounds geom.AABB3, bucketSize int) (*Octree[T], error) {
+	if bucketSize <= 0 {
+		return nil, vec.NewOpError("NewOctree", fmt.Errorf("%w: bucket size must be positive", vec.ErrInvalidArgument), bounds, bucketSize)
+	}
+
+	return &Octree[T]{
+		root:       &octNode[T]{bounds: bounds},
+		bucketSize: bucketSize,
+	}, nil
+}
+
+// Len returns the number of values in the octree.
+func (o *Octree[T]) Len() int {
+	return o.size
+}
+
+// Bounds returns the box covered by the octree.
+func (o *Octree[T]) Bounds() geom.AABB3 {
+	return o.root.bounds
+}
+
+// Insert adds value at position p.
+//
+// If p lies outside the octree's bounds, this function will return an error wrapping [vec.ErrInvalidArgument].
+func (o *Octree[T]) Insert(p vec.Vec3, value T) error {
+	if !o.root.bounds.Contains(p) {
+		return vec.NewOpError("Octree.Insert", fmt.Errorf("%w: position lies outside the octree's bounds", vec.ErrInvalidArgument), p, value)
+	}
+
+	o.root.insert(Item3[T]{p, value}, o.bucketSize)
+	o.size++
+
+	return nil
+}
+
+// Remove deletes one occurrence of value at position p, returning false if there is none.
+func (o *Octree[T]) Remove(p vec.Vec3, value T) bool {
+	if !o.root.bounds.Contains(p) || !o.root.remove(Item3[T]{p, value}, o.bucketSize) {
+		return false
+	}
+
+	o.size--
+	return true
+}
+
+// Move relocates one occurrence of value from position from to position to.
+//
+// If to lies outside the octree's bounds, this function will return an error wrapping [vec.ErrInvalidArgument].
+// If value is not stored at from, it will return an error wrapping [ErrNotFound]. Either way, the octree is
+// left unchanged.
+func (o *Octree[T]) Move(from, to vec.Vec3, value T) error {
+	if !o.root.bounds.Contains(to) {
+		return vec.NewOpError("Octree.Move", fmt.Errorf("%w: position lies outside the octree's bounds", vec.ErrInvalidArgument), from, to, value)
+	}
+
+	if !o.Remove(from, value) {
+		return vec.NewOpError("Octree.Move", ErrNotFound, from, to, value)
+	}
+
+	return o.Insert(to, value)
+}
+
+// QueryAABB returns every value positioned inside or on the boundary of b, in no particular order.
+func (o *Octree[T]) QueryAABB(b geom.AABB3) []Item3[T] {
+	var found []Item3[T]
+
+	o.root.query(b.Overlaps, b.Contains, &found)
+
+	return found
+}
+
+// QuerySphere returns every value positioned inside or on the boundary of s, in no particular order.
+func (o *Octree[T]) QuerySphere(s geom.Sphere) []Item3[T] {
+	var found []Item3[T]
+
+	o.root.query(s.OverlapsAABB, s.Contains, &found)
+
+	return found
+}
+
+// Nearest returns the k values positioned closest to p, nearest first. Values at the same distance
+// are returned in no particular order.
+//
+// If the octree holds fewer than k values, all of them are returned.
+func (o *Octree[T]) Nearest(p vec.Vec3, k int) []Item3[T] {
+	var found []Item3[T]
+
+	// best-first search, as in [Quadtree.Nearest]
+	type entry struct {
+		node *octNode[T]
+		item Item3[T]
+	}
+	var queue minQueue[entry]
+	queue.push(0, entry{node: o.root})
+
+	for queue.len() > 0 && len(found) < k {
+		_, e := queue.pop()
+
+		switch {
+		case e.node == nil:
+			found = append(found, e.item)
+		case e.node.children != nil:
+			for i := range e.node.children {
+				child := &e.node.children[i]
+				if child.count > 0 {
+					queue.push(distanceSquared3(child.bounds.ClosestPoint(p), p), entry{node: child})
+				}
+			}
+		default:
+			for _, item := range e.node.items {
+				queue.push(distanceSquared3(item.Position, p), entry{item: item})
+			}
+		}
+	}
+
+	return found
+}
+
+// Raycast returns every value positioned within distance radius of r, ordered by how far along r
+// the nearest point to each value lies. A radius of 0 finds only values lying exactly on the ray.
+func (o *Octree[T]) Raycast(r geom.Ray3, radius float64) []Item3[T] {
+	var found []Item3[T]
+
+	// best-first search by distance along the ray, visiting nodes in the order the ray reaches them
+	type entry struct {
+		node *octNode[T]
+		item Item3[T]
+	}
+	var queue minQueue[entry]
+	if t, ok := rayEntry(r, o.root.bounds, radius); ok {
+		queue.push(t, entry{node: o.root})
+	}
+
+	for queue.len() > 0 {
+		_, e := queue.pop()
+
+		switch {
+		case e.node == nil:
+			found = append(found, e.item)
+		case e.node.children != nil:
+			for i := range e.node.children {
+				child := &e.node.children[i]
+				if t, ok := rayEntry(r, child.bounds, radius); ok && child.count > 0 {
+					queue.push(t, entry{node: child})
+				}
+			}
+		default:
+			for _, item := range e.node.items {
+				if t, d := rayClosest(r, item.Position); d <= radius*radius {
+					queue.push(t, entry{item: item})
+				}
+			}
+		}
+	}
+
+	return found
+}
+
+// octant returns the index of the child of n that p belongs in.
+func (n *octNode[T]) octant(p vec.Vec3) int {
+	centre := n.bounds.Centre()
+
+	i := 0
+	if p.X >= centre.X {
+		i |= 1
+	}
+	if p.Y >= centre.Y {
+		i |= 2
+	}
+	if p.Z >= centre.Z {
+		i |= 4
+	}
+
+	return i
+}
+
+func (n *octNode[T]) insert(item Item3[T], bucketSize int) {
+	n.count++
+
+	if n.children != nil {
+		n.children[n.octant(item.Position)].insert(item, bucketSize)
+		return
+	}
+
+	n.items = append(n.items, item)
+	if len(n.items) > bucketSize && n.depth < maxDepth {
+		n.split(bucketSize)
+	}
+}
+
+// split turns the leaf n into an internal node, sharing its values between eight new octants.
+func (n *octNode[T]) split(bucketSize int) {
+	lo, centre, hi := n.bounds.Min, n.bounds.Centre(), n.bounds.Max
+
+	n.children = new([8]octNode[T])
+	for i := range n.children {
+		child := &n.children[i]
+		child.depth = n.depth + 1
+		child.bounds = geom.AABB3{Min: lo, Max: centre}
+
+		if i&1 != 0 {
+			child.bounds.Min.X, child.bounds.Max.X = centre.X, hi.X
+		}
+		if i&2 != 0 {
+			child.bounds.Min.Y, child.bounds.Max.Y = centre.Y, hi.Y
+		}
+		if i&4 != 0 {
+			child.bounds.Min.Z, child.bounds.Max.Z = centre.Z, hi.Z
+		}
+	}
+
+	items := n.items
+	n.items = nil
+	for _, item := range items {
+		n.children[n.octant(item.Position)].insert(item, bucketSize)
+	}
+}
+
+func (n *octNode[T]) remove(item Item3[T], bucketSize int) bool {
+	if n.children != nil {
+		if !n.children[n.octant(item.Position)].remove(item, bucketSize) {
+			return false
+		}
+		n.count--
+
+		// once the octants could fit in a single bucket, merge them back together
+		if n.count <= bucketSize {
+			n.collect(&n.items)
+			n.children = nil
+		}
+		return true
+	}
+
+	for i, stored := range n.items {
+		if stored == item {
+			n.items = append(n.items[:i], n.items[i+1:]...)
+			n.count--
+			return true
+		}
+	}
+
+	return false
+}
+
+// collect appends every value in the subtree rooted at n to items.
+func (n *octNode[T]) collect(items *[]Item3[T]) {
+	if n.children == nil {
+		*items = append(*items, n.items...)
+		return
+	}
+
+	for i := range n.children {
+		n.children[i].collect(items)
+	}
+}
+
+// query appends to found every value in the subtree rooted at n that satisfies contains, skipping
+// any node whose bounds fail overlaps.
+func (n *octNode[T]) query(overlaps func(geom.AABB3) bool, contains func(vec.Vec3) bool, found *[]Item3[T]) {
+	if n.count == 0 || !overlaps(n.bounds) {
+		return
+	}
+
+	if n.children != nil {
+		for i := range n.children {
+			n.children[i].query(overlaps, contains, found)
+		}
+		return
+	}
+
+	for _, item := range n.items {
+		if contains(item.Position) {
+			*found = append(*found, item)
+		}
+	}
+}
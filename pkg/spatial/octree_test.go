@@ -0,0 +1,202 @@
+package spatial
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func randomVec3s(r *rand.Rand, n int, size float64) []vec.Vec3 {
+	points := make([]vec.Vec3, n)
+	for i := range points {
+		points[i] = vec.Vec3{X: r.Float64() * size, Y: r.Float64() * size, Z: r.Float64() * size}
+	}
+	return points
+}
+
+func item3Value(item Item3[int]) int {
+	return item.Value
+}
+
+// index3 is the interface shared by Octree and KDTree, so the two can be checked against the same brute-force results.
+type index3 interface {
+	QueryAABB(b geom.AABB3) []Item3[int]
+	QuerySphere(s geom.Sphere) []Item3[int]
+	Nearest(p vec.Vec3, k int) []Item3[int]
+	Raycast(r geom.Ray3, radius float64) []Item3[int]
+}
+
+// checkIndex3 compares the queries of index, which holds each of points with its index as its value, against brute force.
+func checkIndex3(t *testing.T, index index3, points []vec.Vec3) {
+	t.Helper()
+
+	boxes := []geom.AABB3{
+		{Min: vec.Vec3{X: 20, Y: 30, Z: 40}, Max: vec.Vec3{X: 50, Y: 60, Z: 70}},
+		{Min: vec.Vec3{X: -10, Y: -10, Z: -10}, Max: vec.Vec3{X: 10, Y: 110, Z: 30}},
+		{Min: vec.Vec3{X: 200, Y: 200, Z: 200}, Max: vec.Vec3{X: 300, Y: 300, Z: 300}},
+	}
+	for _, b := range boxes {
+		var want []int
+		for i, p := range points {
+			if b.Contains(p) {
+				want = append(want, i)
+			}
+		}
+		if got := sortedValues(index.QueryAABB(b), item3Value); !slices.Equal(got, want) {
+			t.Errorf("QueryAABB(%v) = %v, want %v", b, got, want)
+		}
+	}
+
+	spheres := []geom.Sphere{
+		{Centre: vec.Vec3{X: 50, Y: 50, Z: 50}, Radius: 20},
+		{Centre: vec.Vec3{X: 0, Y: 100, Z: 0}, Radius: 35},
+		{Centre: vec.Vec3{X: -50, Y: -50, Z: -50}, Radius: 10},
+	}
+	for _, s := range spheres {
+		var want []int
+		for i, p := range points {
+			if s.Contains(p) {
+				want = append(want, i)
+			}
+		}
+		if got := sortedValues(index.QuerySphere(s), item3Value); !slices.Equal(got, want) {
+			t.Errorf("QuerySphere(%v) = %v, want %v", s, got, want)
+		}
+	}
+
+	for _, k := range []int{0, 1, 10, len(points) + 10} {
+		for _, p := range []vec.Vec3{{X: 50, Y: 50, Z: 50}, {X: 150, Y: -20, Z: 10}} {
+			want := make([]int, len(points))
+			for i := range want {
+				want[i] = i
+			}
+			slices.SortFunc(want, func(a, b int) int {
+				return cmp.Compare(distanceSquared3(points[a], p), distanceSquared3(points[b], p))
+			})
+			want = want[:min(k, len(want))]
+
+			got := index.Nearest(p, k)
+			if len(got) != len(want) {
+				t.Fatalf("Nearest(%v, %v) returned %v values, want %v", p, k, len(got), len(want))
+			}
+			// values at the same distance may come in any order, so compare distances
+			for i := range got {
+				if distanceSquared3(got[i].Position, p) != distanceSquared3(points[want[i]], p) {
+					t.Fatalf("Nearest(%v, %v)[%v] = %v, want %v", p, k, i, got[i].Value, want[i])
+				}
+			}
+		}
+	}
+
+	rays := []geom.Ray3{
+		{Origin: vec.Vec3{X: -10, Y: 50, Z: 50}, Direction: vec.Vec3{X: 1, Y: 0.1, Z: -0.2}},
+		{Origin: vec.Vec3{X: 50, Y: 50, Z: 50}, Direction: vec.Vec3{X: 0, Y: 0, Z: 2}},
+		{Origin: vec.Vec3{X: -10, Y: -10, Z: -10}, Direction: vec.Vec3{X: -1, Y: 0, Z: 0}},
+	}
+	for _, r := range rays {
+		const radius = 5
+		var want []int
+		for i, p := range points {
+			if _, d := rayClosest(r, p); d <= radius*radius {
+				want = append(want, i)
+			}
+		}
+
+		got := index.Raycast(r, radius)
+		if values := sortedValues(got, item3Value); !slices.Equal(values, want) {
+			t.Errorf("Raycast(%v) = %v, want %v", r, values, want)
+		}
+		for i := 1; i < len(got); i++ {
+			before, _ := rayClosest(r, got[i-1].Position)
+			after, _ := rayClosest(r, got[i].Position)
+			if before > after {
+				t.Errorf("Raycast(%v) is not ordered along the ray", r)
+				break
+			}
+		}
+	}
+}
+
+func TestNewOctree(t *testing.T) {
+	tests := []struct {
+		name       string
+		bucketSize int
+		wantErr    bool
+	}{
+		{name: "positive", bucketSize: 4},
+		{name: "zero", bucketSize: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewOctree[int](geom.AABB3{Max: vec.Vec3{X: 1, Y: 1, Z: 1}}, tt.bucketSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewOctree() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOctree_Queries(t *testing.T) {
+	points := randomVec3s(rand.New(rand.NewSource(4)), 3000, 100)
+
+	o, _ := NewOctree[int](geom.AABB3{Max: vec.Vec3{X: 100, Y: 100, Z: 100}}, 8)
+	for i, p := range points {
+		if err := o.Insert(p, i); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+	}
+
+	checkIndex3(t, o, points)
+}
+
+func TestOctree_RemoveAndMove(t *testing.T) {
+	o, _ := NewOctree[int](geom.AABB3{Max: vec.Vec3{X: 100, Y: 100, Z: 100}}, 2)
+	points := randomVec3s(rand.New(rand.NewSource(5)), 300, 100)
+	for i, p := range points {
+		o.Insert(p, i)
+	}
+
+	if err := o.Insert(vec.Vec3{X: 101, Y: 0, Z: 0}, -1); err == nil {
+		t.Errorf("Insert() outside the bounds error = nil, want error")
+	}
+	if o.Remove(points[0], 1) {
+		t.Errorf("Remove() of a value at the wrong position = true, want false")
+	}
+
+	// remove every other point, and move the rest to mirrored positions
+	for i := 0; i < len(points); i += 2 {
+		if !o.Remove(points[i], i) {
+			t.Fatalf("Remove(%v, %v) = false, want true", points[i], i)
+		}
+	}
+	var moved []vec.Vec3
+	for i := 1; i < len(points); i += 2 {
+		to := vec.Vec3{X: 100, Y: 100, Z: 100}.Subtract(points[i])
+		if err := o.Move(points[i], to, i); err != nil {
+			t.Fatalf("Move() error = %v", err)
+		}
+		moved = append(moved, to)
+	}
+
+	if err := o.Move(points[0], points[0], 0); err == nil {
+		t.Errorf("Move() of a removed value error = nil, want error")
+	}
+	if o.Len() != len(moved) {
+		t.Errorf("Len() = %v, want %v", o.Len(), len(moved))
+	}
+
+	got := o.QueryAABB(o.Bounds())
+	if len(got) != len(moved) {
+		t.Fatalf("QueryAABB() found %v values, want %v", len(got), len(moved))
+	}
+	for _, item := range got {
+		if item.Value%2 == 0 || !item.Position.Equals(moved[item.Value/2]) {
+			t.Errorf("QueryAABB() found %v, which should not be present", item)
+		}
+	}
+}
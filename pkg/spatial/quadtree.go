@@ -0,0 +1,282 @@
+package spatial
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// maxDepth limits how often a node may be subdivided, so that many values at one position cannot
+// cause unbounded splitting. Nodes at this depth hold any number of values.
+const maxDepth = 32
+
+// ErrNotFound means an operation needed a value stored at a given position, but there was none.
+// Like the sentinel errors of the vec package, it is returned wrapped in a [*vec.OpError].
+var ErrNotFound = errors.New("value not found")
+
+// Item2 is a value stored at a position in 2D space.
+type Item2[T any] struct {
+	Position vec.Vec2
+	Value    T
+}
+
+// Quadtree is a spatial index over values positioned within a fixed rectangle in 2D space.
+//
+// Each node holds up to a configurable number of values before splitting into four equal quadrants.
+// Values are identified by their position together with the value itself, so the same value may be
+// stored at several positions, and several values at the same position.
+//
+// The zero value is not usable; create quadtrees with [NewQuadtree].
+type Quadtree[T comparable] struct {
+	root       *quadNode[T]
+	bucketSize int
+	size       int
+}
+
+type quadNode[T comparable] struct {
+	bounds geom.Rect
+	depth  int
+	// items holds the values in a leaf, and is always empty for internal nodes
+	items []Item2[T]
+	// children is nil for leaves, and otherwise holds the quadrants, indexed by quadrant
+	children *[4]quadNode[T]
+	// count is the number of values in this subtree
+	count int
+}
+
+// NewQuadtree returns an empty quadtree covering bounds, in which each node holds up to bucketSize values
+// before it is split.
+//
+// If bucketSize is not positive, this function will return an error wrapping [vec.ErrInvalidArgument].
+func NewQuadtree[T comparable](bounds geom.Rect, bucketSize int) (*Quadtree[T], error) {
+	if bucketSize <= 0 {
+		return nil, vec.NewOpError("NewQuadtree", fmt.Errorf("%w: bucket size must be positive", vec.ErrInvalidArgument), bounds, bucketSize)
+	}
+
+	return &Quadtree[T]{
+		root:       &quadNode[T]{bounds: bounds},
+		bucketSize: bucketSize,
+	}, nil
+}
+
+// Len returns the number of values in the quadtree.
+func (q *Quadtree[T]) Len() int {
+	return q.size
+}
+
+// Bounds returns the rectangle covered by the quadtree.
+func (q *Quadtree[T]) Bounds() geom.Rect {
+	return q.root.bounds
+}
+
+// Insert adds value at position p.
+//
+// If p lies outside the quadtree's bounds, this function will return an error wrapping [vec.ErrInvalidArgument].
+func (q *Quadtree[T]) Insert(p vec.Vec2, value T) error {
+	if !q.root.bounds.Contains(p) {
+		return vec.NewOpError("Quadtree.Insert", fmt.Errorf("%w: position lies outside the quadtree's bounds", vec.ErrInvalidArgument), p, value)
+	}
+
+	q.root.insert(Item2[T]{p, value}, q.bucketSize)
+	q.size++
+
+	return nil
+}
+
+// Remove deletes one occurrence of value at position p, returning false if there is none.
+func (q *Quadtree[T]) Remove(p vec.Vec2, value T) bool {
+	if !q.root.bounds.Contains(p) || !q.root.remove(Item2[T]{p, value}, q.bucketSize) {
+		return false
+	}
+
+	q.size--
+	return true
+}
+
+// Move relocates one occurrence of value from position from to position to.
+//
+// If to lies outside the quadtree's bounds, this function will return an error wrapping [vec.ErrInvalidArgument].
+// If value is not stored at from, it will return an error wrapping [ErrNotFound]. Either way, the quadtree is
+// left unchanged.
+func (q *Quadtree[T]) Move(from, to vec.Vec2, value T) error {
+	if !q.root.bounds.Contains(to) {
+		return vec.NewOpError("Quadtree.Move", fmt.Errorf("%w: position lies outside the quadtree's bounds", vec.ErrInvalidArgument), from, to, value)
+	}
+
+	if !q.Remove(from, value) {
+		return vec.NewOpError("Quadtree.Move", ErrNotFound, from, to, value)
+	}
+
+	return q.Insert(to, value)
+}
+
+// QueryRect returns every value positioned inside or on the boundary of r, in no particular order.
+func (q *Quadtree[T]) QueryRect(r geom.Rect) []Item2[T] {
+	var found []Item2[T]
+
+	q.root.query(r.Overlaps, r.Contains, &found)
+
+	return found
+}
+
+// QueryCircle returns every value positioned inside or on the boundary of c, in no particular order.
+func (q *Quadtree[T]) QueryCircle(c geom.Circle) []Item2[T] {
+	var found []Item2[T]
+
+	q.root.query(c.OverlapsRect, c.Contains, &found)
+
+	return found
+}
+
+// Nearest returns the k values positioned closest to p, nearest first. Values at the same distance
+// are returned in no particular order.
+//
+// If the quadtree holds fewer than k values, all of them are returned.
+func (q *Quadtree[T]) Nearest(p vec.Vec2, k int) []Item2[T] {
+	var found []Item2[T]
+
+	// best-first search: nodes are queued by the distance to the nearest point of their bounds, which is
+	// never more than the distance to any value inside, so values come off the queue in order
+	type entry struct {
+		node *quadNode[T]
+		item Item2[T]
+	}
+	var queue minQueue[entry]
+	queue.push(0, entry{node: q.root})
+
+	for queue.len() > 0 && len(found) < k {
+		_, e := queue.pop()
+
+		if e.node == nil {
+			found = append(found, e.item)
+			continue
+		}
+
+		if e.node.children != nil {
+			for i := range e.node.children {
+				child := &e.node.children[i]
+				if child.count > 0 {
+					queue.push(distanceSquared2(child.bounds.ClosestPoint(p), p), entry{node: child})
+				}
+			}
+			continue
+		}
+
+		for _, item := range e.node.items {
+			queue.push(distanceSquared2(item.Position, p), entry{item: item})
+		}
+	}
+
+	return found
+}
+
+// quadrant returns the index of the child of n that p belongs in.
+func (n *quadNode[T]) quadrant(p vec.Vec2) int {
+	centre := n.bounds.Centre()
+
+	i := 0
+	if p.X >= centre.X {
+		i |= 1
+	}
+	if p.Y >= centre.Y {
+		i |= 2
+	}
+
+	return i
+}
+
+func (n *quadNode[T]) insert(item Item2[T], bucketSize int) {
+	n.count++
+
+	if n.children != nil {
+		n.children[n.quadrant(item.Position)].insert(item, bucketSize)
+		return
+	}
+
+	n.items = append(n.items, item)
+	if len(n.items) > bucketSize && n.depth < maxDepth {
+		n.split(bucketSize)
+	}
+}
+
+// split turns the leaf n into an internal node, sharing its values between four new quadrants.
+func (n *quadNode[T]) split(bucketSize int) {
+	lo, centre, hi := n.bounds.Min, n.bounds.Centre(), n.bounds.Max
+
+	n.children = &[4]quadNode[T]{
+		{bounds: geom.Rect{Min: lo, Max: centre}},
+		{bounds: geom.Rect{Min: vec.Vec2{X: centre.X, Y: lo.Y}, Max: vec.Vec2{X: hi.X, Y: centre.Y}}},
+		{bounds: geom.Rect{Min: vec.Vec2{X: lo.X, Y: centre.Y}, Max: vec.Vec2{X: centre.X, Y: hi.Y}}},
+		{bounds: geom.Rect{Min: centre, Max: hi}},
+	}
+	for i := range n.children {
+		n.children[i].depth = n.depth + 1
+	}
+
+	items := n.items
+	n.items = nil
+	for _, item := range items {
+		n.children[n.quadrant(item.Position)].insert(item, bucketSize)
+	}
+}
+
+func (n *quadNode[T]) remove(item Item2[T], bucketSize int) bool {
+	if n.children != nil {
+		if !n.children[n.quadrant(item.Position)].remove(item, bucketSize) {
+			return false
+		}
+		n.count--
+
+		// once the quadrants could fit in a single bucket, merge them back together
+		if n.count <= bucketSize {
+			n.collect(&n.items)
+			n.children = nil
+		}
+		return true
+	}
+
+	for i, stored := range n.items {
+		if stored == item {
+			n.items = append(n.items[:i], n.items[i+1:]...)
+			n.count--
+			return true
+		}
+	}
+
+	return false
+}
+
+// collect appends every value in the subtree rooted at n to items.
+func (n *quadNode[T]) collect(items *[]Item2[T]) {
+	if n.children == nil {
+		*items = append(*items, n.items...)
+		return
+	}
+
+	for i := range n.children {
+		n.children[i].collect(items)
+	}
+}
+
+// query appends to found every value in the subtree rooted at n that satisfies contains, skipping
+// any node whose bounds fail overlaps.
+func (n *quadNode[T]) query(overlaps func(geom.Rect) bool, contains func(vec.Vec2) bool, found *[]Item2[T]) {
+	if n.count == 0 || !overlaps(n.bounds) {
+		return
+	}
+
+	if n.children != nil {
+		for i := range n.children {
+			n.children[i].query(overlaps, contains, found)
+		}
+		return
+	}
+
+	for _, item := range n.items {
+		if contains(item.Position) {
+			*found = append(*found, item)
+		}
+	}
+}
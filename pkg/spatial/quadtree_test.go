@@ -0,0 +1,242 @@
+package spatial
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/geom"
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func randomVec2s(r *rand.Rand, n int, size float64) []vec.Vec2 {
+	points := make([]vec.Vec2, n)
+	for i := range points {
+		points[i] = vec.Vec2{X: r.Float64() * size, Y: r.Float64() * size}
+	}
+	return points
+}
+
+// sortedValues returns the values of items in ascending order, for comparing results that come in no particular order.
+func sortedValues[T cmp.Ordered, I any](items []I, value func(I) T) []T {
+	values := make([]T, len(items))
+	for i, item := range items {
+		values[i] = value(item)
+	}
+	slices.Sort(values)
+	return values
+}
+
+func item2Value(item Item2[int]) int {
+	return item.Value
+}
+
+func TestNewQuadtree(t *testing.T) {
+	tests := []struct {
+		name       string
+		bucketSize int
+		wantErr    bool
+	}{
+		{name: "positive", bucketSize: 4},
+		{name: "zero", bucketSize: 0, wantErr: true},
+		{name: "negative", bucketSize: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewQuadtree[int](geom.Rect{Max: vec.Vec2{X: 1, Y: 1}}, tt.bucketSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewQuadtree() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuadtree_Insert(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       vec.Vec2
+		wantErr bool
+	}{
+		{name: "inside", p: vec.Vec2{X: 5, Y: 5}},
+		{name: "on min corner", p: vec.Vec2{X: 0, Y: 0}},
+		{name: "on max corner", p: vec.Vec2{X: 10, Y: 10}},
+		{name: "outside", p: vec.Vec2{X: 11, Y: 5}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := NewQuadtree[int](geom.Rect{Max: vec.Vec2{X: 10, Y: 10}}, 1)
+			err := q.Insert(tt.p, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Insert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			wantLen := 1
+			if tt.wantErr {
+				wantLen = 0
+			}
+			if q.Len() != wantLen {
+				t.Errorf("Len() = %v, want %v", q.Len(), wantLen)
+			}
+		})
+	}
+}
+
+func TestQuadtree_RemoveAndMove(t *testing.T) {
+	q, _ := NewQuadtree[int](geom.Rect{Max: vec.Vec2{X: 100, Y: 100}}, 2)
+	points := randomVec2s(rand.New(rand.NewSource(1)), 200, 100)
+	for i, p := range points {
+		q.Insert(p, i)
+	}
+
+	// many copies of one value at one position must not split forever
+	for range 100 {
+		q.Insert(vec.Vec2{X: 1, Y: 1}, -1)
+	}
+
+	if q.Remove(points[0], 1) {
+		t.Errorf("Remove() of a value at the wrong position = true, want false")
+	}
+	if q.Remove(vec.Vec2{X: -1, Y: 0}, 0) {
+		t.Errorf("Remove() of a position outside the bounds = true, want false")
+	}
+
+	for i := 0; i < len(points); i += 2 {
+		if !q.Remove(points[i], i) {
+			t.Fatalf("Remove(%v, %v) = false, want true", points[i], i)
+		}
+	}
+	for range 100 {
+		if !q.Remove(vec.Vec2{X: 1, Y: 1}, -1) {
+			t.Fatalf("Remove() of a repeated value = false, want true")
+		}
+	}
+
+	if err := q.Move(points[1], vec.Vec2{X: 200, Y: 0}, 1); err == nil {
+		t.Errorf("Move() outside the bounds error = nil, want error")
+	}
+	if err := q.Move(points[0], vec.Vec2{X: 50, Y: 50}, 0); err == nil {
+		t.Errorf("Move() of a removed value error = nil, want error")
+	}
+	if err := q.Move(points[1], vec.Vec2{X: 50, Y: 50}, 1); err != nil {
+		t.Errorf("Move() error = %v", err)
+	}
+
+	if q.Len() != len(points)/2 {
+		t.Errorf("Len() = %v, want %v", q.Len(), len(points)/2)
+	}
+
+	got := q.QueryRect(q.Bounds())
+	if len(got) != len(points)/2 {
+		t.Errorf("QueryRect() found %v values, want %v", len(got), len(points)/2)
+	}
+	for _, item := range got {
+		want := points[item.Value]
+		if item.Value == 1 {
+			want = vec.Vec2{X: 50, Y: 50}
+		}
+		if item.Value%2 == 0 || !item.Position.Equals(want) {
+			t.Errorf("QueryRect() found %v, which should not be present", item)
+		}
+	}
+}
+
+func TestQuadtree_Query(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	points := randomVec2s(r, 2000, 100)
+
+	q, _ := NewQuadtree[int](geom.Rect{Max: vec.Vec2{X: 100, Y: 100}}, 8)
+	for i, p := range points {
+		q.Insert(p, i)
+	}
+
+	tests := []struct {
+		name   string
+		rect   geom.Rect
+		circle geom.Circle
+	}{
+		{
+			name:   "middle",
+			rect:   geom.Rect{Min: vec.Vec2{X: 40, Y: 40}, Max: vec.Vec2{X: 60, Y: 70}},
+			circle: geom.Circle{Centre: vec.Vec2{X: 50, Y: 50}, Radius: 15},
+		},
+		{
+			name:   "overhanging the bounds",
+			rect:   geom.Rect{Min: vec.Vec2{X: -10, Y: 80}, Max: vec.Vec2{X: 20, Y: 120}},
+			circle: geom.Circle{Centre: vec.Vec2{X: 100, Y: 0}, Radius: 30},
+		},
+		{
+			name:   "outside the bounds",
+			rect:   geom.Rect{Min: vec.Vec2{X: 200, Y: 200}, Max: vec.Vec2{X: 300, Y: 300}},
+			circle: geom.Circle{Centre: vec.Vec2{X: -50, Y: -50}, Radius: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var wantRect, wantCircle []int
+			for i, p := range points {
+				if tt.rect.Contains(p) {
+					wantRect = append(wantRect, i)
+				}
+				if tt.circle.Contains(p) {
+					wantCircle = append(wantCircle, i)
+				}
+			}
+
+			if got := sortedValues(q.QueryRect(tt.rect), item2Value); !slices.Equal(got, wantRect) {
+				t.Errorf("QueryRect() = %v, want %v", got, wantRect)
+			}
+			if got := sortedValues(q.QueryCircle(tt.circle), item2Value); !slices.Equal(got, wantCircle) {
+				t.Errorf("QueryCircle() = %v, want %v", got, wantCircle)
+			}
+		})
+	}
+}
+
+func TestQuadtree_Nearest(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	points := randomVec2s(r, 2000, 100)
+
+	q, _ := NewQuadtree[int](geom.Rect{Max: vec.Vec2{X: 100, Y: 100}}, 8)
+	for i, p := range points {
+		q.Insert(p, i)
+	}
+
+	tests := []struct {
+		name string
+		p    vec.Vec2
+		k    int
+	}{
+		{name: "single", p: vec.Vec2{X: 50, Y: 50}, k: 1},
+		{name: "several", p: vec.Vec2{X: 10, Y: 90}, k: 10},
+		{name: "outside the bounds", p: vec.Vec2{X: 150, Y: -20}, k: 5},
+		{name: "none", p: vec.Vec2{X: 50, Y: 50}, k: 0},
+		{name: "more than stored", p: vec.Vec2{X: 50, Y: 50}, k: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := make([]int, len(points))
+			for i := range want {
+				want[i] = i
+			}
+			slices.SortFunc(want, func(a, b int) int {
+				return cmp.Compare(points[a].Subtract(tt.p).Magnitude(), points[b].Subtract(tt.p).Magnitude())
+			})
+			want = want[:min(tt.k, len(want))]
+
+			got := q.Nearest(tt.p, tt.k)
+			if len(got) != len(want) {
+				t.Fatalf("Nearest() returned %v values, want %v", len(got), len(want))
+			}
+			for i := range got {
+				if got[i].Value != want[i] {
+					t.Fatalf("Nearest()[%v] = %v, want %v", i, got[i].Value, want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package spatial
+
+// minQueue is a priority queue that always yields its lowest priority value first.
+type minQueue[E any] struct {
+	entries []queued[E]
+}
+
+type queued[E any] struct {
+	priority float64
+	value    E
+}
+
+func (q *minQueue[E]) len() int {
+	return len(q.entries)
+}
+
+// push adds value to the queue with the given priority.
+func (q *minQueue[E]) push(priority float64, value E) {
+	q.entries = append(q.entries, queued[E]{priority, value})
+
+	// sift the new entry up the binary heap until its parent is no larger
+	i := len(q.entries) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if q.entries[parent].priority <= q.entries[i].priority {
+			break
+		}
+		q.entries[parent], q.entries[i] = q.entries[i], q.entries[parent]
+		i = parent
+	}
+}
+
+// pop removes and returns the value with the lowest priority, along with that priority.
+// The queue must not be empty.
+func (q *minQueue[E]) pop() (float64, E) {
+	top := q.entries[0]
+	last := len(q.entries) - 1
+	q.entries[0] = q.entries[last]
+	q.entries = q.entries[:last]
+
+	// sift the moved entry down until neither child is smaller
+	i := 0
+	for {
+		smallest := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(q.entries) && q.entries[child].priority < q.entries[smallest].priority {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			break
+		}
+		q.entries[smallest], q.entries[i] = q.entries[i], q.entries[smallest]
+		i = smallest
+	}
+
+	return top.priority, top.value
+}
@@ -0,0 +1,49 @@
+package vec
+
+import "math"
+
+// NormaliseAngle returns the angle equivalent to a, in radians, in the range [0, 2pi).
+func NormaliseAngle(a float64) float64 {
+	a = math.Mod(a, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+
+	// adding 2pi to a tiny negative angle can round up to exactly 2pi
+	if a >= 2*math.Pi {
+		return 0
+	}
+
+	return a
+}
+
+// NormaliseAngleSigned returns the angle equivalent to a, in radians, in the range (-pi, pi].
+func NormaliseAngleSigned(a float64) float64 {
+	a = NormaliseAngle(a)
+	if a > math.Pi {
+		a -= 2 * math.Pi
+	}
+
+	return a
+}
+
+// AngleDifference returns the smallest rotation, in radians, that turns the angle from into the angle to.
+// Positive results are anticlockwise.
+//
+// The result is in the range (-pi, pi], so angles exactly opposite each other are pi apart, not -pi.
+func AngleDifference(from, to float64) float64 {
+	return NormaliseAngleSigned(to - from)
+}
+
+// LerpAngle linearly interpolates between the angles a1 and a2 by factor t, taking the short way round.
+// For example, interpolating halfway from 350 degrees to 10 degrees gives 0 degrees, not 180 degrees.
+//
+// At t = 0, the result of this function is equivalent to a1.
+//
+// At t = 1, the result of this function is equivalent to a2.
+//
+// The result is normalised to the range (-pi, pi]. As with [Vec2.Lerp], values of t outside [0, 1]
+// extrapolate beyond a1 or a2.
+func LerpAngle(a1, a2, t float64) float64 {
+	return NormaliseAngleSigned(a1 + AngleDifference(a1, a2)*t)
+}
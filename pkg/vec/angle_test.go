@@ -0,0 +1,103 @@
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormaliseAngle(t *testing.T) {
+	tests := []struct {
+		name string
+		a    float64
+		want float64
+	}{
+		{"zero", 0, 0},
+		{"in range", 1, 1},
+		{"full turn", 2 * math.Pi, 0},
+		{"more than a turn", 2*math.Pi + 1, 1},
+		{"negative", -math.Pi / 2, 3 * math.Pi / 2},
+		{"several negative turns", -6*math.Pi - 1, 2*math.Pi - 1},
+		{"tiny negative", -1e-18, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormaliseAngle(tt.a); math.Abs(got-tt.want) > 1e-12 || got < 0 || got >= 2*math.Pi {
+				t.Errorf("NormaliseAngle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormaliseAngleSigned(t *testing.T) {
+	tests := []struct {
+		name string
+		a    float64
+		want float64
+	}{
+		{"zero", 0, 0},
+		{"pi", math.Pi, math.Pi},
+		{"minus pi", -math.Pi, math.Pi},
+		{"three quarters of a turn", 3 * math.Pi / 2, -math.Pi / 2},
+		{"negative", -1, -1},
+		{"several turns", 8*math.Pi + 0.5, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormaliseAngleSigned(tt.a); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("NormaliseAngleSigned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAngleDifference(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to float64
+		want     float64
+	}{
+		{"same", 1, 1, 0},
+		{"anticlockwise", 0, 1, 1},
+		{"clockwise", 1, 0, -1},
+		{"across zero", 2*math.Pi - 0.1, 0.1, 0.2},
+		{"across pi", math.Pi - 0.1, -math.Pi + 0.1, 0.2},
+		{"opposite", 0, math.Pi, math.Pi},
+		{"opposite the other way", math.Pi, 0, math.Pi},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AngleDifference(tt.from, tt.to); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("AngleDifference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLerpAngle(t *testing.T) {
+	deg := math.Pi / 180
+
+	tests := []struct {
+		name   string
+		a1, a2 float64
+		t      float64
+		want   float64
+	}{
+		{"start", 10 * deg, 50 * deg, 0, 10 * deg},
+		{"end", 10 * deg, 50 * deg, 1, 50 * deg},
+		{"middle", 10 * deg, 50 * deg, 0.5, 30 * deg},
+		{"short way across zero", 350 * deg, 10 * deg, 0.5, 0},
+		{"short way across pi", 170 * deg, -170 * deg, 0.25, 175 * deg},
+		{"extrapolate", 0, 10 * deg, 2, 20 * deg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LerpAngle(tt.a1, tt.a2, tt.t); math.Abs(AngleDifference(got, tt.want)) > 1e-12 {
+				t.Errorf("LerpAngle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+)
+
+// The camera functions in this file follow the OpenGL conventions: a right-handed world space, a camera
+// looking down its local -Z axis, and normalised device coordinates spanning [-1, 1] on every axis.
+
+// LookAt returns the view matrix for a camera positioned at eye, looking towards target.
+// up gives the rough upwards direction of the camera, and does not need to be perpendicular to the view direction.
+//
+// If eye and target are the same point, or up is parallel to the view direction, the camera orientation
+// is undefined and this function will return an error wrapping [ErrZeroLength] or [ErrParallel] respectively.
+func LookAt(eye, target, up Vec3) (Mat4, error) {
+	forward, err := target.Subtract(eye).Normalised()
+	if err != nil {
+		return Mat4{}, NewOpError("LookAt", fmt.Errorf("%w: eye and target are the same point", ErrZeroLength), eye, target, up)
+	}
+
+	right, err := forward.Cross(up).Normalised()
+	if err != nil {
+		return Mat4{}, NewOpError("LookAt", fmt.Errorf("%w: up is parallel to the view direction", ErrParallel), eye, target, up)
+	}
+
+	trueUp := right.Cross(forward)
+
+	return Mat4{
+		{right.X, right.Y, right.Z, -right.Dot(eye)},
+		{trueUp.X, trueUp.Y, trueUp.Z, -trueUp.Dot(eye)},
+		{-forward.X, -forward.Y, -forward.Z, forward.Dot(eye)},
+		{0, 0, 0, 1},
+	}, nil
+}
+
+// Perspective returns a perspective projection matrix.
+//
+// fovY is the vertical field of view in radians, and aspect is the ratio of the viewport width to its height.
+// near and far are the distances from the camera to the near and far clipping planes.
+//
+// This function will return an error wrapping [ErrInvalidArgument] unless 0 < fovY < pi, aspect > 0 and 0 < near < far.
+func Perspective(fovY, aspect, near, far float64) (Mat4, error) {
+	if fovY <= 0 || fovY >= math.Pi {
+		return Mat4{}, NewOpError("Perspective", fmt.Errorf("%w: field of view must be between 0 and pi", ErrInvalidArgument), fovY, aspect, near, far)
+	}
+
+	if aspect <= 0 {
+		return Mat4{}, NewOpError("Perspective", fmt.Errorf("%w: aspect ratio must be positive", ErrInvalidArgument), fovY, aspect, near, far)
+	}
+
+	if near <= 0 || far <= near {
+		return Mat4{}, NewOpError("Perspective", fmt.Errorf("%w: clipping planes must satisfy 0 < near < far", ErrInvalidArgument), fovY, aspect, near, far)
+	}
+
+	f := 1 / math.Tan(fovY/2)
+
+	return Mat4{
+		{f / aspect, 0, 0, 0},
+		{0, f, 0, 0},
+		{0, 0, (far + near) / (near - far), 2 * far * near / (near - far)},
+		{0, 0, -1, 0},
+	}, nil
+}
+
+// Orthographic returns an orthographic projection matrix, mapping the box bounded by the given
+// clipping planes onto normalised device coordinates.
+//
+// near and far are distances along the view direction, so may be negative to include points behind the camera.
+//
+// If any pair of opposing clipping planes coincide, this function will return an error wrapping [ErrInvalidArgument].
+func Orthographic(left, right, bottom, top, near, far float64) (Mat4, error) {
+	if left == right || bottom == top || near == far {
+		return Mat4{}, NewOpError("Orthographic", fmt.Errorf("%w: opposing clipping planes must not coincide", ErrInvalidArgument), left, right, bottom, top, near, far)
+	}
+
+	return Mat4{
+		{2 / (right - left), 0, 0, -(right + left) / (right - left)},
+		{0, 2 / (top - bottom), 0, -(top + bottom) / (top - bottom)},
+		{0, 0, -2 / (far - near), -(far + near) / (far - near)},
+		{0, 0, 0, 1},
+	}, nil
+}
+
+// Project maps the world-space point p to normalised screen space, using the combined
+// view-projection matrix viewProjection (typically projection.Mul(view)).
+//
+// The returned screen position spans [0, 1] on each axis across the viewport, with (0, 0) at the
+// bottom left. The returned depth spans [0, 1] between the near and far clipping planes.
+// Points outside the view volume produce values outside these ranges.
+//
+// If p lies on the plane through the camera perpendicular to the view direction, it has no
+// projection and this function will return an error wrapping [ErrDivideByZero].
+func Project(p Vec3, viewProjection Mat4) (screen Vec2, depth float64, err error) {
+	ndc, err := viewProjection.MulVec4(p.ToHomogeneous(1)).PerspectiveDivide()
+	if err != nil {
+		return Vec2{}, 0, NewOpError("Project", fmt.Errorf("%w: point lies on the camera plane", ErrDivideByZero), p, viewProjection)
+	}
+
+	return Vec2{
+		(ndc.X + 1) / 2,
+		(ndc.Y + 1) / 2,
+	}, (ndc.Z + 1) / 2, nil
+}
+
+// Unproject maps a position in normalised screen space at the given depth back to world space.
+//
+// This is the inverse operation of [Project], and uses the same conventions.
+//
+// If viewProjection is singular, this function will return an error wrapping [ErrSingular].
+// If the position maps to a point at infinity, this function will return an error wrapping [ErrDivideByZero].
+func Unproject(screen Vec2, depth float64, viewProjection Mat4) (Vec3, error) {
+	inverse, err := viewProjection.Inverse()
+	if err != nil {
+		return Vec3{}, NewOpError("Unproject", ErrSingular, screen, depth, viewProjection)
+	}
+
+	ndc := Vec3{
+		2*screen.X - 1,
+		2*screen.Y - 1,
+		2*depth - 1,
+	}
+
+	p, err := inverse.MulVec4(ndc.ToHomogeneous(1)).PerspectiveDivide()
+	if err != nil {
+		return Vec3{}, NewOpError("Unproject", fmt.Errorf("%w: position maps to a point at infinity", ErrDivideByZero), screen, depth, viewProjection)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,199 @@
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLookAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		eye     Vec3
+		target  Vec3
+		up      Vec3
+		p       Vec3
+		want    Vec3
+		wantErr bool
+	}{
+		{
+			name:    "eye = target = error",
+			eye:     Vec3{1, 1, 1},
+			target:  Vec3{1, 1, 1},
+			up:      Vec3{0, 1, 0},
+			wantErr: true,
+		},
+		{
+			name:    "up parallel to view direction = error",
+			eye:     Vec3{0, 0, 0},
+			target:  Vec3{0, 5, 0},
+			up:      Vec3{0, 1, 0},
+			wantErr: true,
+		},
+		{
+			name:   "target maps onto -z",
+			eye:    Vec3{0, 0, 5},
+			target: Vec3{0, 0, 0},
+			up:     Vec3{0, 1, 0},
+			p:      Vec3{0, 0, 0},
+			want:   Vec3{0, 0, -5},
+		},
+		{
+			name:   "looking along +x, +z is to the right",
+			eye:    Vec3{0, 0, 0},
+			target: Vec3{1, 0, 0},
+			up:     Vec3{0, 1, 0},
+			p:      Vec3{2, 0, 3},
+			want:   Vec3{3, 0, -2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := LookAt(tt.eye, tt.target, tt.up)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LookAt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := view.MulPoint(tt.p); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("LookAt().MulPoint(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerspective(t *testing.T) {
+	tests := []struct {
+		name    string
+		fovY    float64
+		aspect  float64
+		near    float64
+		far     float64
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			fovY:    math.Pi / 2,
+			aspect:  16.0 / 9,
+			near:    0.1,
+			far:     100,
+			wantErr: false,
+		},
+		{
+			name:    "fov = 0",
+			fovY:    0,
+			aspect:  1,
+			near:    0.1,
+			far:     100,
+			wantErr: true,
+		},
+		{
+			name:    "aspect = 0",
+			fovY:    1,
+			aspect:  0,
+			near:    0.1,
+			far:     100,
+			wantErr: true,
+		},
+		{
+			name:    "near = 0",
+			fovY:    1,
+			aspect:  1,
+			near:    0,
+			far:     100,
+			wantErr: true,
+		},
+		{
+			name:    "far < near",
+			fovY:    1,
+			aspect:  1,
+			near:    10,
+			far:     1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Perspective(tt.fovY, tt.aspect, tt.near, tt.far)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Perspective() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	view, err := LookAt(Vec3{0, 0, 5}, Vec3{0, 0, 0}, Vec3{0, 1, 0})
+	if err != nil {
+		t.Fatalf("LookAt() error = %v", err)
+	}
+	perspective, err := Perspective(math.Pi/2, 1, 1, 9)
+	if err != nil {
+		t.Fatalf("Perspective() error = %v", err)
+	}
+	orthographic, err := Orthographic(-2, 2, -1, 1, 1, 9)
+	if err != nil {
+		t.Fatalf("Orthographic() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		m          Mat4
+		p          Vec3
+		wantScreen Vec2
+		wantDepth  float64
+	}{
+		{
+			name:       "perspective, centre of near plane",
+			m:          perspective.Mul(view),
+			p:          Vec3{0, 0, 4},
+			wantScreen: Vec2{0.5, 0.5},
+			wantDepth:  0,
+		},
+		{
+			name:       "perspective, corner of far plane",
+			m:          perspective.Mul(view),
+			p:          Vec3{9, 9, -4},
+			wantScreen: Vec2{1, 1},
+			wantDepth:  1,
+		},
+		{
+			name:       "orthographic, bottom left of far plane",
+			m:          orthographic.Mul(view),
+			p:          Vec3{-2, -1, -4},
+			wantScreen: Vec2{0, 0},
+			wantDepth:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen, depth, err := Project(tt.p, tt.m)
+			if err != nil {
+				t.Fatalf("Project() error = %v", err)
+			}
+			if !screen.AlmostEquals(tt.wantScreen, 1e-12) || math.Abs(depth-tt.wantDepth) > 1e-12 {
+				t.Errorf("Project() = %v, %v, want %v, %v", screen, depth, tt.wantScreen, tt.wantDepth)
+			}
+
+			p, err := Unproject(screen, depth, tt.m)
+			if err != nil {
+				t.Fatalf("Unproject() error = %v", err)
+			}
+			if !p.AlmostEquals(tt.p, 1e-9) {
+				t.Errorf("Unproject(Project(p)) = %v, want %v", p, tt.p)
+			}
+		})
+	}
+}
+
+func TestProject_CameraPlane(t *testing.T) {
+	perspective, err := Perspective(math.Pi/2, 1, 1, 9)
+	if err != nil {
+		t.Fatalf("Perspective() error = %v", err)
+	}
+
+	if _, _, err := Project(Vec3{1, 1, 0}, perspective); err == nil {
+		t.Errorf("Project() error = nil, want error")
+	}
+}
@@ -0,0 +1,159 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+)
+
+type comparisonMode int
+
+const (
+	absoluteMode comparisonMode = iota
+	relativeMode
+	ulpMode
+	euclideanMode
+	combinedMode
+)
+
+// Comparator decides whether two values are close enough to be treated as equal, in one of several ways.
+// Pass one to methods such as [Vec2.EqualsWith], or call its methods directly in tests.
+//
+// A Comparator must be created with one of [AbsoluteTolerance], [RelativeTolerance], [ULPTolerance],
+// [EuclideanTolerance] or [CombinedTolerance]. The zero Comparator only accepts values that are exactly equal.
+//
+// Values that are exactly equal are always accepted, while NaN is never accepted and an infinity is only
+// accepted as equal to itself. Negative tolerances behave as 0.
+type Comparator struct {
+	mode     comparisonMode
+	abs, rel float64
+	ulps     uint64
+}
+
+// AbsoluteTolerance returns a Comparator accepting values whose components each differ by at most tolerance.
+// This matches AlmostEquals, and suits values of a known, limited scale.
+func AbsoluteTolerance(tolerance float64) Comparator {
+	return Comparator{mode: absoluteMode, abs: tolerance}
+}
+
+// RelativeTolerance returns a Comparator accepting values whose components each differ by at most tolerance
+// times the larger of their magnitudes. For example, a tolerance of 1e-9 accepts differences in roughly the
+// ninth significant figure, whatever the scale of the values.
+//
+// Since no difference is small relative to 0, only an exact 0 is accepted as equal to 0. Use
+// [CombinedTolerance] for values that may be close to 0.
+func RelativeTolerance(tolerance float64) Comparator {
+	return Comparator{mode: relativeMode, rel: tolerance}
+}
+
+// ULPTolerance returns a Comparator accepting values whose components are each at most ulps units in the
+// last place apart: that is, with at most ulps - 1 representable float64 values between them.
+//
+// This is the natural measure of rounding error, and behaves like a relative tolerance of about
+// ulps * 2.2e-16. 0 and -0 are 0 ULPs apart, but the smallest positive and negative values are not close.
+func ULPTolerance(ulps uint64) Comparator {
+	return Comparator{mode: ulpMode, ulps: ulps}
+}
+
+// EuclideanTolerance returns a Comparator accepting values that are at most tolerance apart, measuring
+// the distance between them as vectors rather than component by component. For matrices, this is the
+// Frobenius norm of their difference.
+func EuclideanTolerance(tolerance float64) Comparator {
+	return Comparator{mode: euclideanMode, abs: tolerance}
+}
+
+// CombinedTolerance returns a Comparator accepting values whose components each differ by at most abs,
+// or by at most rel times the larger of their magnitudes. The absolute tolerance handles values near 0,
+// and the relative tolerance handles large values.
+func CombinedTolerance(abs, rel float64) Comparator {
+	return Comparator{mode: combinedMode, abs: abs, rel: rel}
+}
+
+// Equal returns true if a and b are close enough to be treated as equal.
+func (c Comparator) Equal(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+
+	diff := math.Abs(a - b)
+	scale := math.Max(math.Abs(a), math.Abs(b))
+
+	switch c.mode {
+	case relativeMode:
+		return diff <= c.rel*scale
+	case ulpMode:
+		return ulpDistance(a, b) <= c.ulps
+	case combinedMode:
+		return diff <= c.abs || diff <= c.rel*scale
+	default:
+		return diff <= c.abs
+	}
+}
+
+// EqualComponents returns true if a and b have the same length and are close enough to be treated as
+// equal. They are compared component by component, or as vectors if c uses [EuclideanTolerance].
+func (c Comparator) EqualComponents(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	if c.mode == euclideanMode {
+		var sum float64
+		for i := range a {
+			if a[i] == b[i] {
+				continue
+			}
+			d := a[i] - b[i]
+			sum += d * d
+		}
+		// NaN fails this comparison
+		return math.Sqrt(sum) <= c.abs
+	}
+
+	for i := range a {
+		if !c.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String describes the comparison c makes, such as "within 4 ULPs".
+func (c Comparator) String() string {
+	switch c.mode {
+	case relativeMode:
+		return fmt.Sprintf("within a relative tolerance of %v", c.rel)
+	case ulpMode:
+		return fmt.Sprintf("within %v ULPs", c.ulps)
+	case euclideanMode:
+		return fmt.Sprintf("within a distance of %v", c.abs)
+	case combinedMode:
+		return fmt.Sprintf("within an absolute tolerance of %v or a relative tolerance of %v", c.abs, c.rel)
+	default:
+		return fmt.Sprintf("within an absolute tolerance of %v", c.abs)
+	}
+}
+
+// ulpDistance returns the number of steps from one representable float64 to the next needed to get from a to b.
+func ulpDistance(a, b float64) uint64 {
+	// reinterpreting the bits of a float gives an integer that increases with the float for positive
+	// values and decreases for negative ones, so flip the negative half to get one ordered line
+	ordered := func(f float64) int64 {
+		bits := int64(math.Float64bits(f))
+		if bits < 0 {
+			return math.MinInt64 - bits
+		}
+		return bits
+	}
+
+	x, y := ordered(a), ordered(b)
+	if x > y {
+		x, y = y, x
+	}
+
+	// the difference may not fit in an int64, but does in a uint64
+	return uint64(y) - uint64(x)
+}
@@ -0,0 +1,144 @@
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComparator_Equal(t *testing.T) {
+	next := func(f float64, steps int) float64 {
+		for range steps {
+			f = math.Nextafter(f, math.Inf(1))
+		}
+		return f
+	}
+
+	tests := []struct {
+		name string
+		c    Comparator
+		a, b float64
+		want bool
+	}{
+		{"zero comparator, equal", Comparator{}, 1.5, 1.5, true},
+		{"zero comparator, different", Comparator{}, 1.5, next(1.5, 1), false},
+		{"absolute, within", AbsoluteTolerance(0.1), 1, 1.05, true},
+		{"absolute, outside", AbsoluteTolerance(0.1), 1, 1.2, false},
+		{"absolute, useless for large values", AbsoluteTolerance(1e-9), 1e9, next(1e9, 1), false},
+		{"relative, large values", RelativeTolerance(1e-9), 1e9, 1e9 + 0.5, true},
+		{"relative, small values", RelativeTolerance(1e-9), 1e-9, 1.0000000005e-9, true},
+		{"relative, outside", RelativeTolerance(1e-9), 1e-9, 1.1e-9, false},
+		{"relative, only 0 equals 0", RelativeTolerance(0.5), 0, 1e-300, false},
+		{"relative, negative", RelativeTolerance(1e-6), -1e6, -1e6 - 0.5, true},
+		{"ULP, within", ULPTolerance(4), 1, next(1, 4), true},
+		{"ULP, outside", ULPTolerance(4), 1, next(1, 5), false},
+		{"ULP, across 0", ULPTolerance(2), -math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64, true},
+		{"ULP, signed zeros", ULPTolerance(0), 0, math.Copysign(0, -1), true},
+		{"ULP, opposite signs", ULPTolerance(math.MaxUint64 - 1), -math.MaxFloat64, math.MaxFloat64, true},
+		{"ULP, huge values", ULPTolerance(1), 1e300, next(1e300, 1), true},
+		{"Euclidean, within", EuclideanTolerance(0.1), 1, 1.05, true},
+		{"Euclidean, outside", EuclideanTolerance(0.1), 1, 0.8, false},
+		{"combined, absolute near 0", CombinedTolerance(1e-12, 1e-9), 0, 1e-13, true},
+		{"combined, relative when large", CombinedTolerance(1e-12, 1e-9), 1e9, 1e9 + 0.5, true},
+		{"combined, outside both", CombinedTolerance(1e-12, 1e-9), 1, 1.001, false},
+		{"infinities", RelativeTolerance(1e-9), math.Inf(1), math.Inf(1), true},
+		{"opposite infinities", RelativeTolerance(1), math.Inf(1), math.Inf(-1), false},
+		{"NaN", AbsoluteTolerance(math.Inf(1)), math.NaN(), math.NaN(), false},
+		{"negative tolerance", AbsoluteTolerance(-1), 1, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Equal(tt.a, tt.b); got != tt.want {
+				t.Errorf("Equal(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.c.Equal(tt.b, tt.a); got != tt.want {
+				t.Errorf("Equal(%v, %v) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparator_EqualComponents(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Comparator
+		a, b []float64
+		want bool
+	}{
+		{"empty", AbsoluteTolerance(0), nil, []float64{}, true},
+		{"different lengths", AbsoluteTolerance(1), []float64{1, 2}, []float64{1, 2, 3}, false},
+		{"every component within", AbsoluteTolerance(0.1), []float64{1, 2, 3}, []float64{1.05, 1.95, 3}, true},
+		{"one component outside", AbsoluteTolerance(0.1), []float64{1, 2, 3}, []float64{1.05, 2.2, 3}, false},
+		// each component is within 0.1, but the distance between the vectors is 0.17
+		{"Euclidean, components close but vectors apart", EuclideanTolerance(0.1), []float64{0, 0, 0}, []float64{0.1, 0.1, 0.1}, false},
+		{"Euclidean, within", EuclideanTolerance(0.2), []float64{0, 0, 0}, []float64{0.1, 0.1, 0.1}, true},
+		{"Euclidean, NaN", EuclideanTolerance(1), []float64{0, math.NaN()}, []float64{0, 0}, false},
+		{"Euclidean, matching infinities", EuclideanTolerance(0), []float64{math.Inf(1), 1}, []float64{math.Inf(1), 1}, true},
+		{"relative per component", RelativeTolerance(1e-9), []float64{1e-9, 1e9}, []float64{1.0000000001e-9, 1e9 + 0.1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.EqualComponents(tt.a, tt.b); got != tt.want {
+				t.Errorf("EqualComponents(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparator_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Comparator
+		want string
+	}{
+		{"zero", Comparator{}, "within an absolute tolerance of 0"},
+		{"absolute", AbsoluteTolerance(0.5), "within an absolute tolerance of 0.5"},
+		{"relative", RelativeTolerance(1e-9), "within a relative tolerance of 1e-09"},
+		{"ULP", ULPTolerance(4), "within 4 ULPs"},
+		{"Euclidean", EuclideanTolerance(2), "within a distance of 2"},
+		{"combined", CombinedTolerance(1e-12, 1e-6), "within an absolute tolerance of 1e-12 or a relative tolerance of 1e-06"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualsWith(t *testing.T) {
+	relative := RelativeTolerance(1e-9)
+	euclidean := EuclideanTolerance(0.1)
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"Vec2 across magnitudes", Vec2{1e-9, 1e9}.EqualsWith(Vec2{1.0000000001e-9, 1e9 + 0.1}, relative), true},
+		{"Vec2 outside", Vec2{1e-9, 1e9}.EqualsWith(Vec2{1.1e-9, 1e9}, relative), false},
+		{"Vec3 Euclidean within", Vec3{0, 0, 0}.EqualsWith(Vec3{0.05, 0.05, 0.05}, euclidean), true},
+		{"Vec3 Euclidean outside", Vec3{0, 0, 0}.EqualsWith(Vec3{0.1, 0.1, 0}, euclidean), false},
+		{"Vec4 within", Vec4{1, 2, 3, 4}.EqualsWith(Vec4{1, 2, 3, 4.05}, euclidean), true},
+		{"Vec4 W outside", Vec4{1, 2, 3, 4}.EqualsWith(Vec4{1, 2, 3, 4.5}, euclidean), false},
+		{"Quat within", Quat{1, 0, 0, 0}.EqualsWith(Quat{1, 0, 0, 1e-3}, euclidean), true},
+		{"Quat negated", Quat{1, 0, 0, 0}.EqualsWith(Quat{-1, 0, 0, 0}, euclidean), false},
+		{"Mat2 within", Identity2().EqualsWith(Mat2{{1, 0.05}, {0, 1}}, euclidean), true},
+		{"Mat2 outside", Identity2().EqualsWith(Mat2{{1, 0.1}, {0.1, 1}}, euclidean), false},
+		{"Mat3 within", Identity3().EqualsWith(Identity3().Multiply(1+1e-12), relative), true},
+		{"Mat3 outside", Identity3().EqualsWith(Identity3().Multiply(2), relative), false},
+		{"Mat4 within", Identity4().EqualsWith(Identity4(), ULPTolerance(0)), true},
+		{"Mat4 outside", Identity4().EqualsWith(Mat4{}, ULPTolerance(1000)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("EqualsWith() = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package vec
+
+import "math"
+
+// Cylindrical represents a point in 3D space by its distance from the Z axis, the angle in radians
+// anticlockwise from the positive X axis when looking down the Z axis towards the origin, and its height
+// along the Z axis.
+//
+// [Vec3.ToCylindrical] and [Cylindrical.Normalised] always give the canonical representation, with a
+// non-negative radius and an azimuth in the range (-pi, pi]. Points on the Z axis are represented with
+// an azimuth of 0.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Cylindrical being operated upon.
+type Cylindrical struct {
+	Radius, Azimuth, Z float64
+}
+
+// ToVec3 returns the point in Cartesian coordinates.
+func (c Cylindrical) ToVec3() Vec3 {
+	sin, cos := math.Sincos(c.Azimuth)
+
+	return Vec3{
+		c.Radius * cos,
+		c.Radius * sin,
+		c.Z,
+	}
+}
+
+// Normalised returns the canonical representation of the same point, with a non-negative radius and an
+// azimuth in the range (-pi, pi]. If the radius is 0, the azimuth is 0.
+func (c Cylindrical) Normalised() Cylindrical {
+	if c.Radius == 0 {
+		return Cylindrical{0, 0, c.Z}
+	}
+
+	if c.Radius < 0 {
+		c = Cylindrical{-c.Radius, c.Azimuth + math.Pi, c.Z}
+	}
+
+	return Cylindrical{c.Radius, NormaliseAngleSigned(c.Azimuth), c.Z}
+}
+
+// Lerp interpolates between c1 and c2 by factor t, linearly in radius, azimuth and height. The azimuth
+// turns the short way round, so the result sweeps through at most half a turn around the Z axis.
+//
+// At t = 0, the result of this function is equivalent to c1.
+//
+// At t = 1, the result of this function is equivalent to c2.
+//
+// The result is normalised. Points on the Z axis have no meaningful azimuth, so if either point lies on
+// it, the azimuth of the other is used throughout.
+//
+// No safeguards are in place for vales of t that do not satisfy 0 <= t <= 1.
+// Instead, this will extrapolate beyond c1 or c2.
+func (c1 Cylindrical) Lerp(c2 Cylindrical, t float64) Cylindrical {
+	c1, c2 = c1.Normalised(), c2.Normalised()
+
+	if c1.Radius == 0 {
+		c1.Azimuth = c2.Azimuth
+	}
+	if c2.Radius == 0 {
+		c2.Azimuth = c1.Azimuth
+	}
+
+	return Cylindrical{
+		c1.Radius + t*(c2.Radius-c1.Radius),
+		LerpAngle(c1.Azimuth, c2.Azimuth, t),
+		c1.Z + t*(c2.Z-c1.Z),
+	}.Normalised()
+}
+
+// Equals returns true if the two representations are identical. Different representations of the same
+// point are not equal unless both are normalised first.
+func (c1 Cylindrical) Equals(c2 Cylindrical) bool {
+	return c1.Radius == c2.Radius && c1.Azimuth == c2.Azimuth && c1.Z == c2.Z
+}
+
+// AlmostEquals returns true if the two representations are almost identical, within some tolerance threshold.
+// Azimuths are compared the short way round, so azimuths either side of pi can still be almost equal.
+func (c1 Cylindrical) AlmostEquals(c2 Cylindrical, threshold float64) bool {
+	return math.Abs(c1.Radius-c2.Radius) <= threshold &&
+		math.Abs(AngleDifference(c1.Azimuth, c2.Azimuth)) <= threshold &&
+		math.Abs(c1.Z-c2.Z) <= threshold
+}
+
+// ToSpherical returns the same point in spherical coordinates.
+func (c Cylindrical) ToSpherical() Spherical {
+	c = c.Normalised()
+
+	radius := math.Hypot(c.Radius, c.Z)
+	if radius == 0 {
+		return Spherical{}
+	}
+
+	return Spherical{radius, c.Azimuth, math.Atan2(c.Radius, c.Z)}.Normalised()
+}
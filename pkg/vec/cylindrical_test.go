@@ -0,0 +1,91 @@
+package vec
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestVec3_ToCylindrical(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Vec3
+		want Cylindrical
+	}{
+		{"origin", Vec3{0, 0, 0}, Cylindrical{0, 0, 0}},
+		{"on the Z axis", Vec3{0, 0, -3}, Cylindrical{0, 0, -3}},
+		{"positive X", Vec3{2, 0, 1}, Cylindrical{2, 0, 1}},
+		{"negative X", Vec3{-2, math.Copysign(0, -1), 1}, Cylindrical{2, math.Pi, 1}},
+		{"fourth quadrant", Vec3{1, -1, 5}, Cylindrical{math.Sqrt2, -math.Pi / 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.v.ToCylindrical()
+			if math.Abs(got.Radius-tt.want.Radius) > 1e-12 || math.Abs(got.Azimuth-tt.want.Azimuth) > 1e-12 ||
+				got.Z != tt.want.Z {
+				t.Errorf("ToCylindrical() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCylindrical_ToVec3(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for range 1000 {
+		v := Vec3{r.Float64()*200 - 100, r.Float64()*200 - 100, r.Float64()*200 - 100}
+		if got := v.ToCylindrical().ToVec3(); !got.AlmostEquals(v, 1e-12) {
+			t.Fatalf("%v.ToCylindrical().ToVec3() = %v", v, got)
+		}
+
+		c := Cylindrical{r.Float64() * 100, r.Float64()*2*math.Pi - math.Pi, r.Float64()*200 - 100}
+		if got := c.ToVec3().ToCylindrical(); !got.AlmostEquals(c, 1e-12) {
+			t.Fatalf("%v.ToVec3().ToCylindrical() = %v", c, got)
+		}
+	}
+}
+
+func TestCylindrical_Normalised(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Cylindrical
+		want Cylindrical
+	}{
+		{"already normalised", Cylindrical{1, 1, 1}, Cylindrical{1, 1, 1}},
+		{"large azimuth", Cylindrical{2, -5 * math.Pi / 2, 0}, Cylindrical{2, -math.Pi / 2, 0}},
+		{"negative radius", Cylindrical{-1, 0, 4}, Cylindrical{1, math.Pi, 4}},
+		{"on the Z axis", Cylindrical{0, 2, 4}, Cylindrical{0, 0, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Normalised(); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("Normalised() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCylindrical_Lerp(t *testing.T) {
+	tests := []struct {
+		name   string
+		c1, c2 Cylindrical
+		t      float64
+		want   Cylindrical
+	}{
+		{"start", Cylindrical{1, 0, 0}, Cylindrical{3, 1, 4}, 0, Cylindrical{1, 0, 0}},
+		{"end", Cylindrical{1, 0, 0}, Cylindrical{3, 1, 4}, 1, Cylindrical{3, 1, 4}},
+		{"middle", Cylindrical{1, 0, 0}, Cylindrical{3, 1, 4}, 0.5, Cylindrical{2, 0.5, 2}},
+		{"short way round", Cylindrical{1, -3, 0}, Cylindrical{1, 3, 2}, 0.5, Cylindrical{1, math.Pi, 1}},
+		{"from the Z axis", Cylindrical{0, 0, 0}, Cylindrical{2, 2, 2}, 0.5, Cylindrical{1, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c1.Lerp(tt.c2, tt.t); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("Lerp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,432 @@
+package vec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Vec2 and Vec3 are encoded in the following forms:
+//
+//   - Text, such as "(1, 2.5)", as used by [encoding.TextMarshaler], and by [fmt] when a precision is given.
+//   - JSON objects, such as {"X":1,"Y":2.5}. Wrapping a vector in [Vec2Array] or [Vec3Array] encodes it as
+//     a JSON array, such as [1,2.5], instead. Either form can be decoded into any of these types.
+//   - Binary, as each component's IEEE 754 bits in little-endian order, X first. This is 16 bytes for a
+//     Vec2 and 24 bytes for a Vec3.
+//
+// Decoding is strict: malformed input, including missing, extra or unknown components, returns an error
+// wrapping [ErrSyntax] and leaves the vector unchanged.
+
+// Vec2Array is a [Vec2] that is encoded as a JSON array of its components, rather than a JSON object.
+type Vec2Array Vec2
+
+// Vec3Array is a [Vec3] that is encoded as a JSON array of its components, rather than a JSON object.
+type Vec3Array Vec3
+
+// MarshalText encodes the vector in the form "(X, Y)".
+func (v Vec2) MarshalText() ([]byte, error) {
+	return appendText(nil, v.X, v.Y), nil
+}
+
+// UnmarshalText decodes a vector in the form "(X, Y)", as produced by [Vec2.MarshalText].
+//
+// If the text is in any other form, this function will return an error wrapping [ErrSyntax].
+func (v *Vec2) UnmarshalText(text []byte) error {
+	components, err := parseText(text, 2)
+	if err != nil {
+		return NewOpError("Vec2.UnmarshalText", err)
+	}
+
+	*v = Vec2{components[0], components[1]}
+	return nil
+}
+
+// MarshalJSON encodes the vector as a JSON object with the fields X and Y.
+// To encode it as a JSON array instead, use [Vec2Array].
+//
+// JSON cannot represent NaN or infinite components, so if the vector has any, this function will return an error.
+func (v Vec2) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct{ X, Y float64 }{v.X, v.Y})
+}
+
+// UnmarshalJSON decodes a vector from either a JSON object with the fields X and Y, or a JSON array of
+// two numbers. Field names are matched case-insensitively.
+//
+// If the data is in any other form, this function will return an error wrapping [ErrSyntax].
+func (v *Vec2) UnmarshalJSON(data []byte) error {
+	components, err := parseJSON(data, []string{"X", "Y"})
+	if err != nil {
+		return NewOpError("Vec2.UnmarshalJSON", err)
+	}
+
+	if components != nil {
+		*v = Vec2{components[0], components[1]}
+	}
+	return nil
+}
+
+// MarshalJSON encodes the vector as a JSON array of its components.
+//
+// JSON cannot represent NaN or infinite components, so if the vector has any, this function will return an error.
+func (v Vec2Array) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{v.X, v.Y})
+}
+
+// UnmarshalJSON decodes a vector in the same way as [Vec2.UnmarshalJSON].
+func (v *Vec2Array) UnmarshalJSON(data []byte) error {
+	return (*Vec2)(v).UnmarshalJSON(data)
+}
+
+// MarshalBinary encodes the vector as 16 bytes: the IEEE 754 bits of X and then Y, each in little-endian order.
+func (v Vec2) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, v.X, v.Y), nil
+}
+
+// UnmarshalBinary decodes a vector encoded by [Vec2.MarshalBinary].
+//
+// If data is not exactly 16 bytes long, this function will return an error wrapping [ErrSyntax].
+func (v *Vec2) UnmarshalBinary(data []byte) error {
+	components, err := parseBinary(data, 2)
+	if err != nil {
+		return NewOpError("Vec2.UnmarshalBinary", err)
+	}
+
+	*v = Vec2{components[0], components[1]}
+	return nil
+}
+
+// Format implements [fmt.Formatter], so that a precision can be given when printing the vector.
+//
+// When a precision is given to %v or one of the floating point verbs, such as %.3v or %.2f, the vector is
+// printed in the form "(X, Y)", with the width, precision and flags applied to each component separately.
+// For example, %.3f prints (1.000, 2.500). Otherwise, the vector is printed exactly as it would be without
+// this method, so %v still prints {1 2.5}, %+v prints {X:1 Y:2.5} and %#v prints vec.Vec2{X:1, Y:2.5}.
+//
+// A *Vec2 shares this method, and fmt cannot tell it was given a pointer, so a pointer prints the same as the
+// vector it points to. %v prints {1 2.5} rather than &{1 2.5} for a *Vec2, and [{1 2.5}] rather than the
+// addresses for a []*Vec2. A nil *Vec2 still prints <nil>.
+func (v Vec2) Format(f fmt.State, verb rune) {
+	format(f, verb, "vec.Vec2", []string{"X", "Y"}, v.X, v.Y)
+}
+
+// MarshalText encodes the vector in the form "(X, Y, Z)".
+func (v Vec3) MarshalText() ([]byte, error) {
+	return appendText(nil, v.X, v.Y, v.Z), nil
+}
+
+// UnmarshalText decodes a vector in the form "(X, Y, Z)", as produced by [Vec3.MarshalText].
+//
+// If the text is in any other form, this function will return an error wrapping [ErrSyntax].
+func (v *Vec3) UnmarshalText(text []byte) error {
+	components, err := parseText(text, 3)
+	if err != nil {
+		return NewOpError("Vec3.UnmarshalText", err)
+	}
+
+	*v = Vec3{components[0], components[1], components[2]}
+	return nil
+}
+
+// MarshalJSON encodes the vector as a JSON object with the fields X, Y and Z.
+// To encode it as a JSON array instead, use [Vec3Array].
+//
+// JSON cannot represent NaN or infinite components, so if the vector has any, this function will return an error.
+func (v Vec3) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct{ X, Y, Z float64 }{v.X, v.Y, v.Z})
+}
+
+// UnmarshalJSON decodes a vector from either a JSON object with the fields X, Y and Z, or a JSON array of
+// three numbers. Field names are matched case-insensitively.
+//
+// If the data is in any other form, this function will return an error wrapping [ErrSyntax].
+func (v *Vec3) UnmarshalJSON(data []byte) error {
+	components, err := parseJSON(data, []string{"X", "Y", "Z"})
+	if err != nil {
+		return NewOpError("Vec3.UnmarshalJSON", err)
+	}
+
+	if components != nil {
+		*v = Vec3{components[0], components[1], components[2]}
+	}
+	return nil
+}
+
+// MarshalJSON encodes the vector as a JSON array of its components.
+//
+// JSON cannot represent NaN or infinite components, so if the vector has any, this function will return an error.
+func (v Vec3Array) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{v.X, v.Y, v.Z})
+}
+
+// UnmarshalJSON decodes a vector in the same way as [Vec3.UnmarshalJSON].
+func (v *Vec3Array) UnmarshalJSON(data []byte) error {
+	return (*Vec3)(v).UnmarshalJSON(data)
+}
+
+// MarshalBinary encodes the vector as 24 bytes: the IEEE 754 bits of X, Y and then Z, each in little-endian order.
+func (v Vec3) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, v.X, v.Y, v.Z), nil
+}
+
+// UnmarshalBinary decodes a vector encoded by [Vec3.MarshalBinary].
+//
+// If data is not exactly 24 bytes long, this function will return an error wrapping [ErrSyntax].
+func (v *Vec3) UnmarshalBinary(data []byte) error {
+	components, err := parseBinary(data, 3)
+	if err != nil {
+		return NewOpError("Vec3.UnmarshalBinary", err)
+	}
+
+	*v = Vec3{components[0], components[1], components[2]}
+	return nil
+}
+
+// Format implements [fmt.Formatter], so that a precision can be given when printing the vector.
+//
+// When a precision is given to %v or one of the floating point verbs, such as %.3v or %.2f, the vector is
+// printed in the form "(X, Y, Z)", with the width, precision and flags applied to each component separately.
+// For example, %.3f prints (1.000, 2.500, 3.000). Otherwise, the vector is printed exactly as it would be
+// without this method, so %v still prints {1 2.5 3}, %+v prints {X:1 Y:2.5 Z:3} and %#v prints
+// vec.Vec3{X:1, Y:2.5, Z:3}.
+//
+// As with [Vec2.Format], a *Vec3 prints the same as the vector it points to.
+func (v Vec3) Format(f fmt.State, verb rune) {
+	format(f, verb, "vec.Vec3", []string{"X", "Y", "Z"}, v.X, v.Y, v.Z)
+}
+
+// appendText appends the components to b in the form "(X, Y, ...)", using the fewest digits that
+// decode back to exactly the same value.
+func appendText(b []byte, components ...float64) []byte {
+	b = append(b, '(')
+	for i, c := range components {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = strconv.AppendFloat(b, c, 'g', -1, 64)
+	}
+	return append(b, ')')
+}
+
+// parseText parses n components in the form "(X, Y, ...)". Spaces are allowed around each component,
+// but not outside the parentheses.
+func parseText(text []byte, n int) ([]float64, error) {
+	s := string(text)
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("%w: %q is not enclosed in parentheses", ErrSyntax, s)
+	}
+
+	fields := strings.Split(s[1:len(s)-1], ",")
+	if len(fields) != n {
+		return nil, fmt.Errorf("%w: %q has %v components, want %v", ErrSyntax, s, len(fields), n)
+	}
+
+	components := make([]float64, n)
+	for i, field := range fields {
+		c, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q has a malformed component %q", ErrSyntax, s, strings.TrimSpace(field))
+		}
+		components[i] = c
+	}
+
+	return components, nil
+}
+
+// parseJSON parses either a JSON object with exactly the given fields, each given once, or a JSON array of
+// as many numbers. If data is the JSON literal null, it returns nil and no error, so that the value being
+// decoded into is left unchanged as is conventional.
+//
+// The input is walked token by token, rather than decoded into a map, so that repeated fields are caught.
+func parseJSON(data []byte, fields []string) ([]float64, error) {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil, nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	open, err := d.Token()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSyntax, err)
+	}
+
+	var components []float64
+	switch open {
+	case json.Delim('['):
+		components, err = parseJSONArray(d, len(fields))
+	case json.Delim('{'):
+		components, err = parseJSONObject(d, fields)
+	default:
+		return nil, fmt.Errorf("%w: want an object or array, got %v", ErrSyntax, open)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := d.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: unexpected data after the vector", ErrSyntax)
+	}
+
+	return components, nil
+}
+
+// parseJSONArray parses the rest of a JSON array of exactly n numbers, after its opening bracket.
+func parseJSONArray(d *json.Decoder, n int) ([]float64, error) {
+	var components []float64
+	for d.More() {
+		c, err := parseJSONNumber(d, fmt.Sprintf("array component %v", len(components)))
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, c)
+	}
+
+	if _, err := d.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSyntax, err)
+	}
+
+	if len(components) != n {
+		return nil, fmt.Errorf("%w: array has %v components, want %v", ErrSyntax, len(components), n)
+	}
+
+	return components, nil
+}
+
+// parseJSONObject parses the rest of a JSON object after its opening brace. Every one of fields must appear
+// exactly once, matched case-insensitively, and no other fields may appear.
+func parseJSONObject(d *json.Decoder, fields []string) ([]float64, error) {
+	components := make([]float64, len(fields))
+	found := make([]bool, len(fields))
+
+	for d.More() {
+		token, err := d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSyntax, err)
+		}
+		key := token.(string)
+
+		i := slices.IndexFunc(fields, func(field string) bool { return strings.EqualFold(key, field) })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrSyntax, key)
+		}
+		if found[i] {
+			return nil, fmt.Errorf("%w: field %v is given more than once", ErrSyntax, fields[i])
+		}
+
+		components[i], err = parseJSONNumber(d, "field "+fields[i])
+		if err != nil {
+			return nil, err
+		}
+		found[i] = true
+	}
+
+	if _, err := d.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSyntax, err)
+	}
+
+	for i, field := range fields {
+		if !found[i] {
+			return nil, fmt.Errorf("%w: missing field %v", ErrSyntax, field)
+		}
+	}
+
+	return components, nil
+}
+
+// parseJSONNumber parses the next token of d, which must be a number. name describes the token in errors.
+func parseJSONNumber(d *json.Decoder, name string) (float64, error) {
+	token, err := d.Token()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrSyntax, err)
+	}
+
+	number, ok := token.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("%w: %v is not a number", ErrSyntax, name)
+	}
+
+	c, err := strconv.ParseFloat(string(number), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v is out of range", ErrSyntax, name)
+	}
+
+	return c, nil
+}
+
+// appendBinary appends the IEEE 754 bits of each component to b in little-endian order.
+func appendBinary(b []byte, components ...float64) []byte {
+	for _, c := range components {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(c))
+	}
+	return b
+}
+
+// parseBinary parses n components encoded by appendBinary.
+func parseBinary(data []byte, n int) ([]float64, error) {
+	if len(data) != 8*n {
+		return nil, fmt.Errorf("%w: got %v bytes, want %v", ErrSyntax, len(data), 8*n)
+	}
+
+	components := make([]float64, n)
+	for i := range components {
+		components[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+
+	return components, nil
+}
+
+// format prints the components in the form "(X, Y, ...)", formatting each according to f and verb, if f
+// has a precision. Otherwise, it prints them exactly as fmt would print a struct without a Format method.
+func format(f fmt.State, verb rune, typeName string, names []string, components ...float64) {
+	_, hasPrecision := f.Precision()
+
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "%v{", typeName)
+		for i, c := range components {
+			if i > 0 {
+				fmt.Fprint(f, ", ")
+			}
+			fmt.Fprintf(f, "%v:%#v", names[i], c)
+		}
+		fmt.Fprint(f, "}")
+
+	case hasPrecision && strings.ContainsRune("vbeEfFgGxX", verb):
+		componentFormat := fmt.FormatString(f, verb)
+
+		fmt.Fprint(f, "(")
+		for i, c := range components {
+			if i > 0 {
+				fmt.Fprint(f, ", ")
+			}
+			fmt.Fprintf(f, componentFormat, c)
+		}
+		fmt.Fprint(f, ")")
+
+	default:
+		// %+v names the fields, and does not pass the + flag on to them
+		componentFormat := fmt.FormatString(f, verb)
+		plusV := verb == 'v' && f.Flag('+')
+		if plusV {
+			componentFormat = strings.Replace(componentFormat, "+", "", 1)
+		}
+
+		fmt.Fprint(f, "{")
+		for i, c := range components {
+			if i > 0 {
+				fmt.Fprint(f, " ")
+			}
+			if plusV {
+				fmt.Fprintf(f, "%v:", names[i])
+			}
+			fmt.Fprintf(f, componentFormat, c)
+		}
+		fmt.Fprint(f, "}")
+	}
+}
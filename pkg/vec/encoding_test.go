@@ -0,0 +1,405 @@
+package vec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestVec2_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Vec2
+		want string
+	}{
+		{"integers", Vec2{1, 2}, "(1, 2)"},
+		{"fractions", Vec2{1, 2.5}, "(1, 2.5)"},
+		{"negative zero", Vec2{math.Copysign(0, -1), -3}, "(-0, -3)"},
+		{"shortest round trip", Vec2{0.1, 1.0 / 3}, "(0.1, 0.3333333333333333)"},
+		{"large", Vec2{1e21, 1e-7}, "(1e+21, 1e-07)"},
+		{"non-finite", Vec2{math.Inf(1), math.NaN()}, "(+Inf, NaN)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.v.MarshalText()
+			if err != nil {
+				t.Fatalf("Vec2.MarshalText() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Vec2.MarshalText() = %q, want %q", got, tt.want)
+			}
+
+			var decoded Vec2
+			if err := decoded.UnmarshalText(got); err != nil {
+				t.Fatalf("Vec2.UnmarshalText() error = %v", err)
+			}
+			if decoded.X != tt.v.X && !(math.IsNaN(decoded.X) && math.IsNaN(tt.v.X)) ||
+				decoded.Y != tt.v.Y && !(math.IsNaN(decoded.Y) && math.IsNaN(tt.v.Y)) {
+				t.Errorf("Vec2.UnmarshalText() = %v, want %v", decoded, tt.v)
+			}
+		})
+	}
+}
+
+func TestVec2_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    Vec2
+		wantErr bool
+	}{
+		{"canonical", "(1, 2.5)", Vec2{1, 2.5}, false},
+		{"no spaces", "(1,2.5)", Vec2{1, 2.5}, false},
+		{"extra spaces", "( 1 ,  2.5 )", Vec2{1, 2.5}, false},
+		{"exponent", "(1e3, -2E-1)", Vec2{1000, -0.2}, false},
+		{"empty", "", Vec2{}, true},
+		{"no parentheses", "1, 2", Vec2{}, true},
+		{"space outside parentheses", " (1, 2)", Vec2{}, true},
+		{"square brackets", "[1, 2]", Vec2{}, true},
+		{"too few components", "(1)", Vec2{}, true},
+		{"too many components", "(1, 2, 3)", Vec2{}, true},
+		{"empty component", "(1, )", Vec2{}, true},
+		{"not a number", "(1, two)", Vec2{}, true},
+		{"trailing garbage", "(1, 2)x", Vec2{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Vec2{7, 7}
+			err := got.UnmarshalText([]byte(tt.text))
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrSyntax) {
+					t.Errorf("Vec2.UnmarshalText() error = %v, want %v", err, ErrSyntax)
+				}
+				if !got.Equals(Vec2{7, 7}) {
+					t.Errorf("Vec2.UnmarshalText() changed the vector to %v on error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Vec2.UnmarshalText() error = %v", err)
+			}
+			if !got.Equals(tt.want) {
+				t.Errorf("Vec2.UnmarshalText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVec3_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    Vec3
+		wantErr bool
+	}{
+		{"canonical", "(1, 2.5, -3)", Vec3{1, 2.5, -3}, false},
+		{"too few components", "(1, 2)", Vec3{}, true},
+		{"too many components", "(1, 2, 3, 4)", Vec3{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Vec3
+			err := got.UnmarshalText([]byte(tt.text))
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrSyntax) {
+					t.Errorf("Vec3.UnmarshalText() error = %v, want %v", err, ErrSyntax)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Vec3.UnmarshalText() error = %v", err)
+			}
+			if !got.Equals(tt.want) {
+				t.Errorf("Vec3.UnmarshalText() = %v, want %v", got, tt.want)
+			}
+
+			text, _ := got.MarshalText()
+			if string(text) != tt.text {
+				t.Errorf("Vec3.MarshalText() = %q, want %q", text, tt.text)
+			}
+		})
+	}
+}
+
+func TestVec2_MarshalJSON(t *testing.T) {
+	type payload struct {
+		Position Vec2
+		Velocity Vec2Array
+		Target   *Vec3
+		Normal   Vec3Array
+	}
+
+	p := payload{
+		Position: Vec2{1, 2.5},
+		Velocity: Vec2Array{-1, 0},
+		Target:   &Vec3{1, 2, 3},
+		Normal:   Vec3Array{0, 0, 1},
+	}
+	want := `{"Position":{"X":1,"Y":2.5},"Velocity":[-1,0],"Target":{"X":1,"Y":2,"Z":3},"Normal":[0,0,1]}`
+
+	got, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+
+	var decoded payload
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !decoded.Position.Equals(p.Position) || decoded.Velocity != p.Velocity ||
+		!decoded.Target.Equals(*p.Target) || decoded.Normal != p.Normal {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", decoded, p)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Position":{"x":1,"x":2,"y":3}}`), &decoded); !errors.Is(err, ErrSyntax) {
+		t.Errorf("json.Unmarshal() of a duplicate field error = %v, want %v", err, ErrSyntax)
+	}
+
+	if _, err := json.Marshal(Vec2{math.NaN(), 0}); err == nil {
+		t.Errorf("json.Marshal() of NaN component error = nil, want an error")
+	}
+}
+
+func TestVec2_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Vec2
+		wantErr bool
+	}{
+		{"object", `{"X": 1, "Y": 2.5}`, Vec2{1, 2.5}, false},
+		{"lower case object", `{"x": 1, "y": 2.5}`, Vec2{1, 2.5}, false},
+		{"reordered object", `{"Y": 2.5, "X": 1}`, Vec2{1, 2.5}, false},
+		{"array", `[1, 2.5]`, Vec2{1, 2.5}, false},
+		{"null leaves vector unchanged", `null`, Vec2{7, 7}, false},
+		{"missing field", `{"X": 1}`, Vec2{}, true},
+		{"unknown field", `{"X": 1, "Y": 2, "Z": 3}`, Vec2{}, true},
+		{"duplicate field differing in case", `{"X": 1, "x": 2, "Y": 3}`, Vec2{}, true},
+		{"duplicate field", `{"x": 1, "x": 2, "y": 3}`, Vec2{}, true},
+		{"duplicate last field", `{"X": 1, "Y": 2, "Y": 3}`, Vec2{}, true},
+		{"nested field", `{"X": 1, "Y": [2]}`, Vec2{}, true},
+		{"out of range field", `{"X": 1, "Y": 1e400}`, Vec2{}, true},
+		{"nested array", `[1, [2]]`, Vec2{}, true},
+		{"trailing data", `[1, 2] 3`, Vec2{}, true},
+		{"null field", `{"X": 1, "Y": null}`, Vec2{}, true},
+		{"string field", `{"X": 1, "Y": "2"}`, Vec2{}, true},
+		{"short array", `[1]`, Vec2{}, true},
+		{"long array", `[1, 2, 3]`, Vec2{}, true},
+		{"null array element", `[1, null]`, Vec2{}, true},
+		{"number", `1`, Vec2{}, true},
+		{"string", `"(1, 2)"`, Vec2{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Vec2{7, 7}
+			err := got.UnmarshalJSON([]byte(tt.data))
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrSyntax) {
+					t.Errorf("Vec2.UnmarshalJSON() error = %v, want %v", err, ErrSyntax)
+				}
+				if !got.Equals(Vec2{7, 7}) {
+					t.Errorf("Vec2.UnmarshalJSON() changed the vector to %v on error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Vec2.UnmarshalJSON() error = %v", err)
+			}
+			if !got.Equals(tt.want) {
+				t.Errorf("Vec2.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVec3_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Vec3
+		wantErr bool
+	}{
+		{"object", `{"X": 1, "Y": 2, "Z": 3}`, Vec3{1, 2, 3}, false},
+		{"array", `[1, 2, 3]`, Vec3{1, 2, 3}, false},
+		{"missing field", `{"X": 1, "Y": 2}`, Vec3{}, true},
+		{"short array", `[1, 2]`, Vec3{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Vec3Array
+			err := json.Unmarshal([]byte(tt.data), &got)
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrSyntax) {
+					t.Errorf("Vec3Array.UnmarshalJSON() error = %v, want %v", err, ErrSyntax)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Vec3Array.UnmarshalJSON() error = %v", err)
+			}
+			if !Vec3(got).Equals(tt.want) {
+				t.Errorf("Vec3Array.UnmarshalJSON() = %v, want %v", Vec3(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestVec2_MarshalBinary(t *testing.T) {
+	v := Vec2{1, -2}
+	want := []byte{
+		0, 0, 0, 0, 0, 0, 0xf0, 0x3f,
+		0, 0, 0, 0, 0, 0, 0x00, 0xc0,
+	}
+
+	got, err := v.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Vec2.MarshalBinary() error = %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Vec2.MarshalBinary() = %x, want %x", got, want)
+	}
+
+	var decoded Vec2
+	if err := decoded.UnmarshalBinary(got); err != nil {
+		t.Fatalf("Vec2.UnmarshalBinary() error = %v", err)
+	}
+	if !decoded.Equals(v) {
+		t.Errorf("Vec2.UnmarshalBinary() = %v, want %v", decoded, v)
+	}
+
+	for _, n := range []int{0, 15, 17, 24} {
+		if err := decoded.UnmarshalBinary(make([]byte, n)); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Vec2.UnmarshalBinary() of %v bytes error = %v, want %v", n, err, ErrSyntax)
+		}
+	}
+}
+
+func TestVec3_MarshalBinary(t *testing.T) {
+	v := Vec3{0.1, math.Inf(-1), math.Copysign(0, -1)}
+
+	got, err := v.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Vec3.MarshalBinary() error = %v", err)
+	}
+	if len(got) != 24 {
+		t.Fatalf("Vec3.MarshalBinary() has %v bytes, want 24", len(got))
+	}
+
+	var decoded Vec3
+	if err := decoded.UnmarshalBinary(got); err != nil {
+		t.Fatalf("Vec3.UnmarshalBinary() error = %v", err)
+	}
+	if !decoded.Equals(v) || !math.Signbit(decoded.Z) {
+		t.Errorf("Vec3.UnmarshalBinary() = %v, want %v", decoded, v)
+	}
+
+	if err := decoded.UnmarshalBinary(got[:16]); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Vec3.UnmarshalBinary() of 16 bytes error = %v, want %v", err, ErrSyntax)
+	}
+}
+
+func TestVec2_Format(t *testing.T) {
+	v := Vec2{1.23456, -2}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%.3v", "(1.23, -2)"},
+		{"%.2f", "(1.23, -2.00)"},
+		{"%+.1f", "(+1.2, -2.0)"},
+		{"%6.2f", "(  1.23,  -2.00)"},
+		{"%.2e", "(1.23e+00, -2.00e+00)"},
+		{"%.3g", "(1.23, -2)"},
+		{"%v", "{1.23456 -2}"},
+		{"%+v", "{X:1.23456 Y:-2}"},
+		{"%#v", "vec.Vec2{X:1.23456, Y:-2}"},
+		{"%f", "{1.234560 -2.000000}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, v); got != tt.want {
+				t.Errorf("fmt.Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestVec2_Format_Pointer checks that pointers print the same as the vectors they point to, since they share
+// the Format method.
+func TestVec2_Format_Pointer(t *testing.T) {
+	v := Vec2{1, 2.5}
+
+	tests := []struct {
+		name   string
+		format string
+		arg    any
+		want   string
+	}{
+		{"pointer", "%v", &v, "{1 2.5}"},
+		{"pointer with precision", "%.1f", &v, "(1.0, 2.5)"},
+		{"pointer with field names", "%+v", &v, "{X:1 Y:2.5}"},
+		{"slice of pointers", "%v", []*Vec2{&v, &v}, "[{1 2.5} {1 2.5}]"},
+		{"nil pointer", "%v", (*Vec2)(nil), "<nil>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.arg); got != tt.want {
+				t.Errorf("fmt.Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVec3_Format(t *testing.T) {
+	v := Vec3{1, 2.5, -1.0 / 3}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%.3v", "(1, 2.5, -0.333)"},
+		{"%.1f", "(1.0, 2.5, -0.3)"},
+		{"%v", "{1 2.5 -0.3333333333333333}"},
+		{"%#v", "vec.Vec3{X:1, Y:2.5, Z:-0.3333333333333333}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, v); got != tt.want {
+				t.Errorf("fmt.Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestVec3_Format_Compatible checks that, without a precision, vectors print exactly as they did before they
+// implemented fmt.Formatter, by comparing them to a struct of the same shape.
+func TestVec3_Format_Compatible(t *testing.T) {
+	type plainVec3 struct{ X, Y, Z float64 }
+
+	v := Vec3{1, -2.5, math.Inf(1)}
+	plain := plainVec3{1, -2.5, math.Inf(1)}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%8v", "%-8v", "%+8v", "%g", "%e", "%f", "%x", "%s", "%.2s", "%d", "%q"} {
+		t.Run(format, func(t *testing.T) {
+			want := strings.Replace(fmt.Sprintf(format, plain), "vec.plainVec3", "vec.Vec3", 1)
+			if got := fmt.Sprintf(format, v); got != want {
+				t.Errorf("fmt.Sprintf(%q) = %q, want %q", format, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The sentinel errors below describe why an operation in this package failed. Operations never return
+// them directly: they return an [*OpError] wrapping one of them, so use [errors.Is] to check for them.
+var (
+	// ErrDivideByZero means an operation needed to divide by a value that was 0.
+	ErrDivideByZero = errors.New("division by 0")
+	// ErrZeroLength means an operation needed a direction, but was given a vector or quaternion of length 0.
+	ErrZeroLength = errors.New("0-length vector")
+	// ErrSingular means an operation needed to invert or decompose a matrix or transform with a
+	// determinant of 0, which collapses space onto a lower dimension.
+	ErrSingular = errors.New("singular matrix")
+	// ErrParallel means an operation needed two vectors pointing in different directions, but they were parallel.
+	ErrParallel = errors.New("parallel vectors")
+	// ErrInvalidArgument means an argument was outside the range an operation accepts.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrSyntax means text or binary data being decoded into a value was malformed.
+	ErrSyntax = errors.New("invalid syntax")
+)
+
+// OpError is the error returned by every failing operation in this package, and in the packages built on it
+// such as geom, curve and spatial. It records the operation and its operands, and wraps the reason it failed,
+// which is one of the sentinel errors such as [ErrZeroLength], or a sentinel error of the package concerned.
+//
+// Use [errors.Is] to check the reason, and [errors.As] to retrieve the OpError and inspect its operands.
+type OpError struct {
+	// Op is the name of the failed operation, as it is written in Go: "Type.Method" for methods, such as
+	// "Vec2.Normalised", and the function name for functions, such as "LookAt" or "NormalisedVec2".
+	// Generic types are named without their type arguments, such as "Vec2Of.Divide".
+	Op string
+	// Operands holds the values the operation was given, in order. For methods, the receiver comes first.
+	// Large operands, such as point sets, polygons, curves and spatial indexes, are left out.
+	Operands []any
+	// Err is the reason the operation failed. It is a sentinel error, or wraps one with more detail.
+	Err error
+}
+
+func (e *OpError) Error() string {
+	operands := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		operands[i] = fmt.Sprint(operand)
+	}
+
+	return fmt.Sprintf("%v(%v): %v", e.Op, strings.Join(operands, ", "), e.Err)
+}
+
+// Unwrap returns the reason the operation failed.
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+// NewOpError returns an OpError for the operation op, given operands, that failed because of err.
+//
+// It is exported so that packages built on this one report their failures in the same way.
+func NewOpError(op string, err error, operands ...any) *OpError {
+	return &OpError{
+		Op:       op,
+		Operands: operands,
+		Err:      err,
+	}
+}
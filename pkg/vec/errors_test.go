@@ -0,0 +1,108 @@
+package vec
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestOpError(t *testing.T) {
+	ignore := func(_ any, err error) error { return err }
+
+	// swapZW is its own inverse, and maps the centre of the unit cube to a point at infinity
+	swapZW := Mat4{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 0, 1},
+		{0, 0, 1, 0},
+	}
+
+	tests := []struct {
+		name         string
+		err          error
+		want         error
+		wantOp       string
+		wantOperands []any
+	}{
+		{"Vec2.Divide", ignore(Vec2{1, 2}.Divide(0)), ErrDivideByZero, "Vec2.Divide", []any{Vec2{1, 2}, 0.0}},
+		{"Vec3.Divide", ignore(Vec3{1, 2, 3}.Divide(0)), ErrDivideByZero, "Vec3.Divide", []any{Vec3{1, 2, 3}, 0.0}},
+		{"Vec4.Divide", ignore(Vec4{1, 2, 3, 4}.Divide(0)), ErrDivideByZero, "Vec4.Divide", []any{Vec4{1, 2, 3, 4}, 0.0}},
+		{"Vec2Of.Divide", ignore(Vec2Of[int]{1, 2}.Divide(0)), ErrDivideByZero, "Vec2Of.Divide", []any{Vec2Of[int]{1, 2}, 0}},
+		{"Vec3Of.Divide", ignore(Vec3Of[int]{1, 2, 3}.Divide(0)), ErrDivideByZero, "Vec3Of.Divide", []any{Vec3Of[int]{1, 2, 3}, 0}},
+		{"Vec4.PerspectiveDivide", ignore(Vec4{1, 2, 3, 0}.PerspectiveDivide()), ErrDivideByZero, "Vec4.PerspectiveDivide", []any{Vec4{1, 2, 3, 0}}},
+		{"Vec2.Normalised", ignore(Vec2{}.Normalised()), ErrZeroLength, "Vec2.Normalised", []any{Vec2{}}},
+		{"Vec3.Normalised", ignore(Vec3{}.Normalised()), ErrZeroLength, "Vec3.Normalised", []any{Vec3{}}},
+		{"Vec4.Normalised", ignore(Vec4{}.Normalised()), ErrZeroLength, "Vec4.Normalised", []any{Vec4{}}},
+		{"Vec2Of.Angle", ignore(Vec2Of[int]{}.Angle(Vec2Of[int]{1, 0})), ErrZeroLength, "Vec2Of.Angle", []any{Vec2Of[int]{}, Vec2Of[int]{1, 0}}},
+		{"Vec3Of.Angle", ignore(Vec3Of[int]{1, 0, 0}.Angle(Vec3Of[int]{})), ErrZeroLength, "Vec3Of.Angle", []any{Vec3Of[int]{1, 0, 0}, Vec3Of[int]{}}},
+		{"NormalisedVec2", ignore(NormalisedVec2(Vec2Of[float32]{})), ErrZeroLength, "NormalisedVec2", []any{Vec2Of[float32]{}}},
+		{"NormalisedVec3", ignore(NormalisedVec3(Vec3Of[float32]{})), ErrZeroLength, "NormalisedVec3", []any{Vec3Of[float32]{}}},
+		{"Vec2.Angle", ignore(Vec2{1, 0}.Angle(Vec2{})), ErrZeroLength, "Vec2.Angle", []any{Vec2{1, 0}, Vec2{}}},
+		{"Vec3.Angle", ignore(Vec3{}.Angle(Vec3{1, 0, 0})), ErrZeroLength, "Vec3.Angle", []any{Vec3{}, Vec3{1, 0, 0}}},
+		{"Vec4.Angle", ignore(Vec4{}.Angle(Vec4{})), ErrZeroLength, "Vec4.Angle", []any{Vec4{}, Vec4{}}},
+		{"Quat.Normalised", ignore(Quat{}.Normalised()), ErrZeroLength, "Quat.Normalised", []any{Quat{}}},
+		{"Quat.Inverse", ignore(Quat{}.Inverse()), ErrZeroLength, "Quat.Inverse", []any{Quat{}}},
+		{"QuatFromAxisAngle", ignore(QuatFromAxisAngle(Vec3{}, 1)), ErrZeroLength, "QuatFromAxisAngle", []any{Vec3{}, 1.0}},
+		{"Mat2.Inverse", ignore(Mat2{}.Inverse()), ErrSingular, "Mat2.Inverse", []any{Mat2{}}},
+		{"Mat3.Inverse", ignore(Mat3{}.Inverse()), ErrSingular, "Mat3.Inverse", []any{Mat3{}}},
+		{"Mat4.Inverse", ignore(Mat4{}.Inverse()), ErrSingular, "Mat4.Inverse", []any{Mat4{}}},
+		{"Transform2D.Inverse", ignore(Scale2D(Vec2{0, 1}).Inverse()), ErrSingular, "Transform2D.Inverse", []any{Scale2D(Vec2{0, 1})}},
+		{"Transform3D.Inverse", ignore(Transform3D{}.Inverse()), ErrSingular, "Transform3D.Inverse", []any{Transform3D{}}},
+		{"LookAt same point", ignore(LookAt(Vec3{1, 1, 1}, Vec3{1, 1, 1}, Vec3{0, 1, 0})), ErrZeroLength, "LookAt", []any{Vec3{1, 1, 1}, Vec3{1, 1, 1}, Vec3{0, 1, 0}}},
+		{"LookAt parallel", ignore(LookAt(Vec3{}, Vec3{0, 1, 0}, Vec3{0, 1, 0})), ErrParallel, "LookAt", []any{Vec3{}, Vec3{0, 1, 0}, Vec3{0, 1, 0}}},
+		{"Perspective", ignore(Perspective(math.Pi, 1, 0.1, 100)), ErrInvalidArgument, "Perspective", []any{math.Pi, 1.0, 0.1, 100.0}},
+		{"Orthographic", ignore(Orthographic(1, 1, 0, 1, 0, 1)), ErrInvalidArgument, "Orthographic", []any{1.0, 1.0, 0.0, 1.0, 0.0, 1.0}},
+		{"Transform2D.Decompose", func() error { _, _, _, _, err := Scale2D(Vec2{0, 1}).Decompose(); return err }(), ErrSingular, "Transform2D.Decompose", []any{Scale2D(Vec2{0, 1})}},
+		{"Project", func() error { _, _, err := Project(Vec3{1, 2, 3}, Mat4{}); return err }(), ErrDivideByZero, "Project", []any{Vec3{1, 2, 3}, Mat4{}}},
+		{"Unproject", ignore(Unproject(Vec2{}, 0, Mat4{})), ErrSingular, "Unproject", []any{Vec2{}, 0.0, Mat4{}}},
+		{"Unproject at infinity", ignore(Unproject(Vec2{0.5, 0.5}, 0.5, swapZW)), ErrDivideByZero, "Unproject", []any{Vec2{0.5, 0.5}, 0.5, swapZW}},
+	}
+
+	sentinels := []error{ErrDivideByZero, ErrZeroLength, ErrSingular, ErrParallel, ErrInvalidArgument}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, sentinel := range sentinels {
+				if got := errors.Is(tt.err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", tt.err, sentinel, got)
+				}
+			}
+
+			var opErr *OpError
+			if !errors.As(tt.err, &opErr) {
+				t.Fatalf("errors.As(%v) found no *OpError", tt.err)
+			}
+			if opErr.Op != tt.wantOp {
+				t.Errorf("Op = %v, want %v", opErr.Op, tt.wantOp)
+			}
+			if len(opErr.Operands) != len(tt.wantOperands) {
+				t.Fatalf("Operands = %v, want %v", opErr.Operands, tt.wantOperands)
+			}
+			for i := range opErr.Operands {
+				if opErr.Operands[i] != tt.wantOperands[i] {
+					t.Errorf("Operands[%v] = %#v, want %#v", i, opErr.Operands[i], tt.wantOperands[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOpError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *OpError
+		want string
+	}{
+		{"no operands", NewOpError("Op", ErrSingular), "Op(): singular matrix"},
+		{"vector", NewOpError("Vec2.Normalised", ErrZeroLength, Vec2{}), "Vec2.Normalised({0 0}): 0-length vector"},
+		{"several operands", NewOpError("Vec2.Divide", ErrDivideByZero, Vec2{1, 2}, 0.0), "Vec2.Divide({1 2}, 0): division by 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
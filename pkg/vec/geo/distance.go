@@ -0,0 +1,145 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// ErrNotConverged means an iterative calculation failed to settle on an answer.
+// Like the sentinel errors of the vec package, it is returned wrapped in a [*vec.OpError].
+var ErrNotConverged = errors.New("failed to converge")
+
+// Haversine returns the great circle distance in metres between g1 and g2, treating the Earth as a sphere
+// of radius [MeanRadius] and ignoring altitude.
+//
+// This is fast and accurate to within about 0.5%. Use [Vincenty] for an accurate distance over the ellipsoid.
+func Haversine(g1, g2 Geodetic) float64 {
+	lat1, lat2 := radians(g1.Latitude), radians(g2.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(g2.Longitude - g1.Longitude)
+
+	sinLat, sinLon := math.Sin(dLat/2), math.Sin(dLon/2)
+	h := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLon*sinLon
+
+	return 2 * MeanRadius * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// vincentyIterations is the most iterations [Vincenty] will use before giving up.
+const vincentyIterations = 200
+
+// Vincenty returns the shortest distance in metres between g1 and g2 over the surface of the WGS84
+// ellipsoid, ignoring altitude, using Vincenty's inverse formula. The result is accurate to within a
+// millimetre.
+//
+// For points that are nearly antipodal, the formula can fail to converge, and this function will
+// return an error wrapping [ErrNotConverged].
+func Vincenty(g1, g2 Geodetic) (float64, error) {
+	const (
+		a = SemiMajorAxis
+		b = SemiMinorAxis
+		f = Flattening
+	)
+
+	// reduced latitudes, on the auxiliary sphere
+	u1 := math.Atan((1 - f) * math.Tan(radians(g1.Latitude)))
+	u2 := math.Atan((1 - f) * math.Tan(radians(g2.Latitude)))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	l := radians(g2.Longitude - g1.Longitude)
+	lambda := l
+
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM float64
+	converged := false
+	for range vincentyIterations {
+		sinLambda, cosLambda := math.Sincos(lambda)
+
+		x := cosU1*sinU2 - sinU1*cosU2*cosLambda
+		sinSigma = math.Sqrt(cosU2*sinLambda*cosU2*sinLambda + x*x)
+		if sinSigma == 0 {
+			// the points coincide
+			return 0, nil
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+
+		// on the equator, cos2Alpha is 0 and the geodesic is a line along the equator
+		cos2SigmaM = 0
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+
+		c := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = l + (1-c)*f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda) > math.Pi+1 {
+			break
+		}
+		if math.Abs(lambda-previous) < 1e-12 {
+			converged = true
+			break
+		}
+	}
+
+	if !converged {
+		return 0, vec.NewOpError("Vincenty", fmt.Errorf("%w: the points may be nearly antipodal", ErrNotConverged), g1, g2)
+	}
+
+	u2Squared := cos2Alpha * (a*a - b*b) / (b * b)
+	bigA := 1 + u2Squared/16384*(4096+u2Squared*(-768+u2Squared*(320-175*u2Squared)))
+	bigB := u2Squared / 1024 * (256 + u2Squared*(-128+u2Squared*(74-47*u2Squared)))
+	deltaSigma := bigB * sinSigma * (cos2SigmaM + bigB/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return b * bigA * (sigma - deltaSigma), nil
+}
+
+// InitialBearing returns the direction to set off in to travel from g1 to g2 along a great circle, in
+// degrees clockwise from north, in the range [0, 360). The Earth is treated as a sphere.
+//
+// Following a great circle, the bearing changes along the way, so this is only the bearing at g1.
+// If the points coincide, the result is 0.
+func InitialBearing(g1, g2 Geodetic) float64 {
+	lat1, lat2 := radians(g1.Latitude), radians(g2.Latitude)
+	dLon := radians(g2.Longitude - g1.Longitude)
+
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+
+	bearing := math.Mod(degrees(math.Atan2(y, x))+360, 360)
+	if bearing >= 360 {
+		return 0
+	}
+	return bearing
+}
+
+// Destination returns the position reached by travelling distance metres from start along a great circle,
+// setting off at bearing degrees clockwise from north. The Earth is treated as a sphere of radius
+// [MeanRadius], and the altitude of start is kept.
+//
+// The longitude of the result is in the range (-180, 180].
+func Destination(start Geodetic, bearing, distance float64) Geodetic {
+	lat1, lon1 := radians(start.Latitude), radians(start.Longitude)
+	theta := radians(bearing)
+	delta := distance / MeanRadius
+
+	sinLat1, cosLat1 := math.Sincos(lat1)
+	sinDelta, cosDelta := math.Sincos(delta)
+
+	sinLat2 := math.Max(-1, math.Min(1, sinLat1*cosDelta+cosLat1*sinDelta*math.Cos(theta)))
+	lat2 := math.Asin(sinLat2)
+	lon2 := lon1 + math.Atan2(math.Sin(theta)*sinDelta*cosLat1, cosDelta-sinLat1*sinLat2)
+
+	return Geodetic{
+		Latitude:  degrees(lat2),
+		Longitude: normaliseLongitude(degrees(lon2)),
+		Altitude:  start.Altitude,
+	}
+}
@@ -0,0 +1,141 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// dms converts degrees, minutes and seconds into degrees.
+func dms(d, m, s float64) float64 {
+	return math.Copysign(math.Abs(d)+m/60+s/3600, d)
+}
+
+var (
+	// the example used in Vincenty's paper
+	flindersPeak = Geodetic{dms(-37, 57, 3.72030), dms(144, 25, 29.52440), 0}
+	buninyong    = Geodetic{dms(-37, 39, 10.15610), dms(143, 55, 35.38390), 0}
+)
+
+func TestHaversine(t *testing.T) {
+	tests := []struct {
+		name   string
+		g1, g2 Geodetic
+		want   float64
+	}{
+		{"same point", Geodetic{10, 20, 0}, Geodetic{10, 20, 500}, 0},
+		{"equator to pole", Geodetic{0, 0, 0}, Geodetic{90, 0, 0}, math.Pi / 2 * MeanRadius},
+		{"along the equator", Geodetic{0, 170, 0}, Geodetic{0, -170, 0}, math.Pi / 9 * MeanRadius},
+		{"antipodal", Geodetic{30, 40, 0}, Geodetic{-30, -140, 0}, math.Pi * MeanRadius},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Haversine(tt.g1, tt.g2); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("Haversine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVincenty(t *testing.T) {
+	tests := []struct {
+		name      string
+		g1, g2    Geodetic
+		want      float64
+		tolerance float64
+		wantErr   bool
+	}{
+		{"same point", flindersPeak, flindersPeak, 0, 0, false},
+		{"Flinders Peak to Buninyong", flindersPeak, buninyong, 54972.271, 1e-3, false},
+		{"Buninyong to Flinders Peak", buninyong, flindersPeak, 54972.271, 1e-3, false},
+		// a quarter of the meridian ellipse
+		{"equator to pole", Geodetic{0, 0, 0}, Geodetic{90, 0, 0}, 10001965.729, 1e-3, false},
+		{"along the equator", Geodetic{0, 0, 0}, Geodetic{0, 90, 0}, math.Pi / 2 * SemiMajorAxis, 1e-3, false},
+		{"nearly antipodal", Geodetic{0, 0, 0}, Geodetic{0.5, 179.7, 0}, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Vincenty(tt.g1, tt.g2)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Vincenty() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrNotConverged) {
+				t.Errorf("Vincenty() error = %v, want ErrNotConverged", err)
+			}
+			if err == nil && math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("Vincenty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitialBearing(t *testing.T) {
+	tests := []struct {
+		name   string
+		g1, g2 Geodetic
+		want   float64
+	}{
+		{"north", Geodetic{0, 0, 0}, Geodetic{1, 0, 0}, 0},
+		{"east", Geodetic{0, 0, 0}, Geodetic{0, 1, 0}, 90},
+		{"south", Geodetic{0, 0, 0}, Geodetic{-1, 0, 0}, 180},
+		{"west", Geodetic{0, 0, 0}, Geodetic{0, -1, 0}, 270},
+		{"same point", Geodetic{5, 5, 0}, Geodetic{5, 5, 0}, 0},
+		// on a sphere, the bearing differs slightly from the ellipsoidal 306°52'05.37"
+		{"Flinders Peak to Buninyong", flindersPeak, buninyong, 306.98},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InitialBearing(tt.g1, tt.g2); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("InitialBearing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDestination(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    Geodetic
+		bearing  float64
+		distance float64
+		want     Geodetic
+	}{
+		{"nowhere", Geodetic{10, 20, 5}, 45, 0, Geodetic{10, 20, 5}},
+		{"to the pole", Geodetic{0, 30, 0}, 0, math.Pi / 2 * MeanRadius, Geodetic{90, 0, 0}},
+		{"east across the date line", Geodetic{0, 170, 0}, 90, math.Pi / 9 * MeanRadius, Geodetic{0, -170, 0}},
+		{"half way round", Geodetic{0, 0, 100}, 180, math.Pi * MeanRadius, Geodetic{0, 180, 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Destination(tt.start, tt.bearing, tt.distance)
+			// longitude is meaningless at the poles
+			lonError := math.Abs(normaliseLongitude(got.Longitude - tt.want.Longitude))
+			if math.Abs(got.Latitude-tt.want.Latitude) > 1e-9 || (math.Abs(tt.want.Latitude) != 90 && lonError > 1e-9) ||
+				got.Altitude != tt.want.Altitude {
+				t.Errorf("Destination() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("inverts bearing and distance", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+
+		for range 1000 {
+			start := Geodetic{r.Float64()*170 - 85, r.Float64()*360 - 180, 0}
+			bearing, distance := r.Float64()*360, r.Float64()*5e6
+
+			end := Destination(start, bearing, distance)
+			if got := Haversine(start, end); math.Abs(got-distance) > 1e-6 {
+				t.Fatalf("Haversine(%v, %v) = %v, want %v", start, end, got, distance)
+			}
+			if got := InitialBearing(start, end); math.Abs(normaliseLongitude(got-bearing)) > 1e-6 {
+				t.Fatalf("InitialBearing(%v, %v) = %v, want %v", start, end, got, bearing)
+			}
+		}
+	})
+}
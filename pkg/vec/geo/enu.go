@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// ENUFrame is a local East-North-Up frame: a Cartesian coordinate system in metres, centred on a point on
+// the Earth, with the positive X axis pointing east, Y pointing north and Z pointing straight up, away from
+// the ellipsoid.
+//
+// Near its origin, an ENUFrame is a convenient flat approximation of the Earth's surface for distance and
+// direction calculations.
+//
+// An ENUFrame must be created with [NewENUFrame].
+type ENUFrame struct {
+	origin     Geodetic
+	originECEF vec.Vec3
+	// rotation turns ECEF directions into ENU directions, with one row per ENU axis
+	rotation vec.Mat3
+}
+
+// NewENUFrame returns the East-North-Up frame centred on origin.
+func NewENUFrame(origin Geodetic) ENUFrame {
+	sinLat, cosLat := math.Sincos(radians(origin.Latitude))
+	sinLon, cosLon := math.Sincos(radians(origin.Longitude))
+
+	return ENUFrame{
+		origin:     origin,
+		originECEF: origin.ToECEF(),
+		rotation: vec.Mat3{
+			{-sinLon, cosLon, 0},
+			{-sinLat * cosLon, -sinLat * sinLon, cosLat},
+			{cosLat * cosLon, cosLat * sinLon, sinLat},
+		},
+	}
+}
+
+// Origin returns the geodetic position of the frame's origin.
+func (f ENUFrame) Origin() Geodetic {
+	return f.origin
+}
+
+// FromECEF converts p, in Earth-centred, Earth-fixed coordinates, into this frame.
+func (f ENUFrame) FromECEF(p vec.Vec3) vec.Vec3 {
+	return f.rotation.MulVec3(p.Subtract(f.originECEF))
+}
+
+// ToECEF converts p, in this frame, into Earth-centred, Earth-fixed coordinates.
+func (f ENUFrame) ToECEF(p vec.Vec3) vec.Vec3 {
+	// the rotation is orthonormal, so its transpose is its inverse
+	return f.rotation.Transpose().MulVec3(p).Add(f.originECEF)
+}
+
+// FromGeodetic converts the geodetic position g into this frame.
+func (f ENUFrame) FromGeodetic(g Geodetic) vec.Vec3 {
+	return f.FromECEF(g.ToECEF())
+}
+
+// ToGeodetic converts p, in this frame, into a geodetic position.
+func (f ENUFrame) ToGeodetic(p vec.Vec3) Geodetic {
+	return FromECEF(f.ToECEF(p))
+}
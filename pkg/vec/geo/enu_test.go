@@ -0,0 +1,68 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestENUFrame_FromGeodetic(t *testing.T) {
+	origin := Geodetic{51.5, -0.12, 20}
+	frame := NewENUFrame(origin)
+
+	tests := []struct {
+		name string
+		g    Geodetic
+		// check tests the direction of the result, as the exact values depend on the ellipsoid
+		check func(p vec.Vec3) bool
+	}{
+		{"origin", origin, func(p vec.Vec3) bool { return p.Magnitude() < 1e-6 }},
+		{"straight up", Geodetic{51.5, -0.12, 120}, func(p vec.Vec3) bool {
+			return p.AlmostEquals(vec.Vec3{Z: 100}, 1e-6)
+		}},
+		{"north", Geodetic{51.51, -0.12, 20}, func(p vec.Vec3) bool {
+			return p.Y > 1100 && math.Abs(p.X) < 1e-6 && p.Z < 0
+		}},
+		{"east", Geodetic{51.5, -0.11, 20}, func(p vec.Vec3) bool {
+			return p.X > 690 && math.Abs(p.Y) < 0.1 && p.Z < 0
+		}},
+		{"south west", Geodetic{51.49, -0.13, 20}, func(p vec.Vec3) bool {
+			return p.X < 0 && p.Y < 0
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := frame.FromGeodetic(tt.g); !tt.check(got) {
+				t.Errorf("FromGeodetic(%v) = %v", tt.g, got)
+			}
+		})
+	}
+}
+
+func TestENUFrame_ToGeodetic(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for range 1000 {
+		origin := Geodetic{r.Float64()*180 - 90, r.Float64()*360 - 180, r.Float64() * 1000}
+		frame := NewENUFrame(origin)
+
+		if got := frame.Origin(); got != origin {
+			t.Fatalf("Origin() = %v, want %v", got, origin)
+		}
+
+		p := vec.Vec3{X: r.Float64()*20000 - 10000, Y: r.Float64()*20000 - 10000, Z: r.Float64()*2000 - 1000}
+		if got := frame.FromGeodetic(frame.ToGeodetic(p)); !got.AlmostEquals(p, 1e-4) {
+			t.Fatalf("round trip of %v from %v = %v", p, origin, got)
+		}
+
+		// distances are preserved, since the frame is only a rotation and translation of ECEF
+		q := vec.Vec3{X: r.Float64()*20000 - 10000, Y: r.Float64()*20000 - 10000, Z: r.Float64()*2000 - 1000}
+		want := p.Subtract(q).Magnitude()
+		if got := frame.ToECEF(p).Subtract(frame.ToECEF(q)).Magnitude(); math.Abs(got-want) > 1e-6 {
+			t.Fatalf("distance between %v and %v = %v in ECEF, want %v", p, q, got, want)
+		}
+	}
+}
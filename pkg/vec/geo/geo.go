@@ -0,0 +1,111 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// The shape of the WGS84 reference ellipsoid, in metres.
+const (
+	// SemiMajorAxis is the radius of the ellipsoid at the equator.
+	SemiMajorAxis = 6378137.0
+	// Flattening is how much the ellipsoid is squashed at the poles, as a fraction of SemiMajorAxis.
+	Flattening = 1 / 298.257223563
+	// SemiMinorAxis is the distance from the centre of the ellipsoid to either pole.
+	SemiMinorAxis = SemiMajorAxis * (1 - Flattening)
+	// MeanRadius is the radius of the sphere used by the spherical calculations in this package, such as [Haversine].
+	MeanRadius = 6371008.8
+)
+
+// eccentricitySquared is the square of the first eccentricity of the ellipsoid.
+const eccentricitySquared = Flattening * (2 - Flattening)
+
+// Geodetic represents a position relative to the WGS84 ellipsoid, as used by GPS.
+//
+// Latitude and Longitude are in degrees. Latitude is positive north of the equator, and Longitude is
+// positive east of the prime meridian. Altitude is the height above the surface of the ellipsoid in metres,
+// which is not quite the same as height above sea level.
+type Geodetic struct {
+	Latitude, Longitude, Altitude float64
+}
+
+// ToECEF returns the position in Earth-centred, Earth-fixed coordinates, in metres. The origin is the centre
+// of the Earth, the positive X axis passes through latitude 0, longitude 0, the positive Z axis passes
+// through the north pole, and the Y axis completes a right-handed system.
+func (g Geodetic) ToECEF() vec.Vec3 {
+	sinLat, cosLat := math.Sincos(radians(g.Latitude))
+	sinLon, cosLon := math.Sincos(radians(g.Longitude))
+
+	// the radius of curvature of the ellipsoid in the east-west direction
+	n := SemiMajorAxis / math.Sqrt(1-eccentricitySquared*sinLat*sinLat)
+
+	return vec.Vec3{
+		X: (n + g.Altitude) * cosLat * cosLon,
+		Y: (n + g.Altitude) * cosLat * sinLon,
+		Z: (n*(1-eccentricitySquared) + g.Altitude) * sinLat,
+	}
+}
+
+// FromECEF returns the geodetic position of p, given in Earth-centred, Earth-fixed coordinates in metres.
+// See [Geodetic.ToECEF] for the axes used.
+//
+// Longitude is in the range (-180, 180], and is 0 at the poles. The conversion is closed-form, using
+// Heikkinen's method, and is accurate to well under a millimetre anywhere near the surface of the Earth.
+// Points deep inside the Earth, near its centre, are not supported and give an undefined result.
+func FromECEF(p vec.Vec3) Geodetic {
+	const (
+		a2 = SemiMajorAxis * SemiMajorAxis
+		b2 = SemiMinorAxis * SemiMinorAxis
+		e2 = eccentricitySquared
+		// the square of the second eccentricity
+		ep2 = (a2 - b2) / b2
+	)
+
+	horizontal := math.Hypot(p.X, p.Y)
+	z2 := p.Z * p.Z
+
+	f := 54 * b2 * z2
+	g := horizontal*horizontal + (1-e2)*z2 - e2*(a2-b2)
+	c := e2 * e2 * f * horizontal * horizontal / (g * g * g)
+	s := math.Cbrt(1 + c + math.Sqrt(c*c+2*c))
+	k := s + 1 + 1/s
+	bigP := f / (3 * k * k * g * g)
+	q := math.Sqrt(1 + 2*e2*e2*bigP)
+	r0 := -bigP*e2*horizontal/(1+q) +
+		math.Sqrt(math.Max(0, a2/2*(1+1/q)-bigP*(1-e2)*z2/(q*(1+q))-bigP*horizontal*horizontal/2))
+	d := horizontal - e2*r0
+	u := math.Sqrt(d*d + z2)
+	v := math.Sqrt(d*d + (1-e2)*z2)
+	z0 := b2 * p.Z / (SemiMajorAxis * v)
+
+	longitude := 0.0
+	if horizontal != 0 {
+		longitude = normaliseLongitude(degrees(math.Atan2(p.Y, p.X)))
+	}
+
+	return Geodetic{
+		Latitude:  degrees(math.Atan2(p.Z+ep2*z0, horizontal)),
+		Longitude: longitude,
+		Altitude:  u * (1 - b2/(SemiMajorAxis*v)),
+	}
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// normaliseLongitude returns the longitude equivalent to lon, in the range (-180, 180].
+func normaliseLongitude(lon float64) float64 {
+	lon = math.Mod(lon, 360)
+	if lon <= -180 {
+		lon += 360
+	} else if lon > 180 {
+		lon -= 360
+	}
+	return lon
+}
@@ -0,0 +1,78 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+func TestGeodetic_ToECEF(t *testing.T) {
+	tests := []struct {
+		name string
+		g    Geodetic
+		want vec.Vec3
+	}{
+		{"null island", Geodetic{0, 0, 0}, vec.Vec3{X: SemiMajorAxis}},
+		{"equator at 90 east", Geodetic{0, 90, 0}, vec.Vec3{Y: SemiMajorAxis}},
+		{"equator at 180", Geodetic{0, 180, 100}, vec.Vec3{X: -SemiMajorAxis - 100}},
+		{"north pole", Geodetic{90, 0, 0}, vec.Vec3{Z: SemiMinorAxis}},
+		{"south pole", Geodetic{-90, 45, 10}, vec.Vec3{Z: -SemiMinorAxis - 10}},
+		// at 45 degrees, the radius of curvature is a / sqrt(1 - e^2 / 2)
+		{"45 north, 45 east", Geodetic{45, 45, 0}, vec.Vec3{
+			X: SemiMajorAxis / math.Sqrt(1-eccentricitySquared/2) / 2,
+			Y: SemiMajorAxis / math.Sqrt(1-eccentricitySquared/2) / 2,
+			Z: SemiMajorAxis / math.Sqrt(1-eccentricitySquared/2) * (1 - eccentricitySquared) / math.Sqrt2,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.g.ToECEF(); !got.AlmostEquals(tt.want, 1e-6) {
+				t.Errorf("ToECEF() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromECEF(t *testing.T) {
+	tests := []struct {
+		name string
+		p    vec.Vec3
+		want Geodetic
+	}{
+		{"null island", vec.Vec3{X: SemiMajorAxis}, Geodetic{0, 0, 0}},
+		{"north pole", vec.Vec3{Z: SemiMinorAxis + 5}, Geodetic{90, 0, 5}},
+		{"south pole", vec.Vec3{Z: -SemiMinorAxis}, Geodetic{-90, 0, 0}},
+		{"equator at 180", vec.Vec3{X: -SemiMajorAxis - 1000}, Geodetic{0, 180, 1000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromECEF(tt.p)
+			if math.Abs(got.Latitude-tt.want.Latitude) > 1e-9 || math.Abs(got.Longitude-tt.want.Longitude) > 1e-9 ||
+				math.Abs(got.Altitude-tt.want.Altitude) > 1e-6 {
+				t.Errorf("FromECEF() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromECEF_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for range 10000 {
+		g := Geodetic{
+			Latitude:  r.Float64()*180 - 90,
+			Longitude: r.Float64()*360 - 180,
+			Altitude:  r.Float64()*50000 - 10000,
+		}
+
+		got := FromECEF(g.ToECEF())
+		if math.Abs(got.Latitude-g.Latitude) > 1e-9 || math.Abs(got.Longitude-g.Longitude) > 1e-9 ||
+			math.Abs(got.Altitude-g.Altitude) > 1e-4 {
+			t.Fatalf("FromECEF(%v.ToECEF()) = %v", g, got)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package vec
+
+import "math"
+
+// Mat2 represents a 2x2 matrix, stored in row-major order such that m[row][col] is the element at that position.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Mat2 being operated upon.
+type Mat2 [2][2]float64
+
+// Identity2 returns the 2x2 identity matrix.
+func Identity2() Mat2 {
+	return Mat2{
+		{1, 0},
+		{0, 1},
+	}
+}
+
+// Add computes m1 + m2.
+func (m1 Mat2) Add(m2 Mat2) Mat2 {
+	var m Mat2
+
+	for i := range 2 {
+		for j := range 2 {
+			m[i][j] = m1[i][j] + m2[i][j]
+		}
+	}
+
+	return m
+}
+
+// Subtract computes m1 - m2.
+func (m1 Mat2) Subtract(m2 Mat2) Mat2 {
+	var m Mat2
+
+	for i := range 2 {
+		for j := range 2 {
+			m[i][j] = m1[i][j] - m2[i][j]
+		}
+	}
+
+	return m
+}
+
+// Multiply returns this matrix multiplied by a scalar value.
+func (m Mat2) Multiply(n float64) Mat2 {
+	var r Mat2
+
+	for i := range 2 {
+		for j := range 2 {
+			r[i][j] = m[i][j] * n
+		}
+	}
+
+	return r
+}
+
+// Mul computes the matrix product m1 * m2.
+func (m1 Mat2) Mul(m2 Mat2) Mat2 {
+	var m Mat2
+
+	for i := range 2 {
+		for j := range 2 {
+			m[i][j] = m1[i][0]*m2[0][j] + m1[i][1]*m2[1][j]
+		}
+	}
+
+	return m
+}
+
+// MulVec2 computes the product m * v, treating v as a column vector.
+func (m Mat2) MulVec2(v Vec2) Vec2 {
+	return Vec2{
+		m[0][0]*v.X + m[0][1]*v.Y,
+		m[1][0]*v.X + m[1][1]*v.Y,
+	}
+}
+
+// Transpose returns the transpose of this matrix.
+func (m Mat2) Transpose() Mat2 {
+	return Mat2{
+		{m[0][0], m[1][0]},
+		{m[0][1], m[1][1]},
+	}
+}
+
+// Determinant returns the determinant of this matrix.
+func (m Mat2) Determinant() float64 {
+	return m[0][0]*m[1][1] - m[0][1]*m[1][0]
+}
+
+// Inverse returns the inverse of this matrix.
+//
+// Since a singular matrix has no inverse, if det(m) = 0 then this function will return an error wrapping [ErrSingular].
+func (m Mat2) Inverse() (Mat2, error) {
+	det := m.Determinant()
+
+	if det == 0 {
+		return Mat2{}, NewOpError("Mat2.Inverse", ErrSingular, m)
+	}
+
+	return Mat2{
+		{m[1][1] / det, -m[0][1] / det},
+		{-m[1][0] / det, m[0][0] / det},
+	}, nil
+}
+
+// Equals returns true if the two matrices are equal.
+func (m1 Mat2) Equals(m2 Mat2) bool {
+	return m1 == m2
+}
+
+// AlmostEquals returns true if the two matrices are almost equal, within some tolerance threshold.
+func (m1 Mat2) AlmostEquals(m2 Mat2, threshold float64) bool {
+	for i := range 2 {
+		for j := range 2 {
+			if math.Abs(m1[i][j]-m2[i][j]) > threshold {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// EqualsWith returns true if the two matrices are close enough to be treated as equal by c.
+func (m1 Mat2) EqualsWith(m2 Mat2, c Comparator) bool {
+	a := make([]float64, 0, 4)
+	b := make([]float64, 0, 4)
+	for i := range 2 {
+		a = append(a, m1[i][:]...)
+		b = append(b, m2[i][:]...)
+	}
+
+	return c.EqualComponents(a, b)
+}
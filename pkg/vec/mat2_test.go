@@ -0,0 +1,147 @@
+package vec
+
+import "testing"
+
+func TestMat2_Mul(t *testing.T) {
+	tests := []struct {
+		name string
+		m1   Mat2
+		m2   Mat2
+		want Mat2
+	}{
+		{
+			name: "I * m = m",
+			m1:   Identity2(),
+			m2:   Mat2{{1, 2}, {3, 4}},
+			want: Mat2{{1, 2}, {3, 4}},
+		},
+		{
+			name: "[[1,2],[3,4]] * [[5,6],[7,8]] = [[19,22],[43,50]]",
+			m1:   Mat2{{1, 2}, {3, 4}},
+			m2:   Mat2{{5, 6}, {7, 8}},
+			want: Mat2{{19, 22}, {43, 50}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m1.Mul(tt.m2); !got.Equals(tt.want) {
+				t.Errorf("m1.Mul(m2) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat2_MulVec2(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat2
+		v    Vec2
+		want Vec2
+	}{
+		{
+			name: "I * (3,4) = (3,4)",
+			m:    Identity2(),
+			v:    Vec2{3, 4},
+			want: Vec2{3, 4},
+		},
+		{
+			name: "rotate (1,0) by pi/2 = (0,1)",
+			m:    Mat2{{0, -1}, {1, 0}},
+			v:    Vec2{1, 0},
+			want: Vec2{0, 1},
+		},
+		{
+			name: "[[1,2],[3,4]] * (1,1) = (3,7)",
+			m:    Mat2{{1, 2}, {3, 4}},
+			v:    Vec2{1, 1},
+			want: Vec2{3, 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.MulVec2(tt.v); !got.Equals(tt.want) {
+				t.Errorf("m.MulVec2(v) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat2_Determinant(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat2
+		want float64
+	}{
+		{
+			name: "det(I) = 1",
+			m:    Identity2(),
+			want: 1,
+		},
+		{
+			name: "det([[1,2],[3,4]]) = -2",
+			m:    Mat2{{1, 2}, {3, 4}},
+			want: -2,
+		},
+		{
+			name: "det([[1,2],[2,4]]) = 0",
+			m:    Mat2{{1, 2}, {2, 4}},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Determinant(); got != tt.want {
+				t.Errorf("m.Determinant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat2_Inverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Mat2
+		want    Mat2
+		wantErr bool
+	}{
+		{
+			name:    "inverse(I) = I",
+			m:       Identity2(),
+			want:    Identity2(),
+			wantErr: false,
+		},
+		{
+			name:    "inverse([[1,2],[3,4]]) = [[-2,1],[1.5,-0.5]]",
+			m:       Mat2{{1, 2}, {3, 4}},
+			want:    Mat2{{-2, 1}, {1.5, -0.5}},
+			wantErr: false,
+		},
+		{
+			name:    "inverse([[1,2],[2,4]]) = error",
+			m:       Mat2{{1, 2}, {2, 4}},
+			want:    Mat2{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Inverse()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("m.Inverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("m.Inverse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat2_Transpose(t *testing.T) {
+	m := Mat2{{1, 2}, {3, 4}}
+	want := Mat2{{1, 3}, {2, 4}}
+
+	if got := m.Transpose(); !got.Equals(want) {
+		t.Errorf("m.Transpose() = %v, want %v", got, want)
+	}
+}
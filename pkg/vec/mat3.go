@@ -0,0 +1,158 @@
+package vec
+
+import "math"
+
+// Mat3 represents a 3x3 matrix, stored in row-major order such that m[row][col] is the element at that position.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Mat3 being operated upon.
+type Mat3 [3][3]float64
+
+// Identity3 returns the 3x3 identity matrix.
+func Identity3() Mat3 {
+	return Mat3{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+}
+
+// Add computes m1 + m2.
+func (m1 Mat3) Add(m2 Mat3) Mat3 {
+	var m Mat3
+
+	for i := range 3 {
+		for j := range 3 {
+			m[i][j] = m1[i][j] + m2[i][j]
+		}
+	}
+
+	return m
+}
+
+// Subtract computes m1 - m2.
+func (m1 Mat3) Subtract(m2 Mat3) Mat3 {
+	var m Mat3
+
+	for i := range 3 {
+		for j := range 3 {
+			m[i][j] = m1[i][j] - m2[i][j]
+		}
+	}
+
+	return m
+}
+
+// Multiply returns this matrix multiplied by a scalar value.
+func (m Mat3) Multiply(n float64) Mat3 {
+	var r Mat3
+
+	for i := range 3 {
+		for j := range 3 {
+			r[i][j] = m[i][j] * n
+		}
+	}
+
+	return r
+}
+
+// Mul computes the matrix product m1 * m2.
+func (m1 Mat3) Mul(m2 Mat3) Mat3 {
+	var m Mat3
+
+	for i := range 3 {
+		for j := range 3 {
+			m[i][j] = m1[i][0]*m2[0][j] + m1[i][1]*m2[1][j] + m1[i][2]*m2[2][j]
+		}
+	}
+
+	return m
+}
+
+// MulVec3 computes the product m * v, treating v as a column vector.
+func (m Mat3) MulVec3(v Vec3) Vec3 {
+	return Vec3{
+		m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z,
+		m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z,
+		m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+// Transpose returns the transpose of this matrix.
+func (m Mat3) Transpose() Mat3 {
+	var r Mat3
+
+	for i := range 3 {
+		for j := range 3 {
+			r[i][j] = m[j][i]
+		}
+	}
+
+	return r
+}
+
+// Determinant returns the determinant of this matrix.
+func (m Mat3) Determinant() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+// Inverse returns the inverse of this matrix.
+//
+// Since a singular matrix has no inverse, if det(m) = 0 then this function will return an error wrapping [ErrSingular].
+func (m Mat3) Inverse() (Mat3, error) {
+	det := m.Determinant()
+
+	if det == 0 {
+		return Mat3{}, NewOpError("Mat3.Inverse", ErrSingular, m)
+	}
+
+	// the inverse is the adjugate (transposed cofactor matrix) divided by the determinant
+	return Mat3{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det,
+		},
+	}, nil
+}
+
+// Equals returns true if the two matrices are equal.
+func (m1 Mat3) Equals(m2 Mat3) bool {
+	return m1 == m2
+}
+
+// AlmostEquals returns true if the two matrices are almost equal, within some tolerance threshold.
+func (m1 Mat3) AlmostEquals(m2 Mat3, threshold float64) bool {
+	for i := range 3 {
+		for j := range 3 {
+			if math.Abs(m1[i][j]-m2[i][j]) > threshold {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// EqualsWith returns true if the two matrices are close enough to be treated as equal by c.
+func (m1 Mat3) EqualsWith(m2 Mat3, c Comparator) bool {
+	a := make([]float64, 0, 9)
+	b := make([]float64, 0, 9)
+	for i := range 3 {
+		a = append(a, m1[i][:]...)
+		b = append(b, m2[i][:]...)
+	}
+
+	return c.EqualComponents(a, b)
+}
@@ -0,0 +1,143 @@
+package vec
+
+import "testing"
+
+func TestMat3_Mul(t *testing.T) {
+	tests := []struct {
+		name string
+		m1   Mat3
+		m2   Mat3
+		want Mat3
+	}{
+		{
+			name: "I * m = m",
+			m1:   Identity3(),
+			m2:   Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+			want: Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+		},
+		{
+			name: "m * I = m",
+			m1:   Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+			m2:   Identity3(),
+			want: Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+		},
+		{
+			name: "[[1,2,3],[4,5,6],[7,8,9]] * [[9,8,7],[6,5,4],[3,2,1]]",
+			m1:   Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+			m2:   Mat3{{9, 8, 7}, {6, 5, 4}, {3, 2, 1}},
+			want: Mat3{{30, 24, 18}, {84, 69, 54}, {138, 114, 90}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m1.Mul(tt.m2); !got.Equals(tt.want) {
+				t.Errorf("m1.Mul(m2) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat3_MulVec3(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat3
+		v    Vec3
+		want Vec3
+	}{
+		{
+			name: "I * (1,2,3) = (1,2,3)",
+			m:    Identity3(),
+			v:    Vec3{1, 2, 3},
+			want: Vec3{1, 2, 3},
+		},
+		{
+			name: "rotate (1,0,0) by pi/2 about z = (0,1,0)",
+			m:    Mat3{{0, -1, 0}, {1, 0, 0}, {0, 0, 1}},
+			v:    Vec3{1, 0, 0},
+			want: Vec3{0, 1, 0},
+		},
+		{
+			name: "scale (1,2,3) by (2,3,4) = (2,6,12)",
+			m:    Mat3{{2, 0, 0}, {0, 3, 0}, {0, 0, 4}},
+			v:    Vec3{1, 2, 3},
+			want: Vec3{2, 6, 12},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.MulVec3(tt.v); !got.Equals(tt.want) {
+				t.Errorf("m.MulVec3(v) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat3_Determinant(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat3
+		want float64
+	}{
+		{
+			name: "det(I) = 1",
+			m:    Identity3(),
+			want: 1,
+		},
+		{
+			name: "det([[1,2,3],[4,5,6],[7,8,9]]) = 0",
+			m:    Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+			want: 0,
+		},
+		{
+			name: "det([[2,0,1],[1,3,2],[1,1,2]]) = 6",
+			m:    Mat3{{2, 0, 1}, {1, 3, 2}, {1, 1, 2}},
+			want: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Determinant(); got != tt.want {
+				t.Errorf("m.Determinant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat3_Inverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Mat3
+		wantErr bool
+	}{
+		{
+			name:    "inverse(I)",
+			m:       Identity3(),
+			wantErr: false,
+		},
+		{
+			name:    "inverse([[2,0,1],[1,3,2],[1,1,2]])",
+			m:       Mat3{{2, 0, 1}, {1, 3, 2}, {1, 1, 2}},
+			wantErr: false,
+		},
+		{
+			name:    "inverse([[1,2,3],[4,5,6],[7,8,9]]) = error",
+			m:       Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Inverse()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("m.Inverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if product := tt.m.Mul(got); !product.AlmostEquals(Identity3(), 1e-12) {
+				t.Errorf("m * m.Inverse() = %v, want identity", product)
+			}
+		})
+	}
+}
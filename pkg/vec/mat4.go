@@ -0,0 +1,214 @@
+package vec
+
+import "math"
+
+// Mat4 represents a 4x4 matrix, stored in row-major order such that m[row][col] is the element at that position.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Mat4 being operated upon.
+//
+// A Mat4 is most commonly used to represent an affine or projective transform in 3D space.
+type Mat4 [4][4]float64
+
+// Identity4 returns the 4x4 identity matrix.
+func Identity4() Mat4 {
+	return Mat4{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Add computes m1 + m2.
+func (m1 Mat4) Add(m2 Mat4) Mat4 {
+	var m Mat4
+
+	for i := range 4 {
+		for j := range 4 {
+			m[i][j] = m1[i][j] + m2[i][j]
+		}
+	}
+
+	return m
+}
+
+// Subtract computes m1 - m2.
+func (m1 Mat4) Subtract(m2 Mat4) Mat4 {
+	var m Mat4
+
+	for i := range 4 {
+		for j := range 4 {
+			m[i][j] = m1[i][j] - m2[i][j]
+		}
+	}
+
+	return m
+}
+
+// Multiply returns this matrix multiplied by a scalar value.
+func (m Mat4) Multiply(n float64) Mat4 {
+	var r Mat4
+
+	for i := range 4 {
+		for j := range 4 {
+			r[i][j] = m[i][j] * n
+		}
+	}
+
+	return r
+}
+
+// Mul computes the matrix product m1 * m2.
+func (m1 Mat4) Mul(m2 Mat4) Mat4 {
+	var m Mat4
+
+	for i := range 4 {
+		for j := range 4 {
+			m[i][j] = m1[i][0]*m2[0][j] + m1[i][1]*m2[1][j] + m1[i][2]*m2[2][j] + m1[i][3]*m2[3][j]
+		}
+	}
+
+	return m
+}
+
+// MulVec4 computes the product m * v, treating v as a column vector.
+func (m Mat4) MulVec4(v Vec4) Vec4 {
+	return Vec4{
+		m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z + m[0][3]*v.W,
+		m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z + m[1][3]*v.W,
+		m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z + m[2][3]*v.W,
+		m[3][0]*v.X + m[3][1]*v.Y + m[3][2]*v.Z + m[3][3]*v.W,
+	}
+}
+
+// MulPoint transforms the point p by this matrix, treating p as the column vector (p.X, p.Y, p.Z, 1).
+//
+// The resulting w component is discarded, so this is only correct for affine transforms.
+// For projective transforms, use [Mat4.MulVec4] followed by [Vec4.PerspectiveDivide].
+func (m Mat4) MulPoint(p Vec3) Vec3 {
+	return Vec3{
+		m[0][0]*p.X + m[0][1]*p.Y + m[0][2]*p.Z + m[0][3],
+		m[1][0]*p.X + m[1][1]*p.Y + m[1][2]*p.Z + m[1][3],
+		m[2][0]*p.X + m[2][1]*p.Y + m[2][2]*p.Z + m[2][3],
+	}
+}
+
+// MulDirection transforms the direction d by this matrix, treating d as the column vector (d.X, d.Y, d.Z, 0).
+//
+// Unlike [Mat4.MulPoint], the translation part of the matrix has no effect on the result.
+func (m Mat4) MulDirection(d Vec3) Vec3 {
+	return Vec3{
+		m[0][0]*d.X + m[0][1]*d.Y + m[0][2]*d.Z,
+		m[1][0]*d.X + m[1][1]*d.Y + m[1][2]*d.Z,
+		m[2][0]*d.X + m[2][1]*d.Y + m[2][2]*d.Z,
+	}
+}
+
+// Transpose returns the transpose of this matrix.
+func (m Mat4) Transpose() Mat4 {
+	var r Mat4
+
+	for i := range 4 {
+		for j := range 4 {
+			r[i][j] = m[j][i]
+		}
+	}
+
+	return r
+}
+
+// Determinant returns the determinant of this matrix.
+func (m Mat4) Determinant() float64 {
+	// Laplace expansion using the 2x2 minors of the top two and bottom two rows
+	s0 := m[0][0]*m[1][1] - m[1][0]*m[0][1]
+	s1 := m[0][0]*m[1][2] - m[1][0]*m[0][2]
+	s2 := m[0][0]*m[1][3] - m[1][0]*m[0][3]
+	s3 := m[0][1]*m[1][2] - m[1][1]*m[0][2]
+	s4 := m[0][1]*m[1][3] - m[1][1]*m[0][3]
+	s5 := m[0][2]*m[1][3] - m[1][2]*m[0][3]
+
+	c5 := m[2][2]*m[3][3] - m[3][2]*m[2][3]
+	c4 := m[2][1]*m[3][3] - m[3][1]*m[2][3]
+	c3 := m[2][1]*m[3][2] - m[3][1]*m[2][2]
+	c2 := m[2][0]*m[3][3] - m[3][0]*m[2][3]
+	c1 := m[2][0]*m[3][2] - m[3][0]*m[2][2]
+	c0 := m[2][0]*m[3][1] - m[3][0]*m[2][1]
+
+	return s0*c5 - s1*c4 + s2*c3 + s3*c2 - s4*c1 + s5*c0
+}
+
+// Inverse returns the inverse of this matrix.
+//
+// Since a singular matrix has no inverse, if det(m) = 0 then this function will return an error wrapping [ErrSingular].
+func (m Mat4) Inverse() (Mat4, error) {
+	// Gauss-Jordan elimination with partial pivoting, reducing a to the identity while applying
+	// the same row operations to inv
+	a := m
+	inv := Identity4()
+
+	for col := range 4 {
+		pivot := col
+		for row := col + 1; row < 4; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+
+		if a[pivot][col] == 0 {
+			return Mat4{}, NewOpError("Mat4.Inverse", ErrSingular, m)
+		}
+
+		a[col], a[pivot] = a[pivot], a[col]
+		inv[col], inv[pivot] = inv[pivot], inv[col]
+
+		scale := a[col][col]
+		for j := range 4 {
+			a[col][j] /= scale
+			inv[col][j] /= scale
+		}
+
+		for row := range 4 {
+			if row == col {
+				continue
+			}
+
+			factor := a[row][col]
+			for j := range 4 {
+				a[row][j] -= factor * a[col][j]
+				inv[row][j] -= factor * inv[col][j]
+			}
+		}
+	}
+
+	return inv, nil
+}
+
+// Equals returns true if the two matrices are equal.
+func (m1 Mat4) Equals(m2 Mat4) bool {
+	return m1 == m2
+}
+
+// AlmostEquals returns true if the two matrices are almost equal, within some tolerance threshold.
+func (m1 Mat4) AlmostEquals(m2 Mat4, threshold float64) bool {
+	for i := range 4 {
+		for j := range 4 {
+			if math.Abs(m1[i][j]-m2[i][j]) > threshold {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// EqualsWith returns true if the two matrices are close enough to be treated as equal by c.
+func (m1 Mat4) EqualsWith(m2 Mat4, c Comparator) bool {
+	a := make([]float64, 0, 16)
+	b := make([]float64, 0, 16)
+	for i := range 4 {
+		a = append(a, m1[i][:]...)
+		b = append(b, m2[i][:]...)
+	}
+
+	return c.EqualComponents(a, b)
+}
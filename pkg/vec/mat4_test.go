@@ -0,0 +1,134 @@
+package vec
+
+import "testing"
+
+func TestMat4_MulPoint(t *testing.T) {
+	translate := Mat4{
+		{1, 0, 0, 1},
+		{0, 1, 0, 2},
+		{0, 0, 1, 3},
+		{0, 0, 0, 1},
+	}
+
+	tests := []struct {
+		name string
+		m    Mat4
+		p    Vec3
+		want Vec3
+	}{
+		{
+			name: "I * (1,2,3) = (1,2,3)",
+			m:    Identity4(),
+			p:    Vec3{1, 2, 3},
+			want: Vec3{1, 2, 3},
+		},
+		{
+			name: "translate (0,0,0) by (1,2,3) = (1,2,3)",
+			m:    translate,
+			p:    Vec3{0, 0, 0},
+			want: Vec3{1, 2, 3},
+		},
+		{
+			name: "translate (1,1,1) by (1,2,3) = (2,3,4)",
+			m:    translate,
+			p:    Vec3{1, 1, 1},
+			want: Vec3{2, 3, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.MulPoint(tt.p); !got.Equals(tt.want) {
+				t.Errorf("m.MulPoint(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat4_MulDirection(t *testing.T) {
+	translate := Mat4{
+		{1, 0, 0, 1},
+		{0, 1, 0, 2},
+		{0, 0, 1, 3},
+		{0, 0, 0, 1},
+	}
+	d := Vec3{1, 1, 1}
+
+	if got := translate.MulDirection(d); !got.Equals(d) {
+		t.Errorf("m.MulDirection(d) = %v, want %v", got, d)
+	}
+}
+
+func TestMat4_Determinant(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat4
+		want float64
+	}{
+		{
+			name: "det(I) = 1",
+			m:    Identity4(),
+			want: 1,
+		},
+		{
+			name: "det(diag(1,2,3,4)) = 24",
+			m:    Mat4{{1, 0, 0, 0}, {0, 2, 0, 0}, {0, 0, 3, 0}, {0, 0, 0, 4}},
+			want: 24,
+		},
+		{
+			name: "det(rank 2) = 0",
+			m:    Mat4{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}, {13, 14, 15, 16}},
+			want: 0,
+		},
+		{
+			name: "det(general) = -376",
+			m:    Mat4{{1, 3, 5, 9}, {1, 3, 1, 7}, {4, 3, 9, 7}, {5, 2, 0, 9}},
+			want: -376,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Determinant(); got != tt.want {
+				t.Errorf("m.Determinant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMat4_Inverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Mat4
+		wantErr bool
+	}{
+		{
+			name:    "inverse(I)",
+			m:       Identity4(),
+			wantErr: false,
+		},
+		{
+			name:    "inverse(general)",
+			m:       Mat4{{1, 3, 5, 9}, {1, 3, 1, 7}, {4, 3, 9, 7}, {5, 2, 0, 9}},
+			wantErr: false,
+		},
+		{
+			name:    "inverse(rank 2) = error",
+			m:       Mat4{{1, 2, 3, 4}, {2, 4, 6, 8}, {0, 0, 1, 0}, {0, 0, 0, 1}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Inverse()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("m.Inverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if product := tt.m.Mul(got); !product.AlmostEquals(Identity4(), 1e-12) {
+				t.Errorf("m * m.Inverse() = %v, want identity", product)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package vec
+
+// Number is a constraint that permits any integer or floating point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Float is a constraint that permits any floating point type.
+type Float interface {
+	~float32 | ~float64
+}
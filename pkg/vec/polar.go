@@ -0,0 +1,80 @@
+package vec
+
+import "math"
+
+// Polar represents a point in 2D space by its distance from the origin, and the angle in radians
+// anticlockwise from the positive X axis.
+//
+// Many points have more than one representation, as adding 2pi to the angle or negating the radius
+// and turning by pi reach the same point. [Vec2.ToPolar] and [Polar.Normalised] always give the canonical
+// one, with a non-negative radius and an angle in the range (-pi, pi]. The origin is represented with an
+// angle of 0.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Polar being operated upon.
+type Polar struct {
+	Radius, Angle float64
+}
+
+// ToVec2 returns the point in Cartesian coordinates.
+func (p Polar) ToVec2() Vec2 {
+	sin, cos := math.Sincos(p.Angle)
+
+	return Vec2{
+		p.Radius * cos,
+		p.Radius * sin,
+	}
+}
+
+// Normalised returns the canonical representation of the same point, with a non-negative radius and an
+// angle in the range (-pi, pi]. If the radius is 0, the angle is 0.
+func (p Polar) Normalised() Polar {
+	if p.Radius == 0 {
+		return Polar{}
+	}
+
+	if p.Radius < 0 {
+		p = Polar{-p.Radius, p.Angle + math.Pi}
+	}
+
+	return Polar{p.Radius, NormaliseAngleSigned(p.Angle)}
+}
+
+// Lerp interpolates between p1 and p2 by factor t, linearly in radius and angle. The angle turns the short
+// way round, so the result sweeps through at most half a turn.
+//
+// At t = 0, the result of this function is equivalent to p1.
+//
+// At t = 1, the result of this function is equivalent to p2.
+//
+// The result is normalised. The origin has no meaningful angle, so if either point is at the origin, the
+// angle of the other is used throughout.
+//
+// No safeguards are in place for vales of t that do not satisfy 0 <= t <= 1.
+// Instead, this will extrapolate beyond p1 or p2.
+func (p1 Polar) Lerp(p2 Polar, t float64) Polar {
+	p1, p2 = p1.Normalised(), p2.Normalised()
+
+	if p1.Radius == 0 {
+		p1.Angle = p2.Angle
+	}
+	if p2.Radius == 0 {
+		p2.Angle = p1.Angle
+	}
+
+	return Polar{
+		p1.Radius + t*(p2.Radius-p1.Radius),
+		LerpAngle(p1.Angle, p2.Angle, t),
+	}.Normalised()
+}
+
+// Equals returns true if the two representations are identical. Different representations of the same
+// point are not equal unless both are normalised first.
+func (p1 Polar) Equals(p2 Polar) bool {
+	return p1.Radius == p2.Radius && p1.Angle == p2.Angle
+}
+
+// AlmostEquals returns true if the two representations are almost identical, within some tolerance threshold.
+// Angles are compared the short way round, so angles either side of pi can still be almost equal.
+func (p1 Polar) AlmostEquals(p2 Polar, threshold float64) bool {
+	return math.Abs(p1.Radius-p2.Radius) <= threshold && math.Abs(AngleDifference(p1.Angle, p2.Angle)) <= threshold
+}
@@ -0,0 +1,99 @@
+package vec
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestVec2_ToPolar(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Vec2
+		want Polar
+	}{
+		{"origin", Vec2{0, 0}, Polar{0, 0}},
+		{"negative zero origin", Vec2{math.Copysign(0, -1), math.Copysign(0, -1)}, Polar{0, 0}},
+		{"positive X", Vec2{2, 0}, Polar{2, 0}},
+		{"positive Y", Vec2{0, 3}, Polar{3, math.Pi / 2}},
+		{"negative X", Vec2{-1, 0}, Polar{1, math.Pi}},
+		{"negative X, negative zero Y", Vec2{-1, math.Copysign(0, -1)}, Polar{1, math.Pi}},
+		{"third quadrant", Vec2{-1, -1}, Polar{math.Sqrt2, -3 * math.Pi / 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.v.ToPolar()
+			if math.Abs(got.Radius-tt.want.Radius) > 1e-12 || math.Abs(got.Angle-tt.want.Angle) > 1e-12 {
+				t.Errorf("ToPolar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolar_ToVec2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for range 1000 {
+		v := Vec2{r.Float64()*200 - 100, r.Float64()*200 - 100}
+		if got := v.ToPolar().ToVec2(); !got.AlmostEquals(v, 1e-12) {
+			t.Fatalf("%v.ToPolar().ToVec2() = %v", v, got)
+		}
+
+		p := Polar{r.Float64() * 100, r.Float64()*2*math.Pi - math.Pi}
+		if got := p.ToVec2().ToPolar(); !got.AlmostEquals(p, 1e-12) {
+			t.Fatalf("%v.ToVec2().ToPolar() = %v", p, got)
+		}
+	}
+}
+
+func TestPolar_Normalised(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Polar
+		want Polar
+	}{
+		{"already normalised", Polar{1, 1}, Polar{1, 1}},
+		{"large angle", Polar{2, 5 * math.Pi / 2}, Polar{2, math.Pi / 2}},
+		{"negative radius", Polar{-1, math.Pi / 4}, Polar{1, -3 * math.Pi / 4}},
+		{"origin", Polar{0, 3}, Polar{0, 0}},
+		{"minus pi", Polar{1, -math.Pi}, Polar{1, math.Pi}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.p.Normalised()
+			if math.Abs(got.Radius-tt.want.Radius) > 1e-12 || math.Abs(got.Angle-tt.want.Angle) > 1e-12 {
+				t.Errorf("Normalised() = %v, want %v", got, tt.want)
+			}
+			if !got.ToVec2().AlmostEquals(tt.p.ToVec2(), 1e-12) {
+				t.Errorf("Normalised() moved the point from %v to %v", tt.p.ToVec2(), got.ToVec2())
+			}
+		})
+	}
+}
+
+func TestPolar_Lerp(t *testing.T) {
+	tests := []struct {
+		name   string
+		p1, p2 Polar
+		t      float64
+		want   Polar
+	}{
+		{"start", Polar{1, 0}, Polar{3, 1}, 0, Polar{1, 0}},
+		{"end", Polar{1, 0}, Polar{3, 1}, 1, Polar{3, 1}},
+		{"middle", Polar{1, 0}, Polar{3, 1}, 0.5, Polar{2, 0.5}},
+		{"short way round", Polar{1, 3}, Polar{1, -3}, 0.5, Polar{1, math.Pi}},
+		{"from the origin", Polar{0, 0}, Polar{2, 2}, 0.5, Polar{1, 2}},
+		{"to the origin", Polar{2, -2}, Polar{0, 0}, 0.25, Polar{1.5, -2}},
+		{"unnormalised input", Polar{-1, 0}, Polar{1, 3}, 0.5, Polar{1, (math.Pi + 3) / 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p1.Lerp(tt.p2, tt.t); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("Lerp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
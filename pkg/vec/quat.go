@@ -0,0 +1,267 @@
+package vec
+
+import "math"
+
+// Quat represents a quaternion W + Xi + Yj + Zk.
+// Unit quaternions are used to represent rotations in 3D space, avoiding the gimbal lock that
+// affects Euler angles.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Quat being operated upon.
+type Quat struct {
+	W, X, Y, Z float64
+}
+
+// IdentityQuat returns the quaternion representing no rotation.
+func IdentityQuat() Quat {
+	return Quat{1, 0, 0, 0}
+}
+
+// QuatFromAxisAngle returns the unit quaternion representing a rotation of angle radians about axis.
+// The rotation follows the right-hand rule: looking down the axis towards the origin, positive
+// angles rotate anticlockwise.
+//
+// The axis does not need to be normalised, but since a 0-length axis has no direction,
+// if |axis| = 0 then this function will return an error wrapping [ErrZeroLength].
+func QuatFromAxisAngle(axis Vec3, angle float64) (Quat, error) {
+	unit, err := axis.Normalised()
+	if err != nil {
+		return Quat{}, NewOpError("QuatFromAxisAngle", ErrZeroLength, axis, angle)
+	}
+	axis = unit
+
+	sin, cos := math.Sincos(angle / 2)
+
+	return Quat{
+		cos,
+		axis.X * sin,
+		axis.Y * sin,
+		axis.Z * sin,
+	}, nil
+}
+
+// QuatFromMat3 returns the unit quaternion representing the same rotation as m.
+//
+// m is assumed to be a pure rotation matrix, i.e. orthonormal with a determinant of 1.
+// If it is not, the result is undefined.
+func QuatFromMat3(m Mat3) Quat {
+	// Shepperd's method: pick the largest of the four diagonal combinations to divide by,
+	// which keeps the computation numerically stable
+	trace := m[0][0] + m[1][1] + m[2][2]
+
+	var q Quat
+
+	switch {
+	case trace > 0:
+		s := 2 * math.Sqrt(trace+1)
+		q = Quat{
+			s / 4,
+			(m[2][1] - m[1][2]) / s,
+			(m[0][2] - m[2][0]) / s,
+			(m[1][0] - m[0][1]) / s,
+		}
+	case m[0][0] > m[1][1] && m[0][0] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[0][0]-m[1][1]-m[2][2])
+		q = Quat{
+			(m[2][1] - m[1][2]) / s,
+			s / 4,
+			(m[0][1] + m[1][0]) / s,
+			(m[0][2] + m[2][0]) / s,
+		}
+	case m[1][1] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[1][1]-m[0][0]-m[2][2])
+		q = Quat{
+			(m[0][2] - m[2][0]) / s,
+			(m[0][1] + m[1][0]) / s,
+			s / 4,
+			(m[1][2] + m[2][1]) / s,
+		}
+	default:
+		s := 2 * math.Sqrt(1+m[2][2]-m[0][0]-m[1][1])
+		q = Quat{
+			(m[1][0] - m[0][1]) / s,
+			(m[0][2] + m[2][0]) / s,
+			(m[1][2] + m[2][1]) / s,
+			s / 4,
+		}
+	}
+
+	return q
+}
+
+// Add computes q1 + q2.
+func (q1 Quat) Add(q2 Quat) Quat {
+	return Quat{
+		q1.W + q2.W,
+		q1.X + q2.X,
+		q1.Y + q2.Y,
+		q1.Z + q2.Z,
+	}
+}
+
+// Multiply returns this quaternion multiplied by a scalar value.
+func (q Quat) Multiply(n float64) Quat {
+	return Quat{
+		q.W * n,
+		q.X * n,
+		q.Y * n,
+		q.Z * n,
+	}
+}
+
+// Mul computes the Hamilton product q1 * q2.
+//
+// When both are rotations, the result represents the rotation q2 followed by the rotation q1.
+func (q1 Quat) Mul(q2 Quat) Quat {
+	return Quat{
+		q1.W*q2.W - q1.X*q2.X - q1.Y*q2.Y - q1.Z*q2.Z,
+		q1.W*q2.X + q1.X*q2.W + q1.Y*q2.Z - q1.Z*q2.Y,
+		q1.W*q2.Y - q1.X*q2.Z + q1.Y*q2.W + q1.Z*q2.X,
+		q1.W*q2.Z + q1.X*q2.Y - q1.Y*q2.X + q1.Z*q2.W,
+	}
+}
+
+// Dot computes the dot product between q1 and q2, treating them as 4D vectors.
+func (q1 Quat) Dot(q2 Quat) float64 {
+	return q1.W*q2.W + q1.X*q2.X + q1.Y*q2.Y + q1.Z*q2.Z
+}
+
+// Magnitude returns the norm of this quaternion.
+func (q Quat) Magnitude() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Normalised returns the quaternion in the same direction as this quaternion with a norm of 1.
+//
+// Since a 0-length quaternion has no direction, if |q| = 0 then this function will return an error wrapping [ErrZeroLength].
+func (q Quat) Normalised() (Quat, error) {
+	magnitude := q.Magnitude()
+
+	if magnitude == 0 {
+		return Quat{}, NewOpError("Quat.Normalised", ErrZeroLength, q)
+	}
+
+	return q.Multiply(1 / magnitude), nil
+}
+
+// Conjugate returns the conjugate of this quaternion, W - Xi - Yj - Zk.
+//
+// For unit quaternions, this is equal to the inverse and represents the opposite rotation.
+func (q Quat) Conjugate() Quat {
+	return Quat{q.W, -q.X, -q.Y, -q.Z}
+}
+
+// Inverse returns the multiplicative inverse of this quaternion.
+//
+// Since a 0-length quaternion has no inverse, if |q| = 0 then this function will return an error wrapping [ErrZeroLength].
+func (q Quat) Inverse() (Quat, error) {
+	normSquared := q.Dot(q)
+
+	if normSquared == 0 {
+		return Quat{}, NewOpError("Quat.Inverse", ErrZeroLength, q)
+	}
+
+	return q.Conjugate().Multiply(1 / normSquared), nil
+}
+
+// Rotate returns v rotated by this quaternion.
+//
+// q is assumed to be a unit quaternion. If it is not, the result will also be scaled by |q|^2.
+func (q Quat) Rotate(v Vec3) Vec3 {
+	// v' = v + 2w(u x v) + 2(u x (u x v)), an expansion of q * v * q^-1
+	u := Vec3{q.X, q.Y, q.Z}
+	t := u.Cross(v).Multiply(2)
+
+	return v.Add(t.Multiply(q.W)).Add(u.Cross(t))
+}
+
+// Slerp spherically interpolates between the rotations q1 and q2 by factor t, taking the shortest path.
+//
+// At t = 0, the result of this function is equal to q1.
+//
+// At t = 1, the result of this function represents the same rotation as q2.
+//
+// q1 and q2 are assumed to be unit quaternions.
+//
+// No safeguards are in place for vales of t that do not satisfy 0 <= t <= 1.
+// Instead, this will continue the rotation beyond q1 or q2.
+//
+// If you wish to have the result clamped between q1 and q2, use [Quat.SlerpClamped].
+func (q1 Quat) Slerp(q2 Quat, t float64) Quat {
+	cos := q1.Dot(q2)
+
+	// q and -q represent the same rotation, so flip q2 if necessary to take the short way round
+	if cos < 0 {
+		q2 = q2.Multiply(-1)
+		cos = -cos
+	}
+
+	// when the rotations are very close, sin(theta) approaches 0, so fall back to a normalised lerp
+	if cos > 0.9995 {
+		q := Quat{
+			q1.W + t*(q2.W-q1.W),
+			q1.X + t*(q2.X-q1.X),
+			q1.Y + t*(q2.Y-q1.Y),
+			q1.Z + t*(q2.Z-q1.Z),
+		}
+		return q.Multiply(1 / q.Magnitude())
+	}
+
+	theta := math.Acos(cos)
+	sin := math.Sin(theta)
+
+	return q1.Multiply(math.Sin((1-t)*theta) / sin).Add(q2.Multiply(math.Sin(t*theta) / sin))
+}
+
+// SlerpClamped spherically interpolates between the rotations q1 and q2 by factor t, clamping the result between q1 and q2.
+//
+// At t <= 0, the result of this function is equal to q1.
+//
+// At t >= 1, the result of this function represents the same rotation as q2.
+func (q1 Quat) SlerpClamped(q2 Quat, t float64) Quat {
+	var t_clamped float64
+
+	if t < 0 {
+		t_clamped = 0
+	} else if t > 1 {
+		t_clamped = 1
+	} else {
+		t_clamped = t
+	}
+
+	return q1.Slerp(q2, t_clamped)
+}
+
+// ToMat3 returns the rotation matrix equivalent to this quaternion.
+//
+// q is assumed to be a unit quaternion.
+func (q Quat) ToMat3() Mat3 {
+	xx, yy, zz := q.X*q.X, q.Y*q.Y, q.Z*q.Z
+	xy, xz, yz := q.X*q.Y, q.X*q.Z, q.Y*q.Z
+	wx, wy, wz := q.W*q.X, q.W*q.Y, q.W*q.Z
+
+	return Mat3{
+		{1 - 2*(yy+zz), 2 * (xy - wz), 2 * (xz + wy)},
+		{2 * (xy + wz), 1 - 2*(xx+zz), 2 * (yz - wx)},
+		{2 * (xz - wy), 2 * (yz + wx), 1 - 2*(xx+yy)},
+	}
+}
+
+// Equals returns true if the two quaternions are equal.
+//
+// Note that q and -q represent the same rotation, but are not considered equal by this function.
+func (q1 Quat) Equals(q2 Quat) bool {
+	return q1.W == q2.W && q1.X == q2.X && q1.Y == q2.Y && q1.Z == q2.Z
+}
+
+// AlmostEquals returns true if the two quaternions are almost equal, within some tolerance threshold.
+func (q1 Quat) AlmostEquals(q2 Quat, threshold float64) bool {
+	return math.Abs(q1.W-q2.W) <= threshold && math.Abs(q1.X-q2.X) <= threshold &&
+		math.Abs(q1.Y-q2.Y) <= threshold && math.Abs(q1.Z-q2.Z) <= threshold
+}
+
+// EqualsWith returns true if the two quaternions are close enough to be treated as equal by c.
+//
+// Like [Quat.Equals], this compares components, so q and -q are not equal even though they represent the same rotation.
+func (q1 Quat) EqualsWith(q2 Quat, c Comparator) bool {
+	return c.EqualComponents([]float64{q1.W, q1.X, q1.Y, q1.Z}, []float64{q2.W, q2.X, q2.Y, q2.Z})
+}
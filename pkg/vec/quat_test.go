@@ -0,0 +1,256 @@
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuatFromAxisAngle(t *testing.T) {
+	tests := []struct {
+		name    string
+		axis    Vec3
+		angle   float64
+		want    Quat
+		wantErr bool
+	}{
+		{
+			name:    "(0,0,0), pi = error",
+			axis:    Vec3{0, 0, 0},
+			angle:   math.Pi,
+			want:    Quat{},
+			wantErr: true,
+		},
+		{
+			name:    "(0,0,1), 0 = identity",
+			axis:    Vec3{0, 0, 1},
+			angle:   0,
+			want:    IdentityQuat(),
+			wantErr: false,
+		},
+		{
+			name:    "(0,0,2), pi = (0,0,0,1)",
+			axis:    Vec3{0, 0, 2},
+			angle:   math.Pi,
+			want:    Quat{0, 0, 0, 1},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QuatFromAxisAngle(tt.axis, tt.angle)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QuatFromAxisAngle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("QuatFromAxisAngle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuat_Rotate(t *testing.T) {
+	tests := []struct {
+		name  string
+		axis  Vec3
+		angle float64
+		v     Vec3
+		want  Vec3
+	}{
+		{
+			name:  "rotate (1,0,0) by pi/2 about z = (0,1,0)",
+			axis:  Vec3{0, 0, 1},
+			angle: math.Pi / 2,
+			v:     Vec3{1, 0, 0},
+			want:  Vec3{0, 1, 0},
+		},
+		{
+			name:  "rotate (0,1,0) by pi/2 about x = (0,0,1)",
+			axis:  Vec3{1, 0, 0},
+			angle: math.Pi / 2,
+			v:     Vec3{0, 1, 0},
+			want:  Vec3{0, 0, 1},
+		},
+		{
+			name:  "rotate (1,0,0) by 2pi/3 about (1,1,1) = (0,1,0)",
+			axis:  Vec3{1, 1, 1},
+			angle: 2 * math.Pi / 3,
+			v:     Vec3{1, 0, 0},
+			want:  Vec3{0, 1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := QuatFromAxisAngle(tt.axis, tt.angle)
+			if err != nil {
+				t.Fatalf("QuatFromAxisAngle() error = %v", err)
+			}
+			if got := q.Rotate(tt.v); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("q.Rotate(v) = %v, want %v", got, tt.want)
+			}
+			if got := q.ToMat3().MulVec3(tt.v); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("q.ToMat3().MulVec3(v) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuat_Mul(t *testing.T) {
+	qx, _ := QuatFromAxisAngle(Vec3{1, 0, 0}, math.Pi/2)
+	qz, _ := QuatFromAxisAngle(Vec3{0, 0, 1}, math.Pi/2)
+
+	// rotate about z first, then about x: (1,0,0) -> (0,1,0) -> (0,0,1)
+	got := qx.Mul(qz).Rotate(Vec3{1, 0, 0})
+	want := Vec3{0, 0, 1}
+
+	if !got.AlmostEquals(want, 1e-12) {
+		t.Errorf("qx.Mul(qz).Rotate(v) = %v, want %v", got, want)
+	}
+}
+
+func TestQuat_Inverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       Quat
+		wantErr bool
+	}{
+		{
+			name:    "inverse(0) = error",
+			q:       Quat{},
+			wantErr: true,
+		},
+		{
+			name:    "inverse(identity)",
+			q:       IdentityQuat(),
+			wantErr: false,
+		},
+		{
+			name:    "inverse(1,2,3,4)",
+			q:       Quat{1, 2, 3, 4},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.q.Inverse()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("q.Inverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if product := tt.q.Mul(got); !product.AlmostEquals(IdentityQuat(), 1e-12) {
+				t.Errorf("q * q.Inverse() = %v, want identity", product)
+			}
+		})
+	}
+}
+
+func TestQuat_Slerp(t *testing.T) {
+	q1 := IdentityQuat()
+	q2, _ := QuatFromAxisAngle(Vec3{0, 0, 1}, math.Pi/2)
+	half, _ := QuatFromAxisAngle(Vec3{0, 0, 1}, math.Pi/4)
+
+	tests := []struct {
+		name string
+		q1   Quat
+		q2   Quat
+		t    float64
+		want Quat
+	}{
+		{
+			name: "t = 0",
+			q1:   q1,
+			q2:   q2,
+			t:    0,
+			want: q1,
+		},
+		{
+			name: "t = 1",
+			q1:   q1,
+			q2:   q2,
+			t:    1,
+			want: q2,
+		},
+		{
+			name: "t = 0.5",
+			q1:   q1,
+			q2:   q2,
+			t:    0.5,
+			want: half,
+		},
+		{
+			name: "t = 0.5, q2 negated takes the short way",
+			q1:   q1,
+			q2:   q2.Multiply(-1),
+			t:    0.5,
+			want: half,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q1.Slerp(tt.q2, tt.t); !got.AlmostEquals(tt.want, 1e-12) && !got.AlmostEquals(tt.want.Multiply(-1), 1e-12) {
+				t.Errorf("q1.Slerp(q2, t) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuat_SlerpClamped(t *testing.T) {
+	q1 := IdentityQuat()
+	q2, _ := QuatFromAxisAngle(Vec3{0, 0, 1}, math.Pi/2)
+
+	if got := q1.SlerpClamped(q2, 3); !got.AlmostEquals(q2, 1e-12) {
+		t.Errorf("q1.SlerpClamped(q2, 3) = %v, want %v", got, q2)
+	}
+	if got := q1.SlerpClamped(q2, -1); !got.AlmostEquals(q1, 1e-12) {
+		t.Errorf("q1.SlerpClamped(q2, -1) = %v, want %v", got, q1)
+	}
+}
+
+func TestQuatFromMat3(t *testing.T) {
+	tests := []struct {
+		name  string
+		axis  Vec3
+		angle float64
+	}{
+		{
+			name:  "identity",
+			axis:  Vec3{0, 0, 1},
+			angle: 0,
+		},
+		{
+			name:  "pi about x",
+			axis:  Vec3{1, 0, 0},
+			angle: math.Pi,
+		},
+		{
+			name:  "pi about y",
+			axis:  Vec3{0, 1, 0},
+			angle: math.Pi,
+		},
+		{
+			name:  "pi about z",
+			axis:  Vec3{0, 0, 1},
+			angle: math.Pi,
+		},
+		{
+			name:  "1 radian about (1,2,3)",
+			axis:  Vec3{1, 2, 3},
+			angle: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := QuatFromAxisAngle(tt.axis, tt.angle)
+			if err != nil {
+				t.Fatalf("QuatFromAxisAngle() error = %v", err)
+			}
+			got := QuatFromMat3(q.ToMat3())
+			if !got.AlmostEquals(q, 1e-12) && !got.AlmostEquals(q.Multiply(-1), 1e-12) {
+				t.Errorf("QuatFromMat3(q.ToMat3()) = %v, want %v", got, q)
+			}
+		})
+	}
+}
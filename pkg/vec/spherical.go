@@ -0,0 +1,125 @@
+package vec
+
+import "math"
+
+// Spherical represents a point in 3D space by its distance from the origin, and two angles in radians:
+// the azimuth anticlockwise from the positive X axis when looking down the Z axis towards the origin, and
+// the inclination down from the positive Z axis.
+//
+// [Vec3.ToSpherical] and [Spherical.Normalised] always give the canonical representation, with a
+// non-negative radius, an azimuth in the range (-pi, pi] and an inclination in the range [0, pi].
+// Points on the Z axis, including the poles of every sphere, are represented with an azimuth of 0, and
+// the origin with both angles 0.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Spherical being operated upon.
+type Spherical struct {
+	Radius, Azimuth, Inclination float64
+}
+
+// ToVec3 returns the point in Cartesian coordinates.
+func (s Spherical) ToVec3() Vec3 {
+	sinAzimuth, cosAzimuth := math.Sincos(s.Azimuth)
+	sinInclination, cosInclination := math.Sincos(s.Inclination)
+
+	return Vec3{
+		s.Radius * sinInclination * cosAzimuth,
+		s.Radius * sinInclination * sinAzimuth,
+		s.Radius * cosInclination,
+	}
+}
+
+// Normalised returns the canonical representation of the same point, with a non-negative radius, an
+// azimuth in the range (-pi, pi] and an inclination in the range [0, pi]. On the Z axis the azimuth is 0,
+// and if the radius is 0 both angles are 0.
+func (s Spherical) Normalised() Spherical {
+	if s.Radius == 0 {
+		return Spherical{}
+	}
+
+	// an inclination beyond either pole comes back down the other side, half a turn round
+	s.Inclination = NormaliseAngleSigned(s.Inclination)
+	if s.Inclination < 0 {
+		s = Spherical{s.Radius, s.Azimuth + math.Pi, -s.Inclination}
+	}
+
+	if s.Radius < 0 {
+		s = Spherical{-s.Radius, s.Azimuth + math.Pi, math.Pi - s.Inclination}
+	}
+
+	if s.Inclination == 0 || s.Inclination == math.Pi {
+		return Spherical{s.Radius, 0, s.Inclination}
+	}
+
+	return Spherical{s.Radius, NormaliseAngleSigned(s.Azimuth), s.Inclination}
+}
+
+// ToCylindrical returns the same point in cylindrical coordinates.
+func (s Spherical) ToCylindrical() Cylindrical {
+	s = s.Normalised()
+	sin, cos := math.Sincos(s.Inclination)
+
+	return Cylindrical{
+		s.Radius * sin,
+		s.Azimuth,
+		s.Radius * cos,
+	}
+}
+
+// Lerp interpolates between s1 and s2 by factor t. The radius changes linearly, while the direction from
+// the origin turns at a constant rate along the great circle between the two directions, taking the short
+// way round.
+//
+// At t = 0, the result of this function is equivalent to s1.
+//
+// At t = 1, the result of this function is equivalent to s2.
+//
+// The result is normalised. The origin has no meaningful direction, so if either point is at the origin,
+// the direction of the other is used throughout. If the directions are exactly opposite, every great
+// circle between them is equally short, and one is chosen arbitrarily.
+//
+// No safeguards are in place for vales of t that do not satisfy 0 <= t <= 1.
+// Instead, this will extrapolate beyond s1 or s2.
+func (s1 Spherical) Lerp(s2 Spherical, t float64) Spherical {
+	s1, s2 = s1.Normalised(), s2.Normalised()
+	radius := s1.Radius + t*(s2.Radius-s1.Radius)
+
+	d1 := Spherical{1, s1.Azimuth, s1.Inclination}.ToVec3()
+	d2 := Spherical{1, s2.Azimuth, s2.Inclination}.ToVec3()
+	if s1.Radius == 0 {
+		d1 = d2
+	}
+	if s2.Radius == 0 {
+		d2 = d1
+	}
+
+	angle := math.Atan2(d1.Cross(d2).Magnitude(), d1.Dot(d2))
+
+	// the unit vector at right angles to d1, in the plane of the great circle towards d2
+	perpendicular, err := d2.Subtract(d1.Multiply(d1.Dot(d2))).Normalised()
+	if err != nil {
+		// the directions are the same or opposite, so any perpendicular will do
+		perpendicular, err = d1.Cross(Vec3{0, 0, 1}).Normalised()
+		if err != nil {
+			perpendicular = Vec3{1, 0, 0}
+		}
+	}
+
+	sin, cos := math.Sincos(angle * t)
+	direction := d1.Multiply(cos).Add(perpendicular.Multiply(sin)).ToSpherical()
+
+	return Spherical{radius, direction.Azimuth, direction.Inclination}.Normalised()
+}
+
+// Equals returns true if the two representations are identical. Different representations of the same
+// point are not equal unless both are normalised first.
+func (s1 Spherical) Equals(s2 Spherical) bool {
+	return s1.Radius == s2.Radius && s1.Azimuth == s2.Azimuth && s1.Inclination == s2.Inclination
+}
+
+// AlmostEquals returns true if the two representations are almost identical, within some tolerance threshold.
+// Azimuths are compared the short way round, so azimuths either side of pi can still be almost equal.
+func (s1 Spherical) AlmostEquals(s2 Spherical, threshold float64) bool {
+	return math.Abs(s1.Radius-s2.Radius) <= threshold &&
+		math.Abs(AngleDifference(s1.Azimuth, s2.Azimuth)) <= threshold &&
+		math.Abs(s1.Inclination-s2.Inclination) <= threshold
+}
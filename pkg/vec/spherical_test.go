@@ -0,0 +1,131 @@
+package vec
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestVec3_ToSpherical(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Vec3
+		want Spherical
+	}{
+		{"origin", Vec3{0, 0, 0}, Spherical{0, 0, 0}},
+		{"north pole", Vec3{0, 0, 2}, Spherical{2, 0, 0}},
+		{"south pole", Vec3{0, 0, -2}, Spherical{2, 0, math.Pi}},
+		{"south pole with negative zeros", Vec3{math.Copysign(0, -1), math.Copysign(0, -1), -2}, Spherical{2, 0, math.Pi}},
+		{"positive X", Vec3{1, 0, 0}, Spherical{1, 0, math.Pi / 2}},
+		{"positive Y", Vec3{0, 3, 0}, Spherical{3, math.Pi / 2, math.Pi / 2}},
+		{"negative X", Vec3{-1, math.Copysign(0, -1), 0}, Spherical{1, math.Pi, math.Pi / 2}},
+		{"below the equator", Vec3{0, -1, -1}, Spherical{math.Sqrt2, -math.Pi / 2, 3 * math.Pi / 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.v.ToSpherical()
+			if math.Abs(got.Radius-tt.want.Radius) > 1e-12 || math.Abs(got.Azimuth-tt.want.Azimuth) > 1e-12 ||
+				math.Abs(got.Inclination-tt.want.Inclination) > 1e-12 {
+				t.Errorf("ToSpherical() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpherical_ToVec3(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for range 1000 {
+		v := Vec3{r.Float64()*200 - 100, r.Float64()*200 - 100, r.Float64()*200 - 100}
+		if got := v.ToSpherical().ToVec3(); !got.AlmostEquals(v, 1e-12) {
+			t.Fatalf("%v.ToSpherical().ToVec3() = %v", v, got)
+		}
+
+		s := Spherical{r.Float64() * 100, r.Float64()*2*math.Pi - math.Pi, r.Float64() * math.Pi}
+		if got := s.ToVec3().ToSpherical(); !got.AlmostEquals(s, 1e-12) {
+			t.Fatalf("%v.ToVec3().ToSpherical() = %v", s, got)
+		}
+	}
+}
+
+func TestSpherical_Normalised(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Spherical
+		want Spherical
+	}{
+		{"already normalised", Spherical{1, 1, 1}, Spherical{1, 1, 1}},
+		{"large azimuth", Spherical{2, 5 * math.Pi / 2, 1}, Spherical{2, math.Pi / 2, 1}},
+		{"past the north pole", Spherical{1, 0, -math.Pi / 4}, Spherical{1, math.Pi, math.Pi / 4}},
+		{"past the south pole", Spherical{1, 0, 5 * math.Pi / 4}, Spherical{1, math.Pi, 3 * math.Pi / 4}},
+		{"negative radius", Spherical{-1, math.Pi / 2, math.Pi / 4}, Spherical{1, -math.Pi / 2, 3 * math.Pi / 4}},
+		{"pole", Spherical{1, 2, math.Pi}, Spherical{1, 0, math.Pi}},
+		{"origin", Spherical{0, 1, 2}, Spherical{0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.s.Normalised()
+			if math.Abs(got.Radius-tt.want.Radius) > 1e-12 || math.Abs(got.Azimuth-tt.want.Azimuth) > 1e-12 ||
+				math.Abs(got.Inclination-tt.want.Inclination) > 1e-12 {
+				t.Errorf("Normalised() = %v, want %v", got, tt.want)
+			}
+			if !got.ToVec3().AlmostEquals(tt.s.ToVec3(), 1e-12) {
+				t.Errorf("Normalised() moved the point from %v to %v", tt.s.ToVec3(), got.ToVec3())
+			}
+		})
+	}
+}
+
+func TestSpherical_ToCylindrical(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for range 1000 {
+		v := Vec3{r.Float64()*200 - 100, r.Float64()*200 - 100, r.Float64()*200 - 100}
+
+		c := v.ToSpherical().ToCylindrical()
+		if !c.AlmostEquals(v.ToCylindrical(), 1e-12) {
+			t.Fatalf("%v.ToSpherical().ToCylindrical() = %v, want %v", v, c, v.ToCylindrical())
+		}
+		if s := c.ToSpherical(); !s.AlmostEquals(v.ToSpherical(), 1e-12) {
+			t.Fatalf("%v.ToCylindrical().ToSpherical() = %v, want %v", v, s, v.ToSpherical())
+		}
+	}
+}
+
+func TestSpherical_Lerp(t *testing.T) {
+	tests := []struct {
+		name   string
+		s1, s2 Spherical
+		t      float64
+		want   Spherical
+	}{
+		{"start", Spherical{1, 0, 1}, Spherical{3, 1, 2}, 0, Spherical{1, 0, 1}},
+		{"end", Spherical{1, 0, 1}, Spherical{3, 1, 2}, 1, Spherical{3, 1, 2}},
+		{"along the equator", Spherical{1, 0, math.Pi / 2}, Spherical{3, 1, math.Pi / 2}, 0.5, Spherical{2, 0.5, math.Pi / 2}},
+		{"along a meridian", Spherical{1, 1, 0.5}, Spherical{1, 1, 1.5}, 0.25, Spherical{1, 1, 0.75}},
+		{"short way across pi", Spherical{1, 3, math.Pi / 2}, Spherical{1, -3, math.Pi / 2}, 0.5, Spherical{1, math.Pi, math.Pi / 2}},
+		// the great circle between two points either side of the pole runs over the pole, not around it
+		{"over the pole", Spherical{1, 0, 0.5}, Spherical{1, math.Pi, 0.5}, 0.5, Spherical{1, 0, 0}},
+		{"from the origin", Spherical{0, 0, 0}, Spherical{2, 2, 2}, 0.5, Spherical{1, 2, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s1.Lerp(tt.s2, tt.t); !got.ToVec3().AlmostEquals(tt.want.ToVec3(), 1e-12) {
+				t.Errorf("Lerp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("opposite directions", func(t *testing.T) {
+		s1, s2 := Spherical{1, 0, math.Pi / 2}, Spherical{1, math.Pi, math.Pi / 2}
+		for _, f := range []float64{0.25, 0.5, 0.75} {
+			got := s1.Lerp(s2, f)
+			if angle, _ := s1.ToVec3().Angle(got.ToVec3()); math.Abs(got.Radius-1) > 1e-12 || math.Abs(angle-f*math.Pi) > 1e-9 {
+				t.Errorf("Lerp(%v) = %v, which is %v from the start", f, got, angle)
+			}
+		}
+	})
+}
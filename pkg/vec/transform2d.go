@@ -0,0 +1,151 @@
+package vec
+
+import "math"
+
+// Transform2D represents an affine transform in 2D space: a linear part (rotation, scale and shear)
+// followed by a translation.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Transform2D being operated upon.
+type Transform2D struct {
+	Linear      Mat2
+	Translation Vec2
+}
+
+// IdentityTransform2D returns the transform that leaves all points unchanged.
+func IdentityTransform2D() Transform2D {
+	return Transform2D{
+		Linear: Identity2(),
+	}
+}
+
+// NewTransform2D builds a transform from its components. When applied to a point, the point is first
+// scaled, then sheared, then rotated by rotation radians anticlockwise, then translated.
+//
+// The shear is applied along the X axis, mapping (x, y) to (x + shear*y, y).
+//
+// This is the inverse operation of [Transform2D.Decompose].
+func NewTransform2D(translation Vec2, rotation float64, scale Vec2, shear float64) Transform2D {
+	return Translation2D(translation).
+		Mul(Rotation2D(rotation)).
+		Mul(Shear2D(shear)).
+		Mul(Scale2D(scale))
+}
+
+// Translation2D returns the transform that translates points by t.
+func Translation2D(t Vec2) Transform2D {
+	return Transform2D{
+		Linear:      Identity2(),
+		Translation: t,
+	}
+}
+
+// Rotation2D returns the transform that rotates points anticlockwise about the origin by angle radians.
+func Rotation2D(angle float64) Transform2D {
+	sin, cos := math.Sincos(angle)
+
+	return Transform2D{
+		Linear: Mat2{
+			{cos, -sin},
+			{sin, cos},
+		},
+	}
+}
+
+// Scale2D returns the transform that scales points about the origin by s.X along the X axis and s.Y along the Y axis.
+func Scale2D(s Vec2) Transform2D {
+	return Transform2D{
+		Linear: Mat2{
+			{s.X, 0},
+			{0, s.Y},
+		},
+	}
+}
+
+// Shear2D returns the transform that shears points along the X axis, mapping (x, y) to (x + k*y, y).
+func Shear2D(k float64) Transform2D {
+	return Transform2D{
+		Linear: Mat2{
+			{1, k},
+			{0, 1},
+		},
+	}
+}
+
+// Mul composes t1 and t2, returning the transform that applies t2 followed by t1.
+func (t1 Transform2D) Mul(t2 Transform2D) Transform2D {
+	return Transform2D{
+		Linear:      t1.Linear.Mul(t2.Linear),
+		Translation: t1.Linear.MulVec2(t2.Translation).Add(t1.Translation),
+	}
+}
+
+// MulPoint applies this transform to the point p.
+func (t Transform2D) MulPoint(p Vec2) Vec2 {
+	return t.Linear.MulVec2(p).Add(t.Translation)
+}
+
+// MulDirection applies this transform to the direction d.
+//
+// Unlike [Transform2D.MulPoint], the translation has no effect on the result.
+func (t Transform2D) MulDirection(d Vec2) Vec2 {
+	return t.Linear.MulVec2(d)
+}
+
+// Inverse returns the transform that undoes this transform.
+//
+// If this transform collapses space onto a line or point (for example, a scale of 0 on either axis),
+// it has no inverse and this function will return an error wrapping [ErrSingular].
+func (t Transform2D) Inverse() (Transform2D, error) {
+	linear, err := t.Linear.Inverse()
+	if err != nil {
+		return Transform2D{}, NewOpError("Transform2D.Inverse", ErrSingular, t)
+	}
+
+	return Transform2D{
+		Linear:      linear,
+		Translation: linear.MulVec2(t.Translation).Multiply(-1),
+	}, nil
+}
+
+// Decompose splits this transform into the components accepted by [NewTransform2D].
+//
+// The X scale is always non-negative. If the transform includes a reflection, it is represented by a negative Y scale.
+//
+// If this transform is degenerate, it cannot be uniquely decomposed and this function will return an error
+// wrapping [ErrSingular].
+func (t Transform2D) Decompose() (translation Vec2, rotation float64, scale Vec2, shear float64, err error) {
+	if t.Linear.Determinant() == 0 {
+		return Vec2{}, 0, Vec2{}, 0, NewOpError("Transform2D.Decompose", ErrSingular, t)
+	}
+
+	// the first column of R * H * S is R * (sx, 0), so it gives both the rotation and the X scale
+	m := t.Linear
+	rotation = math.Atan2(m[1][0], m[0][0])
+	scale.X = math.Hypot(m[0][0], m[1][0])
+
+	// undoing the rotation leaves H * S = [[sx, k*sy], [0, sy]]
+	hs := Rotation2D(-rotation).Linear.Mul(m)
+	scale.Y = hs[1][1]
+	shear = hs[0][1] / scale.Y
+
+	return t.Translation, rotation, scale, shear, nil
+}
+
+// ToMat3 returns this transform as a 3x3 matrix acting on homogeneous 2D coordinates.
+func (t Transform2D) ToMat3() Mat3 {
+	return Mat3{
+		{t.Linear[0][0], t.Linear[0][1], t.Translation.X},
+		{t.Linear[1][0], t.Linear[1][1], t.Translation.Y},
+		{0, 0, 1},
+	}
+}
+
+// Equals returns true if the two transforms are equal.
+func (t1 Transform2D) Equals(t2 Transform2D) bool {
+	return t1.Linear.Equals(t2.Linear) && t1.Translation.Equals(t2.Translation)
+}
+
+// AlmostEquals returns true if the two transforms are almost equal, within some tolerance threshold.
+func (t1 Transform2D) AlmostEquals(t2 Transform2D, threshold float64) bool {
+	return t1.Linear.AlmostEquals(t2.Linear, threshold) && t1.Translation.AlmostEquals(t2.Translation, threshold)
+}
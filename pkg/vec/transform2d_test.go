@@ -0,0 +1,153 @@
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransform2D_MulPoint(t *testing.T) {
+	tests := []struct {
+		name string
+		t    Transform2D
+		p    Vec2
+		want Vec2
+	}{
+		{
+			name: "identity",
+			t:    IdentityTransform2D(),
+			p:    Vec2{1, 2},
+			want: Vec2{1, 2},
+		},
+		{
+			name: "translate (1,2) by (3,4) = (4,6)",
+			t:    Translation2D(Vec2{3, 4}),
+			p:    Vec2{1, 2},
+			want: Vec2{4, 6},
+		},
+		{
+			name: "scale then rotate then translate",
+			t:    NewTransform2D(Vec2{1, 0}, math.Pi/2, Vec2{2, 3}, 0),
+			p:    Vec2{1, 1},
+			want: Vec2{-2, 2},
+		},
+		{
+			name: "shear (1,2) by 0.5 = (2,2)",
+			t:    Shear2D(0.5),
+			p:    Vec2{1, 2},
+			want: Vec2{2, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.MulPoint(tt.p); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("t.MulPoint(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransform2D_MulDirection(t *testing.T) {
+	tr := NewTransform2D(Vec2{10, 10}, math.Pi/2, Vec2{1, 1}, 0)
+	want := Vec2{0, 1}
+
+	if got := tr.MulDirection(Vec2{1, 0}); !got.AlmostEquals(want, 1e-12) {
+		t.Errorf("t.MulDirection(d) = %v, want %v", got, want)
+	}
+}
+
+func TestTransform2D_Inverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       Transform2D
+		wantErr bool
+	}{
+		{
+			name:    "identity",
+			t:       IdentityTransform2D(),
+			wantErr: false,
+		},
+		{
+			name:    "general",
+			t:       NewTransform2D(Vec2{3, -2}, 0.7, Vec2{2, -0.5}, 0.3),
+			wantErr: false,
+		},
+		{
+			name:    "zero scale = error",
+			t:       NewTransform2D(Vec2{3, -2}, 0.7, Vec2{0, 1}, 0),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.t.Inverse()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("t.Inverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if composed := got.Mul(tt.t); !composed.AlmostEquals(IdentityTransform2D(), 1e-12) {
+				t.Errorf("t.Inverse().Mul(t) = %v, want identity", composed)
+			}
+		})
+	}
+}
+
+func TestTransform2D_Decompose(t *testing.T) {
+	tests := []struct {
+		name        string
+		translation Vec2
+		rotation    float64
+		scale       Vec2
+		shear       float64
+		wantErr     bool
+	}{
+		{
+			name:        "identity",
+			translation: Vec2{0, 0},
+			rotation:    0,
+			scale:       Vec2{1, 1},
+			shear:       0,
+		},
+		{
+			name:        "general",
+			translation: Vec2{3, -2},
+			rotation:    0.7,
+			scale:       Vec2{2, 0.5},
+			shear:       0.3,
+		},
+		{
+			name:        "reflection",
+			translation: Vec2{1, 1},
+			rotation:    -2,
+			scale:       Vec2{1.5, -3},
+			shear:       -1,
+		},
+		{
+			name:        "degenerate = error",
+			translation: Vec2{1, 1},
+			rotation:    0,
+			scale:       Vec2{1, 0},
+			shear:       0,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translation, rotation, scale, shear, err := NewTransform2D(tt.translation, tt.rotation, tt.scale, tt.shear).Decompose()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("t.Decompose() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !translation.AlmostEquals(tt.translation, 1e-12) || math.Abs(rotation-tt.rotation) > 1e-12 ||
+				!scale.AlmostEquals(tt.scale, 1e-12) || math.Abs(shear-tt.shear) > 1e-12 {
+				t.Errorf("t.Decompose() = %v, %v, %v, %v, want %v, %v, %v, %v",
+					translation, rotation, scale, shear, tt.translation, tt.rotation, tt.scale, tt.shear)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package vec
+
+// Transform3D represents an affine transform in 3D space: a linear part (rotation, scale and shear)
+// followed by a translation.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Transform3D being operated upon.
+type Transform3D struct {
+	Linear      Mat3
+	Translation Vec3
+}
+
+// IdentityTransform3D returns the transform that leaves all points unchanged.
+func IdentityTransform3D() Transform3D {
+	return Transform3D{
+		Linear: Identity3(),
+	}
+}
+
+// NewTransform3D builds a transform from its components. When applied to a point, the point is first
+// scaled, then rotated, then translated.
+//
+// rotation is assumed to be a unit quaternion.
+func NewTransform3D(translation Vec3, rotation Quat, scale Vec3) Transform3D {
+	linear := rotation.ToMat3().Mul(Mat3{
+		{scale.X, 0, 0},
+		{0, scale.Y, 0},
+		{0, 0, scale.Z},
+	})
+
+	return Transform3D{
+		Linear:      linear,
+		Translation: translation,
+	}
+}
+
+// Mul composes t1 and t2, returning the transform that applies t2 followed by t1.
+func (t1 Transform3D) Mul(t2 Transform3D) Transform3D {
+	return Transform3D{
+		Linear:      t1.Linear.Mul(t2.Linear),
+		Translation: t1.Linear.MulVec3(t2.Translation).Add(t1.Translation),
+	}
+}
+
+// MulPoint applies this transform to the point p.
+func (t Transform3D) MulPoint(p Vec3) Vec3 {
+	return t.Linear.MulVec3(p).Add(t.Translation)
+}
+
+// MulDirection applies this transform to the direction d.
+//
+// Unlike [Transform3D.MulPoint], the translation has no effect on the result.
+func (t Transform3D) MulDirection(d Vec3) Vec3 {
+	return t.Linear.MulVec3(d)
+}
+
+// Inverse returns the transform that undoes this transform.
+//
+// If this transform collapses space onto a plane, line or point (for example, a scale of 0 on any axis),
+// it has no inverse and this function will return an error wrapping [ErrSingular].
+func (t Transform3D) Inverse() (Transform3D, error) {
+	linear, err := t.Linear.Inverse()
+	if err != nil {
+		return Transform3D{}, NewOpError("Transform3D.Inverse", ErrSingular, t)
+	}
+
+	return Transform3D{
+		Linear:      linear,
+		Translation: linear.MulVec3(t.Translation).Multiply(-1),
+	}, nil
+}
+
+// ToMat4 returns this transform as a 4x4 matrix acting on homogeneous 3D coordinates.
+func (t Transform3D) ToMat4() Mat4 {
+	return Mat4{
+		{t.Linear[0][0], t.Linear[0][1], t.Linear[0][2], t.Translation.X},
+		{t.Linear[1][0], t.Linear[1][1], t.Linear[1][2], t.Translation.Y},
+		{t.Linear[2][0], t.Linear[2][1], t.Linear[2][2], t.Translation.Z},
+		{0, 0, 0, 1},
+	}
+}
+
+// Equals returns true if the two transforms are equal.
+func (t1 Transform3D) Equals(t2 Transform3D) bool {
+	return t1.Linear.Equals(t2.Linear) && t1.Translation.Equals(t2.Translation)
+}
+
+// AlmostEquals returns true if the two transforms are almost equal, within some tolerance threshold.
+func (t1 Transform3D) AlmostEquals(t2 Transform3D, threshold float64) bool {
+	return t1.Linear.AlmostEquals(t2.Linear, threshold) && t1.Translation.AlmostEquals(t2.Translation, threshold)
+}
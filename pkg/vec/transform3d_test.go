@@ -0,0 +1,76 @@
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransform3D_MulPoint(t *testing.T) {
+	rotation, _ := QuatFromAxisAngle(Vec3{0, 0, 1}, math.Pi/2)
+
+	tests := []struct {
+		name string
+		t    Transform3D
+		p    Vec3
+		want Vec3
+	}{
+		{
+			name: "identity",
+			t:    IdentityTransform3D(),
+			p:    Vec3{1, 2, 3},
+			want: Vec3{1, 2, 3},
+		},
+		{
+			name: "scale then rotate then translate",
+			t:    NewTransform3D(Vec3{1, 0, 0}, rotation, Vec3{2, 3, 4}),
+			p:    Vec3{1, 1, 1},
+			want: Vec3{-2, 2, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.MulPoint(tt.p); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("t.MulPoint(p) = %v, want %v", got, tt.want)
+			}
+			if got := tt.t.ToMat4().MulPoint(tt.p); !got.AlmostEquals(tt.want, 1e-12) {
+				t.Errorf("t.ToMat4().MulPoint(p) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransform3D_Inverse(t *testing.T) {
+	rotation, _ := QuatFromAxisAngle(Vec3{1, 2, 3}, 0.4)
+
+	tests := []struct {
+		name    string
+		t       Transform3D
+		wantErr bool
+	}{
+		{
+			name:    "general",
+			t:       NewTransform3D(Vec3{1, -2, 3}, rotation, Vec3{2, 0.5, -1}),
+			wantErr: false,
+		},
+		{
+			name:    "zero scale = error",
+			t:       NewTransform3D(Vec3{1, -2, 3}, rotation, Vec3{2, 0, 1}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.t.Inverse()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("t.Inverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if composed := tt.t.Mul(got); !composed.AlmostEquals(IdentityTransform3D(), 1e-12) {
+				t.Errorf("t.Mul(t.Inverse()) = %v, want identity", composed)
+			}
+		})
+	}
+}
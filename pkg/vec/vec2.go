@@ -1,50 +1,44 @@
 package vec
 
-import (
-	"errors"
-	"math"
-)
+import "math"
 
 // Vec2 represents a vector in 2D space.
 // Many methods are provided - note these are value receivers,
 // and therefore never modify the Vec2 being operated upon.
+//
+// Vec2 has the same layout as a [Vec2Of] with float64 components, and the arithmetic shared by every element type, such as
+// Add and Dot, is implemented once on Vec2Of. Operations that can fail, such as Divide, are implemented
+// here so that their errors name Vec2.
 type Vec2 struct {
 	X, Y float64
 }
 
 // Add computes v1 + v2.
 func (v1 Vec2) Add(v2 Vec2) Vec2 {
-	return Vec2{
-		v1.X + v2.X,
-		v1.Y + v2.Y,
-	}
+	return Vec2(Vec2Of[float64](v1).Add(Vec2Of[float64](v2)))
 }
 
 // Subtract computes v1 - v2.
 func (v1 Vec2) Subtract(v2 Vec2) Vec2 {
-	return Vec2{
-		v1.X - v2.X,
-		v1.Y - v2.Y,
-	}
+	return Vec2(Vec2Of[float64](v1).Subtract(Vec2Of[float64](v2)))
 }
 
 // Dot computes the dot product between v1 and v2.
 func (v1 Vec2) Dot(v2 Vec2) float64 {
-	return v1.X*v2.X + v1.Y*v2.Y
+	return Vec2Of[float64](v1).Dot(Vec2Of[float64](v2))
 }
 
 // Multiply returns this vector multiplied by a scalar value.
 func (v Vec2) Multiply(n float64) Vec2 {
-	return Vec2{
-		v.X * n,
-		v.Y * n,
-	}
+	return Vec2(Vec2Of[float64](v).Multiply(n))
 }
 
 // Divide returns this vector divided by a scalar value.
+//
+// If n = 0, this function will return an error wrapping [ErrDivideByZero].
 func (v Vec2) Divide(n float64) (Vec2, error) {
 	if n == 0 {
-		return Vec2{}, errors.New("tried to divide by 0")
+		return Vec2{}, NewOpError("Vec2.Divide", ErrDivideByZero, v, n)
 	}
 
 	return Vec2{
@@ -60,12 +54,12 @@ func (v Vec2) Magnitude() float64 {
 
 // Normalised returns the vector in the same direction as this vector with a length of 1.
 //
-// Since a 0-length array has no direction, if |v| = 0 then this function will return an error.
+// Since a 0-length array has no direction, if |v| = 0 then this function will return an error wrapping [ErrZeroLength].
 func (v Vec2) Normalised() (Vec2, error) {
 	magnitude := v.Magnitude()
 
 	if magnitude == 0 {
-		return Vec2{}, errors.New("tried to normalise a 0-length vector")
+		return Vec2{}, NewOpError("Vec2.Normalised", ErrZeroLength, v)
 	}
 
 	return Vec2{
@@ -75,13 +69,15 @@ func (v Vec2) Normalised() (Vec2, error) {
 }
 
 // Angle computes the angle between v1 and v2, in radians.
+//
+// If either vector has a length of 0, this function will return an error wrapping [ErrZeroLength].
 func (v1 Vec2) Angle(v2 Vec2) (float64, error) {
 	if v1.Magnitude() == 0 {
-		return 0, errors.New("v1 length is 0, cannot compute angle")
+		return 0, NewOpError("Vec2.Angle", ErrZeroLength, v1, v2)
 	}
 
 	if v2.Magnitude() == 0 {
-		return 0, errors.New("v2 length is 0, cannot compute angle")
+		return 0, NewOpError("Vec2.Angle", ErrZeroLength, v1, v2)
 	}
 
 	return math.Acos(v1.Dot(v2) / (v1.Magnitude() * v2.Magnitude())), nil
@@ -101,10 +97,7 @@ func (v1 Vec2) Angle(v2 Vec2) (float64, error) {
 //
 // If you wish to have the result clamped between v1 and v2, use [LerpClamped].
 func (v1 Vec2) Lerp(v2 Vec2, t float64) Vec2 {
-	return Vec2{
-		v1.X + t*(v2.X-v1.X),
-		v1.Y + t*(v2.Y-v1.Y),
-	}
+	return Vec2(LerpVec2(Vec2Of[float64](v1), Vec2Of[float64](v2), t))
 }
 
 // LerpClamped linearly interpolates between v1 and v2 by factor t, clamping the result between v1 and v2.
@@ -128,12 +121,36 @@ func (v1 Vec2) LerpClamped(v2 Vec2, t float64) Vec2 {
 	return v1.Lerp(v2, t_clamped)
 }
 
+// Cross returns the 2D cross product of v1 and v2, also known as the perp dot product.
+//
+// This is the Z component of the 3D cross product of (v1.X, v1.Y, 0) and (v2.X, v2.Y, 0). It is positive
+// if v2 is anticlockwise from v1, negative if v2 is clockwise from v1, and 0 if they are parallel.
+func (v1 Vec2) Cross(v2 Vec2) float64 {
+	return v1.X*v2.Y - v1.Y*v2.X
+}
+
+// ToPolar returns the vector in polar coordinates. The result is normalised, as described by [Polar].
+func (v Vec2) ToPolar() Polar {
+	radius := math.Hypot(v.X, v.Y)
+	if radius == 0 {
+		return Polar{}
+	}
+
+	// normalising catches atan2 returning -pi when Y is -0
+	return Polar{radius, NormaliseAngleSigned(math.Atan2(v.Y, v.X))}
+}
+
 // Equals returns true if the two vectors are equal.
 func (v1 Vec2) Equals(v2 Vec2) bool {
-	return v1.X == v2.X && v1.Y == v2.Y
+	return Vec2Of[float64](v1).Equals(Vec2Of[float64](v2))
 }
 
 // AlmostEqual returns true if the two vectors are almost equal, within some tolerance threshold.
 func (v1 Vec2) AlmostEquals(v2 Vec2, threshold float64) bool {
-	return math.Abs(v1.X-v2.X) <= threshold && math.Abs(v1.Y-v2.Y) <= threshold
+	return AlmostEqualsVec2(Vec2Of[float64](v1), Vec2Of[float64](v2), threshold)
+}
+
+// EqualsWith returns true if the two vectors are close enough to be treated as equal by c.
+func (v1 Vec2) EqualsWith(v2 Vec2, c Comparator) bool {
+	return c.EqualComponents([]float64{v1.X, v1.Y}, []float64{v2.X, v2.Y})
 }
@@ -0,0 +1,53 @@
+package vec_test
+
+import (
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+	"github.com/michael-ryan/mikelib/pkg/vec/vectest"
+)
+
+// Tests in this file use vectest, which imports vec, so they belong to the external test package.
+
+func TestVec2_Add(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   vec.Vec2
+		v2   vec.Vec2
+		want vec.Vec2
+		// c decides whether the sum is close enough to want, and the zero Comparator requires it to be exact
+		c vec.Comparator
+	}{
+		{
+			name: "(0,0) + (0,0) = (0,0)",
+			v1:   vec.Vec2{X: 0, Y: 0},
+			v2:   vec.Vec2{X: 0, Y: 0},
+			want: vec.Vec2{X: 0, Y: 0},
+		},
+		{
+			name: "(1,2) + (3,4) = (4,6)",
+			v1:   vec.Vec2{X: 1, Y: 2},
+			v2:   vec.Vec2{X: 3, Y: 4},
+			want: vec.Vec2{X: 4, Y: 6},
+		},
+		{
+			name: "(-20, 3) + (14, -1) = (-6, 2)",
+			v1:   vec.Vec2{X: -20, Y: 3},
+			v2:   vec.Vec2{X: 14, Y: -1},
+			want: vec.Vec2{X: -6, Y: 2},
+		},
+		{
+			// none of these decimals are exact in binary, so the sum is only within rounding error
+			name: "(-3, 2.01) + (2.05, 0.003) = (-0.95, 2.013)",
+			v1:   vec.Vec2{X: -3, Y: 2.01},
+			v2:   vec.Vec2{X: 2.05, Y: 0.003},
+			want: vec.Vec2{X: -0.95, Y: 2.013},
+			c:    vec.ULPTolerance(4),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vectest.AssertVec2Near(t, tt.v1.Add(tt.v2), tt.want, tt.c)
+		})
+	}
+}
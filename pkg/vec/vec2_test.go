@@ -5,49 +5,6 @@ import (
 	"testing"
 )
 
-func TestVec2_Add(t *testing.T) {
-	tests := []struct {
-		name string
-		v1   Vec2
-		v2   Vec2
-		want Vec2
-	}{
-		{
-			name: "(0,0) + (0,0) = (0,0)",
-			v1:   Vec2{0, 0},
-			v2:   Vec2{0, 0},
-			want: Vec2{0, 0},
-		},
-		{
-			name: "(1,2) + (3,4) = (4,6)",
-			v1:   Vec2{1, 2},
-			v2:   Vec2{3, 4},
-			want: Vec2{4, 6},
-		},
-		{
-			name: "(-20, 3) + (14, -1) = (-6, 2)",
-			v1:   Vec2{-20, 3},
-			v2:   Vec2{14, -1},
-			want: Vec2{-6, 2},
-		},
-		// can't really test the below thanks to rounding errors
-		// unless we use AlmostEquals
-		// {
-		// 	name: "(-3, 2.01) + (2.05, 0.003) = (-0.95, 2.013)",
-		// 	v1:   Vec2{-3, 2.01},
-		// 	v2:   Vec2{2.05, 0.003},
-		// 	want: Vec2{-0.95, 2.013},
-		// },
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.v1.Add(tt.v2); !got.Equals(tt.want) {
-				t.Errorf("Vec2.Add() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestVec2_Subtract(t *testing.T) {
 	tests := []struct {
 		name string
@@ -555,3 +512,44 @@ func TestVec2_AlmostEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestVec2_Cross(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   Vec2
+		v2   Vec2
+		want float64
+	}{
+		{
+			name: "(1,0) x (0,1) = 1",
+			v1:   Vec2{1, 0},
+			v2:   Vec2{0, 1},
+			want: 1,
+		},
+		{
+			name: "(0,1) x (1,0) = -1",
+			v1:   Vec2{0, 1},
+			v2:   Vec2{1, 0},
+			want: -1,
+		},
+		{
+			name: "(2,4) x (1,2) = 0",
+			v1:   Vec2{2, 4},
+			v2:   Vec2{1, 2},
+			want: 0,
+		},
+		{
+			name: "(1,2) x (3,4) = -2",
+			v1:   Vec2{1, 2},
+			v2:   Vec2{3, 4},
+			want: -2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v1.Cross(tt.v2); got != tt.want {
+				t.Errorf("v1.Cross(v2) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package vec
+
+import "math"
+
+// Vec2Of represents a vector in 2D space, with components of any numeric type T.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Vec2Of being operated upon.
+//
+// For float64 components, prefer [Vec2], which provides the full set of floating point operations.
+// A Vec2Of[float64] may be converted to and from a Vec2 directly, as they share the same layout.
+//
+// Operations that only make sense for floating point components, such as normalisation, are
+// provided as functions constrained to [Float] rather than as methods.
+type Vec2Of[T Number] struct {
+	X, Y T
+}
+
+// Vec2i is a 2D vector with int components, suitable for grid and tile coordinates.
+type Vec2i = Vec2Of[int]
+
+// Vec2f32 is a 2D vector with float32 components, suitable for uploading to a GPU.
+type Vec2f32 = Vec2Of[float32]
+
+// ConvertVec2 converts the components of v to type U, using Go's numeric conversion rules.
+// In particular, converting floating point components to an integer type truncates towards zero.
+func ConvertVec2[U, T Number](v Vec2Of[T]) Vec2Of[U] {
+	return Vec2Of[U]{
+		U(v.X),
+		U(v.Y),
+	}
+}
+
+// FromVec2 converts v to a vector with components of type T, using Go's numeric conversion rules.
+func FromVec2[T Number](v Vec2) Vec2Of[T] {
+	return Vec2Of[T]{
+		T(v.X),
+		T(v.Y),
+	}
+}
+
+// Float64 converts this vector to a [Vec2].
+func (v Vec2Of[T]) Float64() Vec2 {
+	return Vec2{
+		float64(v.X),
+		float64(v.Y),
+	}
+}
+
+// Add computes v1 + v2.
+func (v1 Vec2Of[T]) Add(v2 Vec2Of[T]) Vec2Of[T] {
+	return Vec2Of[T]{
+		v1.X + v2.X,
+		v1.Y + v2.Y,
+	}
+}
+
+// Subtract computes v1 - v2.
+func (v1 Vec2Of[T]) Subtract(v2 Vec2Of[T]) Vec2Of[T] {
+	return Vec2Of[T]{
+		v1.X - v2.X,
+		v1.Y - v2.Y,
+	}
+}
+
+// Dot computes the dot product between v1 and v2.
+func (v1 Vec2Of[T]) Dot(v2 Vec2Of[T]) T {
+	return v1.X*v2.X + v1.Y*v2.Y
+}
+
+// Multiply returns this vector multiplied by a scalar value.
+func (v Vec2Of[T]) Multiply(n T) Vec2Of[T] {
+	return Vec2Of[T]{
+		v.X * n,
+		v.Y * n,
+	}
+}
+
+// Divide returns this vector divided by a scalar value.
+// For integer types, each component is truncated towards zero.
+//
+// If n = 0, this function will return an error wrapping [ErrDivideByZero].
+func (v Vec2Of[T]) Divide(n T) (Vec2Of[T], error) {
+	if n == 0 {
+		return Vec2Of[T]{}, NewOpError("Vec2Of.Divide", ErrDivideByZero, v, n)
+	}
+
+	return Vec2Of[T]{
+		v.X / n,
+		v.Y / n,
+	}, nil
+}
+
+// Magnitude returns the length of this vector.
+// The computation is done in float64, so integer vectors do not overflow when squaring their components.
+func (v Vec2Of[T]) Magnitude() float64 {
+	return v.Float64().Magnitude()
+}
+
+// Angle computes the angle between v1 and v2, in radians.
+//
+// If either vector has a length of 0, this function will return an error wrapping [ErrZeroLength].
+func (v1 Vec2Of[T]) Angle(v2 Vec2Of[T]) (float64, error) {
+	angle, err := v1.Float64().Angle(v2.Float64())
+	if err != nil {
+		return 0, NewOpError("Vec2Of.Angle", ErrZeroLength, v1, v2)
+	}
+
+	return angle, nil
+}
+
+// Equals returns true if the two vectors are equal.
+func (v1 Vec2Of[T]) Equals(v2 Vec2Of[T]) bool {
+	return v1.X == v2.X && v1.Y == v2.Y
+}
+
+// NormalisedVec2 returns the vector in the same direction as v with a length of 1.
+//
+// Since a 0-length array has no direction, if |v| = 0 then this function will return an error wrapping [ErrZeroLength].
+func NormalisedVec2[T Float](v Vec2Of[T]) (Vec2Of[T], error) {
+	magnitude := T(v.Magnitude())
+
+	if magnitude == 0 {
+		return Vec2Of[T]{}, NewOpError("NormalisedVec2", ErrZeroLength, v)
+	}
+
+	return Vec2Of[T]{
+		v.X / magnitude,
+		v.Y / magnitude,
+	}, nil
+}
+
+// LerpVec2 linearly interpolates between v1 and v2 by factor t.
+//
+// This behaves identically to [Vec2.Lerp].
+func LerpVec2[T Float](v1, v2 Vec2Of[T], t T) Vec2Of[T] {
+	return Vec2Of[T]{
+		v1.X + t*(v2.X-v1.X),
+		v1.Y + t*(v2.Y-v1.Y),
+	}
+}
+
+// AlmostEqualsVec2 returns true if the two vectors are almost equal, within some tolerance threshold.
+func AlmostEqualsVec2[T Float](v1, v2 Vec2Of[T], threshold T) bool {
+	return T(math.Abs(float64(v1.X-v2.X))) <= threshold && T(math.Abs(float64(v1.Y-v2.Y))) <= threshold
+}
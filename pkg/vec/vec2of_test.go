@@ -0,0 +1,145 @@
+package vec
+
+import "testing"
+
+func TestVec2Of_Add(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   Vec2i
+		v2   Vec2i
+		want Vec2i
+	}{
+		{
+			name: "(0,0) + (0,0) = (0,0)",
+			v1:   Vec2i{0, 0},
+			v2:   Vec2i{0, 0},
+			want: Vec2i{0, 0},
+		},
+		{
+			name: "(-20, 3) + (14, -1) = (-6, 2)",
+			v1:   Vec2i{-20, 3},
+			v2:   Vec2i{14, -1},
+			want: Vec2i{-6, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v1.Add(tt.v2); !got.Equals(tt.want) {
+				t.Errorf("v1.Add(v2) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVec2Of_Divide(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       Vec2i
+		n       int
+		want    Vec2i
+		wantErr bool
+	}{
+		{
+			name:    "(1, 3) / 0 = error",
+			v:       Vec2i{1, 3},
+			n:       0,
+			want:    Vec2i{0, 0},
+			wantErr: true,
+		},
+		{
+			name:    "(7, -7) / 2 = (3, -3)",
+			v:       Vec2i{7, -7},
+			n:       2,
+			want:    Vec2i{3, -3},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.v.Divide(tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("v.Divide(n) error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equals(tt.want) {
+				t.Errorf("v.Divide(n) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVec2Of_Magnitude(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Vec2Of[int8]
+		want float64
+	}{
+		{
+			name: "(3,4) = 5",
+			v:    Vec2Of[int8]{3, 4},
+			want: 5,
+		},
+		{
+			name: "(-120,-50) = 130 without overflowing int8",
+			v:    Vec2Of[int8]{-120, -50},
+			want: 130,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.Magnitude(); got != tt.want {
+				t.Errorf("v.Magnitude() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalisedVec2(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       Vec2f32
+		want    Vec2f32
+		wantErr bool
+	}{
+		{
+			name:    "(0,0) = error",
+			v:       Vec2f32{0, 0},
+			want:    Vec2f32{0, 0},
+			wantErr: true,
+		},
+		{
+			name:    "(3,4) = (0.6, 0.8)",
+			v:       Vec2f32{3, 4},
+			want:    Vec2f32{0.6, 0.8},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalisedVec2(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NormalisedVec2(v) error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !AlmostEqualsVec2(got, tt.want, 1e-6) {
+				t.Errorf("NormalisedVec2(v) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertVec2(t *testing.T) {
+	v := Vec2f32{1.9, -2.9}
+	want := Vec2i{1, -2}
+
+	if got := ConvertVec2[int](v); !got.Equals(want) {
+		t.Errorf("ConvertVec2[int](v) = %v, want %v", got, want)
+	}
+
+	if got := Vec2(Vec2Of[float64]{1, 2}); !got.Equals(Vec2{1, 2}) {
+		t.Errorf("Vec2(Vec2Of[float64]) = %v, want %v", got, Vec2{1, 2})
+	}
+	if got := FromVec2[int](Vec2{3.5, 4.5}).Float64(); !got.Equals(Vec2{3, 4}) {
+		t.Errorf("FromVec2[int](v).Float64() = %v, want %v", got, Vec2{3, 4})
+	}
+}
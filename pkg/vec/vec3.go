@@ -1,53 +1,44 @@
 package vec
 
-import (
-	"errors"
-	"math"
-)
+import "math"
 
 // Vec3 represents a vector in 3D space.
 // Many methods are provided - note these are value receivers,
 // and therefore never modify the Vec3 being operated upon.
+//
+// Vec3 has the same layout as a [Vec3Of] with float64 components, and the arithmetic shared by every element type, such as
+// Add and Dot, is implemented once on Vec3Of. Operations that can fail, such as Divide, are implemented
+// here so that their errors name Vec3.
 type Vec3 struct {
 	X, Y, Z float64
 }
 
 // Add computes v1 + v2.
 func (v1 Vec3) Add(v2 Vec3) Vec3 {
-	return Vec3{
-		v1.X + v2.X,
-		v1.Y + v2.Y,
-		v1.Z + v2.Z,
-	}
+	return Vec3(Vec3Of[float64](v1).Add(Vec3Of[float64](v2)))
 }
 
 // Subtract computes v1 - v2.
 func (v1 Vec3) Subtract(v2 Vec3) Vec3 {
-	return Vec3{
-		v1.X - v2.X,
-		v1.Y - v2.Y,
-		v1.Z - v2.Z,
-	}
+	return Vec3(Vec3Of[float64](v1).Subtract(Vec3Of[float64](v2)))
 }
 
 // Dot computes the dot product between v1 and v2.
 func (v1 Vec3) Dot(v2 Vec3) float64 {
-	return v1.X*v2.X + v1.Y*v2.Y + v1.Z*v2.Z
+	return Vec3Of[float64](v1).Dot(Vec3Of[float64](v2))
 }
 
 // Multiply returns this vector multiplied by a scalar value.
 func (v Vec3) Multiply(n float64) Vec3 {
-	return Vec3{
-		v.X * n,
-		v.Y * n,
-		v.Z * n,
-	}
+	return Vec3(Vec3Of[float64](v).Multiply(n))
 }
 
 // Divide returns this vector divided by a scalar value.
+//
+// If n = 0, this function will return an error wrapping [ErrDivideByZero].
 func (v Vec3) Divide(n float64) (Vec3, error) {
 	if n == 0 {
-		return Vec3{}, errors.New("tried to divide by 0")
+		return Vec3{}, NewOpError("Vec3.Divide", ErrDivideByZero, v, n)
 	}
 
 	return Vec3{
@@ -64,12 +55,12 @@ func (v Vec3) Magnitude() float64 {
 
 // Normalised returns the vector in the same direction as this vector with a length of 1.
 //
-// Since a 0-length array has no direction, if |v| = 0 then this function will return an error.
+// Since a 0-length array has no direction, if |v| = 0 then this function will return an error wrapping [ErrZeroLength].
 func (v Vec3) Normalised() (Vec3, error) {
 	magnitude := v.Magnitude()
 
 	if magnitude == 0 {
-		return Vec3{}, errors.New("tried to normalise a 0-length vector")
+		return Vec3{}, NewOpError("Vec3.Normalised", ErrZeroLength, v)
 	}
 
 	return Vec3{
@@ -80,13 +71,15 @@ func (v Vec3) Normalised() (Vec3, error) {
 }
 
 // Angle computes the angle between v1 and v2, in radians.
+//
+// If either vector has a length of 0, this function will return an error wrapping [ErrZeroLength].
 func (v1 Vec3) Angle(v2 Vec3) (float64, error) {
 	if v1.Magnitude() == 0 {
-		return 0, errors.New("v1 length is 0, cannot compute angle")
+		return 0, NewOpError("Vec3.Angle", ErrZeroLength, v1, v2)
 	}
 
 	if v2.Magnitude() == 0 {
-		return 0, errors.New("v2 length is 0, cannot compute angle")
+		return 0, NewOpError("Vec3.Angle", ErrZeroLength, v1, v2)
 	}
 
 	return math.Acos(v1.Dot(v2) / (v1.Magnitude() * v2.Magnitude())), nil
@@ -106,11 +99,7 @@ func (v1 Vec3) Angle(v2 Vec3) (float64, error) {
 //
 // If you wish to have the result clamped between v1 and v2, use [LerpClamped].
 func (v1 Vec3) Lerp(v2 Vec3, t float64) Vec3 {
-	return Vec3{
-		v1.X + t*(v2.X-v1.X),
-		v1.Y + t*(v2.Y-v1.Y),
-		v1.Z + t*(v2.Z-v1.Z),
-	}
+	return Vec3(LerpVec3(Vec3Of[float64](v1), Vec3Of[float64](v2), t))
 }
 
 // LerpClamped linearly interpolates between v1 and v2 by factor t, clamping the result between v1 and v2.
@@ -136,19 +125,59 @@ func (v1 Vec3) LerpClamped(v2 Vec3, t float64) Vec3 {
 
 // Cross returns the cross product of v1 and v2.
 func (v1 Vec3) Cross(v2 Vec3) Vec3 {
-	return Vec3{
-		v1.Y*v2.Z - v1.Z*v2.Y,
-		v1.Z*v2.X - v1.X*v2.Z,
-		v1.X*v2.Y - v1.Y*v2.X,
+	return Vec3(Vec3Of[float64](v1).Cross(Vec3Of[float64](v2)))
+}
+
+// ToHomogeneous returns this vector in homogeneous coordinates, with the given w component.
+//
+// Use w = 1 for points, which are affected by translation, and w = 0 for directions, which are not.
+func (v Vec3) ToHomogeneous(w float64) Vec4 {
+	return Vec4{
+		v.X,
+		v.Y,
+		v.Z,
+		w,
+	}
+}
+
+// ToSpherical returns the vector in spherical coordinates. The result is normalised, as described by [Spherical].
+func (v Vec3) ToSpherical() Spherical {
+	radius := v.Magnitude()
+	if radius == 0 {
+		return Spherical{}
 	}
+
+	// atan2 stays accurate near the poles, where acos(Z / radius) would not
+	horizontal := math.Hypot(v.X, v.Y)
+	inclination := math.Atan2(horizontal, v.Z)
+	if horizontal == 0 {
+		return Spherical{radius, 0, inclination}
+	}
+
+	return Spherical{radius, NormaliseAngleSigned(math.Atan2(v.Y, v.X)), inclination}
+}
+
+// ToCylindrical returns the vector in cylindrical coordinates. The result is normalised, as described by [Cylindrical].
+func (v Vec3) ToCylindrical() Cylindrical {
+	radius := math.Hypot(v.X, v.Y)
+	if radius == 0 {
+		return Cylindrical{0, 0, v.Z}
+	}
+
+	return Cylindrical{radius, NormaliseAngleSigned(math.Atan2(v.Y, v.X)), v.Z}
 }
 
 // Equals returns true if the two vectors are equal.
 func (v1 Vec3) Equals(v2 Vec3) bool {
-	return v1.X == v2.X && v1.Y == v2.Y && v1.Z == v2.Z
+	return Vec3Of[float64](v1).Equals(Vec3Of[float64](v2))
 }
 
 // AlmostEqual returns true if the two vectors are almost equal, within some tolerance threshold.
 func (v1 Vec3) AlmostEquals(v2 Vec3, threshold float64) bool {
-	return math.Abs(v1.X-v2.X) <= threshold && math.Abs(v1.Y-v2.Y) <= threshold && math.Abs(v1.Z-v2.Z) <= threshold
+	return AlmostEqualsVec3(Vec3Of[float64](v1), Vec3Of[float64](v2), threshold)
+}
+
+// EqualsWith returns true if the two vectors are close enough to be treated as equal by c.
+func (v1 Vec3) EqualsWith(v2 Vec3, c Comparator) bool {
+	return c.EqualComponents([]float64{v1.X, v1.Y, v1.Z}, []float64{v2.X, v2.Y, v2.Z})
 }
@@ -0,0 +1,164 @@
+package vec
+
+import "math"
+
+// Vec3Of represents a vector in 3D space, with components of any numeric type T.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Vec3Of being operated upon.
+//
+// For float64 components, prefer [Vec3], which provides the full set of floating point operations.
+// A Vec3Of[float64] may be converted to and from a Vec3 directly, as they share the same layout.
+//
+// Operations that only make sense for floating point components, such as normalisation, are
+// provided as functions constrained to [Float] rather than as methods.
+type Vec3Of[T Number] struct {
+	X, Y, Z T
+}
+
+// Vec3i is a 3D vector with int components, suitable for voxel coordinates.
+type Vec3i = Vec3Of[int]
+
+// Vec3f32 is a 3D vector with float32 components, suitable for uploading to a GPU.
+type Vec3f32 = Vec3Of[float32]
+
+// ConvertVec3 converts the components of v to type U, using Go's numeric conversion rules.
+// In particular, converting floating point components to an integer type truncates towards zero.
+func ConvertVec3[U, T Number](v Vec3Of[T]) Vec3Of[U] {
+	return Vec3Of[U]{
+		U(v.X),
+		U(v.Y),
+		U(v.Z),
+	}
+}
+
+// FromVec3 converts v to a vector with components of type T, using Go's numeric conversion rules.
+func FromVec3[T Number](v Vec3) Vec3Of[T] {
+	return Vec3Of[T]{
+		T(v.X),
+		T(v.Y),
+		T(v.Z),
+	}
+}
+
+// Float64 converts this vector to a [Vec3].
+func (v Vec3Of[T]) Float64() Vec3 {
+	return Vec3{
+		float64(v.X),
+		float64(v.Y),
+		float64(v.Z),
+	}
+}
+
+// Add computes v1 + v2.
+func (v1 Vec3Of[T]) Add(v2 Vec3Of[T]) Vec3Of[T] {
+	return Vec3Of[T]{
+		v1.X + v2.X,
+		v1.Y + v2.Y,
+		v1.Z + v2.Z,
+	}
+}
+
+// Subtract computes v1 - v2.
+func (v1 Vec3Of[T]) Subtract(v2 Vec3Of[T]) Vec3Of[T] {
+	return Vec3Of[T]{
+		v1.X - v2.X,
+		v1.Y - v2.Y,
+		v1.Z - v2.Z,
+	}
+}
+
+// Dot computes the dot product between v1 and v2.
+func (v1 Vec3Of[T]) Dot(v2 Vec3Of[T]) T {
+	return v1.X*v2.X + v1.Y*v2.Y + v1.Z*v2.Z
+}
+
+// Multiply returns this vector multiplied by a scalar value.
+func (v Vec3Of[T]) Multiply(n T) Vec3Of[T] {
+	return Vec3Of[T]{
+		v.X * n,
+		v.Y * n,
+		v.Z * n,
+	}
+}
+
+// Divide returns this vector divided by a scalar value.
+// For integer types, each component is truncated towards zero.
+//
+// If n = 0, this function will return an error wrapping [ErrDivideByZero].
+func (v Vec3Of[T]) Divide(n T) (Vec3Of[T], error) {
+	if n == 0 {
+		return Vec3Of[T]{}, NewOpError("Vec3Of.Divide", ErrDivideByZero, v, n)
+	}
+
+	return Vec3Of[T]{
+		v.X / n,
+		v.Y / n,
+		v.Z / n,
+	}, nil
+}
+
+// Magnitude returns the length of this vector.
+// The computation is done in float64, so integer vectors do not overflow when squaring their components.
+func (v Vec3Of[T]) Magnitude() float64 {
+	return v.Float64().Magnitude()
+}
+
+// Angle computes the angle between v1 and v2, in radians.
+//
+// If either vector has a length of 0, this function will return an error wrapping [ErrZeroLength].
+func (v1 Vec3Of[T]) Angle(v2 Vec3Of[T]) (float64, error) {
+	angle, err := v1.Float64().Angle(v2.Float64())
+	if err != nil {
+		return 0, NewOpError("Vec3Of.Angle", ErrZeroLength, v1, v2)
+	}
+
+	return angle, nil
+}
+
+// Cross returns the cross product of v1 and v2.
+func (v1 Vec3Of[T]) Cross(v2 Vec3Of[T]) Vec3Of[T] {
+	return Vec3Of[T]{
+		v1.Y*v2.Z - v1.Z*v2.Y,
+		v1.Z*v2.X - v1.X*v2.Z,
+		v1.X*v2.Y - v1.Y*v2.X,
+	}
+}
+
+// Equals returns true if the two vectors are equal.
+func (v1 Vec3Of[T]) Equals(v2 Vec3Of[T]) bool {
+	return v1.X == v2.X && v1.Y == v2.Y && v1.Z == v2.Z
+}
+
+// NormalisedVec3 returns the vector in the same direction as v with a length of 1.
+//
+// Since a 0-length array has no direction, if |v| = 0 then this function will return an error wrapping [ErrZeroLength].
+func NormalisedVec3[T Float](v Vec3Of[T]) (Vec3Of[T], error) {
+	magnitude := T(v.Magnitude())
+
+	if magnitude == 0 {
+		return Vec3Of[T]{}, NewOpError("NormalisedVec3", ErrZeroLength, v)
+	}
+
+	return Vec3Of[T]{
+		v.X / magnitude,
+		v.Y / magnitude,
+		v.Z / magnitude,
+	}, nil
+}
+
+// LerpVec3 linearly interpolates between v1 and v2 by factor t.
+//
+// This behaves identically to [Vec3.Lerp].
+func LerpVec3[T Float](v1, v2 Vec3Of[T], t T) Vec3Of[T] {
+	return Vec3Of[T]{
+		v1.X + t*(v2.X-v1.X),
+		v1.Y + t*(v2.Y-v1.Y),
+		v1.Z + t*(v2.Z-v1.Z),
+	}
+}
+
+// AlmostEqualsVec3 returns true if the two vectors are almost equal, within some tolerance threshold.
+func AlmostEqualsVec3[T Float](v1, v2 Vec3Of[T], threshold T) bool {
+	return T(math.Abs(float64(v1.X-v2.X))) <= threshold && T(math.Abs(float64(v1.Y-v2.Y))) <= threshold &&
+		T(math.Abs(float64(v1.Z-v2.Z))) <= threshold
+}
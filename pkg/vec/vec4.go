@@ -0,0 +1,175 @@
+package vec
+
+import "math"
+
+// Vec4 represents a vector in 4D space.
+// It is most commonly used to represent a point or direction in 3D space in homogeneous coordinates.
+// Many methods are provided - note these are value receivers,
+// and therefore never modify the Vec4 being operated upon.
+type Vec4 struct {
+	X, Y, Z, W float64
+}
+
+// Add computes v1 + v2.
+func (v1 Vec4) Add(v2 Vec4) Vec4 {
+	return Vec4{
+		v1.X + v2.X,
+		v1.Y + v2.Y,
+		v1.Z + v2.Z,
+		v1.W + v2.W,
+	}
+}
+
+// Subtract computes v1 - v2.
+func (v1 Vec4) Subtract(v2 Vec4) Vec4 {
+	return Vec4{
+		v1.X - v2.X,
+		v1.Y - v2.Y,
+		v1.Z - v2.Z,
+		v1.W - v2.W,
+	}
+}
+
+// Dot computes the dot product between v1 and v2.
+func (v1 Vec4) Dot(v2 Vec4) float64 {
+	return v1.X*v2.X + v1.Y*v2.Y + v1.Z*v2.Z + v1.W*v2.W
+}
+
+// Multiply returns this vector multiplied by a scalar value.
+func (v Vec4) Multiply(n float64) Vec4 {
+	return Vec4{
+		v.X * n,
+		v.Y * n,
+		v.Z * n,
+		v.W * n,
+	}
+}
+
+// Divide returns this vector divided by a scalar value.
+//
+// If n = 0, this function will return an error wrapping [ErrDivideByZero].
+func (v Vec4) Divide(n float64) (Vec4, error) {
+	if n == 0 {
+		return Vec4{}, NewOpError("Vec4.Divide", ErrDivideByZero, v, n)
+	}
+
+	return Vec4{
+		v.X / n,
+		v.Y / n,
+		v.Z / n,
+		v.W / n,
+	}, nil
+}
+
+// Magnitude returns the length of this vector.
+func (v Vec4) Magnitude() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z + v.W*v.W)
+}
+
+// Normalised returns the vector in the same direction as this vector with a length of 1.
+//
+// Since a 0-length array has no direction, if |v| = 0 then this function will return an error wrapping [ErrZeroLength].
+func (v Vec4) Normalised() (Vec4, error) {
+	magnitude := v.Magnitude()
+
+	if magnitude == 0 {
+		return Vec4{}, NewOpError("Vec4.Normalised", ErrZeroLength, v)
+	}
+
+	return Vec4{
+		v.X / magnitude,
+		v.Y / magnitude,
+		v.Z / magnitude,
+		v.W / magnitude,
+	}, nil
+}
+
+// Angle computes the angle between v1 and v2, in radians.
+//
+// If either vector has a length of 0, this function will return an error wrapping [ErrZeroLength].
+func (v1 Vec4) Angle(v2 Vec4) (float64, error) {
+	if v1.Magnitude() == 0 {
+		return 0, NewOpError("Vec4.Angle", ErrZeroLength, v1, v2)
+	}
+
+	if v2.Magnitude() == 0 {
+		return 0, NewOpError("Vec4.Angle", ErrZeroLength, v1, v2)
+	}
+
+	return math.Acos(v1.Dot(v2) / (v1.Magnitude() * v2.Magnitude())), nil
+}
+
+// Lerp linearly interpolates between v1 and v2 by factor t.
+//
+// Mathematically, this computes v1 * (1 - t) + v2 * t.
+//
+// At t = 0, the result of this function is equal to v1.
+//
+// At t = 1, the result of this function is equal to v2.
+//
+// No safeguards are in place for vales of t that do not satisfy 0 <= t <= 1.
+// Instead, this will extrapolate beyond v1 or v2. For example, values of t > 1 will be in the direction of
+// (v2 - v1). t = -2 will result in a vector equal to v1 * 3 + v2 * -2.
+//
+// If you wish to have the result clamped between v1 and v2, use [LerpClamped].
+func (v1 Vec4) Lerp(v2 Vec4, t float64) Vec4 {
+	return Vec4{
+		v1.X + t*(v2.X-v1.X),
+		v1.Y + t*(v2.Y-v1.Y),
+		v1.Z + t*(v2.Z-v1.Z),
+		v1.W + t*(v2.W-v1.W),
+	}
+}
+
+// LerpClamped linearly interpolates between v1 and v2 by factor t, clamping the result between v1 and v2.
+//
+// Mathematically, this computes v1 * (1 - t) + v2 * t for 0 <= t <= 1.
+//
+// At t <= 0, the result of this function is equal to v1.
+//
+// At t >= 1, the result of this function is equal to v2.
+func (v1 Vec4) LerpClamped(v2 Vec4, t float64) Vec4 {
+	var t_clamped float64
+
+	if t < 0 {
+		t_clamped = 0
+	} else if t > 1 {
+		t_clamped = 1
+	} else {
+		t_clamped = t
+	}
+
+	return v1.Lerp(v2, t_clamped)
+}
+
+// PerspectiveDivide converts this vector from homogeneous coordinates back to a point in 3D space,
+// by dividing the X, Y and Z components by W.
+//
+// Since a W of 0 represents a direction rather than a point, if v.W = 0 then this function will return an error wrapping [ErrDivideByZero].
+func (v Vec4) PerspectiveDivide() (Vec3, error) {
+	if v.W == 0 {
+		return Vec3{}, NewOpError("Vec4.PerspectiveDivide", ErrDivideByZero, v)
+	}
+
+	return Vec3{
+		v.X / v.W,
+		v.Y / v.W,
+		v.Z / v.W,
+	}, nil
+}
+
+// Equals returns true if the two vectors are equal.
+func (v1 Vec4) Equals(v2 Vec4) bool {
+	return v1.X == v2.X && v1.Y == v2.Y && v1.Z == v2.Z && v1.W == v2.W
+}
+
+// AlmostEqual returns true if the two vectors are almost equal, within some tolerance threshold.
+func (v1 Vec4) AlmostEquals(v2 Vec4, threshold float64) bool {
+	return math.Abs(v1.X-v2.X) <= threshold && math.Abs(v1.Y-v2.Y) <= threshold &&
+		math.Abs(v1.Z-v2.Z) <= threshold && math.Abs(v1.W-v2.W) <= threshold
+}
+
+// EqualsWith returns true if the two vectors are close enough to be treated as equal by c.
+func (v1 Vec4) EqualsWith(v2 Vec4, c Comparator) bool {
+	return c.EqualComponents([]float64{v1.X, v1.Y, v1.Z, v1.W}, []float64{v2.X, v2.Y, v2.Z, v2.W})
+}
@@ -0,0 +1,129 @@
+package vec
+
+import "testing"
+
+func TestVec4_Normalised(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       Vec4
+		want    Vec4
+		wantErr bool
+	}{
+		{
+			name:    "(0,0,0,0) = error",
+			v:       Vec4{0, 0, 0, 0},
+			want:    Vec4{0, 0, 0, 0},
+			wantErr: true,
+		},
+		{
+			name:    "(1,1,1,1) = (0.5,0.5,0.5,0.5)",
+			v:       Vec4{1, 1, 1, 1},
+			want:    Vec4{0.5, 0.5, 0.5, 0.5},
+			wantErr: false,
+		},
+		{
+			name:    "(0,0,0,-3) = (0,0,0,-1)",
+			v:       Vec4{0, 0, 0, -3},
+			want:    Vec4{0, 0, 0, -1},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.v.Normalised()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("v.Normalised() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equals(tt.want) {
+				t.Errorf("v.Normalised() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVec4_PerspectiveDivide(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       Vec4
+		want    Vec3
+		wantErr bool
+	}{
+		{
+			name:    "(1,2,3,0) = error",
+			v:       Vec4{1, 2, 3, 0},
+			want:    Vec3{},
+			wantErr: true,
+		},
+		{
+			name:    "(1,2,3,1) = (1,2,3)",
+			v:       Vec4{1, 2, 3, 1},
+			want:    Vec3{1, 2, 3},
+			wantErr: false,
+		},
+		{
+			name:    "(2,4,6,2) = (1,2,3)",
+			v:       Vec4{2, 4, 6, 2},
+			want:    Vec3{1, 2, 3},
+			wantErr: false,
+		},
+		{
+			name:    "(1,2,3,-0.5) = (-2,-4,-6)",
+			v:       Vec4{1, 2, 3, -0.5},
+			want:    Vec3{-2, -4, -6},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.v.PerspectiveDivide()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("v.PerspectiveDivide() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equals(tt.want) {
+				t.Errorf("v.PerspectiveDivide() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVec3_ToHomogeneous(t *testing.T) {
+	translate := Mat4{
+		{1, 0, 0, 1},
+		{0, 1, 0, 2},
+		{0, 0, 1, 3},
+		{0, 0, 0, 1},
+	}
+
+	tests := []struct {
+		name string
+		v    Vec3
+		w    float64
+		want Vec3
+	}{
+		{
+			name: "point (1,1,1) is translated",
+			v:    Vec3{1, 1, 1},
+			w:    1,
+			want: Vec3{2, 3, 4},
+		},
+		{
+			name: "point (1,1,1) with w = 2 is translated",
+			v:    Vec3{1, 1, 1},
+			w:    2,
+			want: Vec3{1.5, 2.5, 3.5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := translate.MulVec4(tt.v.ToHomogeneous(tt.w)).PerspectiveDivide()
+			if err != nil {
+				t.Fatalf("PerspectiveDivide() error = %v", err)
+			}
+			if !got.Equals(tt.want) {
+				t.Errorf("m.MulVec4(v.ToHomogeneous(w)).PerspectiveDivide() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package vectest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// maxReported is the most elements of a slice whose differences are printed in one failure.
+const maxReported = 10
+
+// component is one named component of a value being compared.
+type component struct {
+	name      string
+	got, want float64
+}
+
+// AssertFloatNear reports an error on tb if got and want are not equal according to c.
+// It returns true if they are equal.
+func AssertFloatNear(tb testing.TB, got, want float64, c vec.Comparator) bool {
+	tb.Helper()
+	return assertNear(tb, "float64", []component{{"", got, want}}, c)
+}
+
+// AssertVec2Near reports an error on tb if got and want are not equal according to c, printing each
+// component of both vectors and their differences. It returns true if they are equal.
+func AssertVec2Near(tb testing.TB, got, want vec.Vec2, c vec.Comparator) bool {
+	tb.Helper()
+	return assertNear(tb, "Vec2", vec2Components("", got, want), c)
+}
+
+// AssertVec3Near reports an error on tb if got and want are not equal according to c, printing each
+// component of both vectors and their differences. It returns true if they are equal.
+func AssertVec3Near(tb testing.TB, got, want vec.Vec3, c vec.Comparator) bool {
+	tb.Helper()
+	return assertNear(tb, "Vec3", vec3Components("", got, want), c)
+}
+
+// AssertVec4Near reports an error on tb if got and want are not equal according to c, printing each
+// component of both vectors and their differences. It returns true if they are equal.
+func AssertVec4Near(tb testing.TB, got, want vec.Vec4, c vec.Comparator) bool {
+	tb.Helper()
+	return assertNear(tb, "Vec4", []component{
+		{"X", got.X, want.X},
+		{"Y", got.Y, want.Y},
+		{"Z", got.Z, want.Z},
+		{"W", got.W, want.W},
+	}, c)
+}
+
+// AssertQuatNear reports an error on tb if got and want are not equal according to c, printing each
+// component of both quaternions and their differences. It returns true if they are equal.
+//
+// Components are compared directly, so q and -q are not equal even though they represent the same rotation.
+func AssertQuatNear(tb testing.TB, got, want vec.Quat, c vec.Comparator) bool {
+	tb.Helper()
+	return assertNear(tb, "Quat", []component{
+		{"W", got.W, want.W},
+		{"X", got.X, want.X},
+		{"Y", got.Y, want.Y},
+		{"Z", got.Z, want.Z},
+	}, c)
+}
+
+// AssertMat2Near reports an error on tb if got and want are not equal according to c, printing each
+// element of both matrices and their differences. It returns true if they are equal.
+func AssertMat2Near(tb testing.TB, got, want vec.Mat2, c vec.Comparator) bool {
+	tb.Helper()
+
+	var components []component
+	for i := range 2 {
+		components = append(components, matrixRow(i, got[i][:], want[i][:])...)
+	}
+
+	return assertNear(tb, "Mat2", components, c)
+}
+
+// AssertMat3Near reports an error on tb if got and want are not equal according to c, printing each
+// element of both matrices and their differences. It returns true if they are equal.
+func AssertMat3Near(tb testing.TB, got, want vec.Mat3, c vec.Comparator) bool {
+	tb.Helper()
+
+	var components []component
+	for i := range 3 {
+		components = append(components, matrixRow(i, got[i][:], want[i][:])...)
+	}
+
+	return assertNear(tb, "Mat3", components, c)
+}
+
+// AssertMat4Near reports an error on tb if got and want are not equal according to c, printing each
+// element of both matrices and their differences. It returns true if they are equal.
+func AssertMat4Near(tb testing.TB, got, want vec.Mat4, c vec.Comparator) bool {
+	tb.Helper()
+
+	var components []component
+	for i := range 4 {
+		components = append(components, matrixRow(i, got[i][:], want[i][:])...)
+	}
+
+	return assertNear(tb, "Mat4", components, c)
+}
+
+// AssertVec2sNear reports an error on tb if got and want differ in length, or any pair of corresponding
+// vectors are not equal according to c. Each differing pair is printed, up to a limit.
+// It returns true if the slices are equal.
+func AssertVec2sNear(tb testing.TB, got, want []vec.Vec2, c vec.Comparator) bool {
+	tb.Helper()
+
+	if len(got) != len(want) {
+		tb.Errorf("[]Vec2 lengths differ: got %v vectors, want %v\ngot:  %v\nwant: %v", len(got), len(want), got, want)
+		return false
+	}
+
+	elements := make([][]component, len(got))
+	for i := range got {
+		elements[i] = vec2Components(fmt.Sprintf("[%v].", i), got[i], want[i])
+	}
+
+	return assertSliceNear(tb, "[]Vec2", elements, c)
+}
+
+// AssertVec3sNear reports an error on tb if got and want differ in length, or any pair of corresponding
+// vectors are not equal according to c. Each differing pair is printed, up to a limit.
+// It returns true if the slices are equal.
+func AssertVec3sNear(tb testing.TB, got, want []vec.Vec3, c vec.Comparator) bool {
+	tb.Helper()
+
+	if len(got) != len(want) {
+		tb.Errorf("[]Vec3 lengths differ: got %v vectors, want %v\ngot:  %v\nwant: %v", len(got), len(want), got, want)
+		return false
+	}
+
+	elements := make([][]component, len(got))
+	for i := range got {
+		elements[i] = vec3Components(fmt.Sprintf("[%v].", i), got[i], want[i])
+	}
+
+	return assertSliceNear(tb, "[]Vec3", elements, c)
+}
+
+func vec2Components(prefix string, got, want vec.Vec2) []component {
+	return []component{
+		{prefix + "X", got.X, want.X},
+		{prefix + "Y", got.Y, want.Y},
+	}
+}
+
+func vec3Components(prefix string, got, want vec.Vec3) []component {
+	return []component{
+		{prefix + "X", got.X, want.X},
+		{prefix + "Y", got.Y, want.Y},
+		{prefix + "Z", got.Z, want.Z},
+	}
+}
+
+// matrixRow returns the elements of row i of two matrices, named by their position.
+func matrixRow(i int, got, want []float64) []component {
+	components := make([]component, len(got))
+	for j := range got {
+		components[j] = component{fmt.Sprintf("[%v][%v]", i, j), got[j], want[j]}
+	}
+	return components
+}
+
+// equal returns true if every component is equal according to c.
+func equal(components []component, c vec.Comparator) bool {
+	got := make([]float64, len(components))
+	want := make([]float64, len(components))
+	for i, comp := range components {
+		got[i], want[i] = comp.got, comp.want
+	}
+
+	return c.EqualComponents(got, want)
+}
+
+func assertNear(tb testing.TB, kind string, components []component, c vec.Comparator) bool {
+	tb.Helper()
+
+	if equal(components, c) {
+		return true
+	}
+
+	tb.Errorf("%v values differ, want them equal %v\n%v", kind, c, diff(components, c))
+	return false
+}
+
+func assertSliceNear(tb testing.TB, kind string, elements [][]component, c vec.Comparator) bool {
+	tb.Helper()
+
+	var differing []component
+	count := 0
+	for _, components := range elements {
+		if equal(components, c) {
+			continue
+		}
+
+		count++
+		if count <= maxReported {
+			differing = append(differing, components...)
+		}
+	}
+
+	if count == 0 {
+		return true
+	}
+
+	message := fmt.Sprintf("%v values differ at %v of %v elements, want them equal %v\n%v", kind, count, len(elements), c, diff(differing, c))
+	if count > maxReported {
+		message += fmt.Sprintf("... and %v more differing elements\n", count-maxReported)
+	}
+
+	tb.Errorf("%v", message)
+	return false
+}
+
+// diff returns a table of the components, with their differences, marking each component that is not
+// equal according to c on its own.
+func diff(components []component, c vec.Comparator) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(w, "\tgot\twant\tgot - want\t\t")
+	distance := 0.0
+	for _, comp := range components {
+		d := comp.got - comp.want
+		if comp.got == comp.want {
+			// matching infinities have no difference
+			d = 0
+		}
+		distance += d * d
+
+		mark := ""
+		if !c.Equal(comp.got, comp.want) {
+			mark = "<"
+		}
+
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", comp.name, comp.got, comp.want, d, mark)
+	}
+	w.Flush()
+
+	if len(components) > 1 {
+		fmt.Fprintf(&b, "distance: %v\n", math.Sqrt(distance))
+	}
+
+	return b.String()
+}
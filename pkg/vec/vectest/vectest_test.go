@@ -0,0 +1,206 @@
+package vectest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/michael-ryan/mikelib/pkg/vec"
+)
+
+// recorder is a testing.TB that records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertVec2Near(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want vec.Vec2
+		c         vec.Comparator
+		pass      bool
+		contains  []string
+	}{
+		{
+			"rounding",
+			vec.Vec2{X: -3, Y: 2.01}.Add(vec.Vec2{X: 2.05, Y: 0.003}),
+			vec.Vec2{X: -0.95, Y: 2.013},
+			vec.ULPTolerance(4),
+			true,
+			nil,
+		},
+		{
+			"equal",
+			vec.Vec2{X: 1, Y: 2},
+			vec.Vec2{X: 1, Y: 2},
+			vec.AbsoluteTolerance(0),
+			true,
+			nil,
+		},
+		{
+			"differing component marked",
+			vec.Vec2{X: 1, Y: 2.5},
+			vec.Vec2{X: 1, Y: 2},
+			vec.AbsoluteTolerance(0.1),
+			false,
+			[]string{"Vec2 values differ", "within an absolute tolerance of 0.1", "Y 2.5 2 0.5 <", "distance: 0.5"},
+		},
+		{
+			"NaN",
+			vec.Vec2{X: math.NaN(), Y: 2},
+			vec.Vec2{X: 1, Y: 2},
+			vec.AbsoluteTolerance(0.1),
+			false,
+			[]string{"NaN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			if pass := AssertVec2Near(r, tt.got, tt.want, tt.c); pass != tt.pass {
+				t.Errorf("got %v, want %v", pass, tt.pass)
+			}
+			checkReport(t, r, tt.pass, tt.contains)
+		})
+	}
+}
+
+func TestAssertVec3Near(t *testing.T) {
+	r := &recorder{}
+	if AssertVec3Near(r, vec.Vec3{X: 1, Y: 2, Z: 3}, vec.Vec3{X: 1, Y: 2, Z: 3 + 1e-12}, vec.RelativeTolerance(1e-9)) {
+		checkReport(t, r, true, nil)
+	} else {
+		t.Errorf("got false, want true")
+	}
+
+	r = &recorder{}
+	if AssertVec3Near(r, vec.Vec3{X: 1, Y: 2, Z: 3}, vec.Vec3{X: 1, Y: 2, Z: 4}, vec.EuclideanTolerance(0.5)) {
+		t.Errorf("got true, want false")
+	}
+	checkReport(t, r, false, []string{"Vec3 values differ", "within a distance of 0.5", "Z 3 4 -1 <"})
+}
+
+func TestAssertQuatNear(t *testing.T) {
+	r := &recorder{}
+	if AssertQuatNear(r, vec.Quat{W: 1}, vec.Quat{W: -1}, vec.AbsoluteTolerance(1e-9)) {
+		t.Errorf("got true, want false")
+	}
+	checkReport(t, r, false, []string{"Quat values differ", "W 1 -1 2 <"})
+}
+
+func TestAssertMat2Near(t *testing.T) {
+	got := vec.Mat2{{1, 2}, {3, 4}}
+	want := vec.Mat2{{1, 2}, {3.5, 4}}
+
+	r := &recorder{}
+	if AssertMat2Near(r, got, want, vec.AbsoluteTolerance(0.1)) {
+		t.Errorf("got true, want false")
+	}
+	checkReport(t, r, false, []string{"Mat2 values differ", "[1][0] 3 3.5 -0.5 <", "[0][1] 2 2 0 [1][0]"})
+
+	r = &recorder{}
+	if !AssertMat2Near(r, got, got, vec.AbsoluteTolerance(0)) {
+		t.Errorf("got false, want true")
+	}
+	checkReport(t, r, true, nil)
+}
+
+func TestAssertVec2sNear(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want []vec.Vec2
+		pass      bool
+		contains  []string
+	}{
+		{
+			"equal",
+			[]vec.Vec2{{X: 1}, {Y: 1}},
+			[]vec.Vec2{{X: 1}, {Y: 1}},
+			true,
+			nil,
+		},
+		{
+			"empty",
+			nil,
+			[]vec.Vec2{},
+			true,
+			nil,
+		},
+		{
+			"length mismatch",
+			[]vec.Vec2{{X: 1}},
+			[]vec.Vec2{{X: 1}, {Y: 1}},
+			false,
+			[]string{"lengths differ: got 1 vectors, want 2"},
+		},
+		{
+			"one element differs",
+			[]vec.Vec2{{X: 1}, {Y: 1}, {X: 2, Y: 2}},
+			[]vec.Vec2{{X: 1}, {Y: 1.5}, {X: 2, Y: 2}},
+			false,
+			[]string{"differ at 1 of 3 elements", "[1].Y", "[1].X"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			if pass := AssertVec2sNear(r, tt.got, tt.want, vec.AbsoluteTolerance(1e-9)); pass != tt.pass {
+				t.Errorf("got %v, want %v", pass, tt.pass)
+			}
+			checkReport(t, r, tt.pass, tt.contains)
+		})
+	}
+}
+
+func TestAssertVec3sNear(t *testing.T) {
+	got := make([]vec.Vec3, 15)
+	want := make([]vec.Vec3, 15)
+	for i := range want {
+		want[i] = vec.Vec3{X: float64(i)}
+	}
+
+	r := &recorder{}
+	if AssertVec3sNear(r, got, want, vec.AbsoluteTolerance(1e-9)) {
+		t.Errorf("got true, want false")
+	}
+	checkReport(t, r, false, []string{"differ at 14 of 15 elements", "[10].X", "... and 4 more differing elements"})
+
+	if strings.Contains(r.errors[0], "[11].X") {
+		t.Errorf("report should stop after %v elements, got:\n%v", maxReported, r.errors[0])
+	}
+}
+
+// checkReport checks that r recorded no errors if pass is true, or else exactly one error containing
+// each of the given strings. Runs of whitespace are treated as a single space, so that table columns
+// can be matched regardless of their width.
+func checkReport(t *testing.T, r *recorder, pass bool, contains []string) {
+	t.Helper()
+
+	if pass {
+		if len(r.errors) != 0 {
+			t.Errorf("got errors %v, want none", r.errors)
+		}
+		return
+	}
+
+	if len(r.errors) != 1 {
+		t.Fatalf("got %v errors, want 1", len(r.errors))
+	}
+
+	report := strings.Join(strings.Fields(r.errors[0]), " ")
+	for _, s := range contains {
+		if !strings.Contains(report, s) {
+			t.Errorf("report does not contain %q, got:\n%v", s, r.errors[0])
+		}
+	}
+}